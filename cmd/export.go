@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/config"
+	"github.com/Util787/user-manager-api/internal/logger/sl"
+	"github.com/Util787/user-manager-api/internal/repository"
+	service "github.com/Util787/user-manager-api/internal/services"
+)
+
+// runExport writes users to csv, ndjson or json file, the same way as GET /api/users/export
+//
+// Example: go run ./cmd export -file users.csv -gender female
+func runExport(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	filePath := flags.String("file", "", "path to output file (required)")
+	format := flags.String("format", "", "csv, ndjson or json, taken from file extension if empty")
+	var filter entities.UserFilter
+	flags.StringVar(&filter.Name, "name", "", "name filter")
+	flags.StringVar(&filter.Surname, "surname", "", "surname filter")
+	flags.StringVar(&filter.Patronymic, "patronymic", "", "patronymic filter")
+	flags.StringVar(&filter.Gender, "gender", "", "gender filter can be only male or female")
+	filterExpr := flags.String("filter", "", `filter expression, example: age>30 and nationality in ["BY","UA"]`)
+	flags.Parse(args)
+
+	servConfig := config.InitServerConfig()
+	log := setupLogger(servConfig.Env)
+
+	if *filePath == "" {
+		log.Error("-file flag is required")
+		flags.Usage()
+		return
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*filePath)), ".")
+	}
+	if filter.Gender != "" && !service.IsValidGender(filter.Gender) {
+		log.Error("Gender filter can be only male or female")
+		return
+	}
+	expr, err := service.ParseFilterExpr(*filterExpr)
+	if err != nil {
+		log.Error("Invalid filter", sl.Err(err))
+		return
+	}
+	filter.Expr = expr
+
+	dbConfig := config.InitDbConfig()
+	postgresDB, err := repository.NewPostgresDB(*dbConfig)
+	if err != nil {
+		log.Error("Failed to connect to db", sl.Err(err))
+		return
+	}
+	defer postgresDB.Close()
+
+	file, err := os.Create(*filePath)
+	if err != nil {
+		log.Error("Failed to create export file", sl.Err(err))
+		return
+	}
+	defer file.Close()
+
+	exportService := service.NewExportService(repository.NewUserRepository(postgresDB), repository.NewAttributeRepository(postgresDB))
+
+	log.Info("Exporting users", slog.String("file", *filePath), slog.String("format", *format), slog.Any("filter", filter))
+	err = exportService.ExportUsers(context.Background(), file, *format, filter)
+	if err != nil {
+		log.Error("Failed to export users", sl.Err(err))
+		return
+	}
+	log.Info("Exported users successfully", slog.String("file", *filePath))
+}
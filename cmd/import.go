@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Util787/user-manager-api/internal/config"
+	"github.com/Util787/user-manager-api/internal/logger/sl"
+	"github.com/Util787/user-manager-api/internal/repository"
+	service "github.com/Util787/user-manager-api/internal/services"
+)
+
+// runImport creates users from csv or ndjson file, the same way as POST /api/users/import
+//
+// Example: go run ./cmd import -file users.csv -enrich -report report.json
+func runImport(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	filePath := flags.String("file", "", "path to csv or ndjson file (required)")
+	format := flags.String("format", "", "csv or ndjson, taken from file extension if empty")
+	enrich := flags.Bool("enrich", false, "fill missing age, gender and nationality from external apis")
+	reportPath := flags.String("report", "", "path to write json report to, stdout if empty")
+	flags.Parse(args)
+
+	servConfig := config.InitServerConfig()
+	log := setupLogger(servConfig.Env)
+
+	if *filePath == "" {
+		log.Error("-file flag is required")
+		flags.Usage()
+		return
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*filePath)), ".")
+	}
+
+	dbConfig := config.InitDbConfig()
+	postgresDB, err := repository.NewPostgresDB(*dbConfig)
+	if err != nil {
+		log.Error("Failed to connect to db", sl.Err(err))
+		return
+	}
+	defer postgresDB.Close()
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		log.Error("Failed to open import file", sl.Err(err))
+		return
+	}
+	defer file.Close()
+
+	namePolicy, err := service.NewNamePolicy(*config.InitNamePolicyConfig())
+	if err != nil {
+		log.Error("Invalid name policy config", sl.Err(err))
+		return
+	}
+
+	// cli doesnt connect to redis, cached stats just expire by ttl
+	importService := service.NewImportService(repository.NewUserRepository(postgresDB), service.NewInfoRequestService(), nil, namePolicy, repository.NewAttributeRepository(postgresDB))
+
+	log.Info("Importing users", slog.String("file", *filePath), slog.String("format", *format), slog.Bool("enrich", *enrich))
+	report, err := importService.ImportUsers(file, *format, *enrich)
+	if err != nil {
+		// rows handled before the error stay imported, their report is still written
+		log.Error("Failed to import users", slog.Int("imported", len(report.Accepted)), sl.Err(err))
+		if report.Total == 0 {
+			return
+		}
+	}
+	for _, rejected := range report.Rejected {
+		log.Warn("Row rejected", slog.Int("row", rejected.Row), slog.String("code", rejected.Code), slog.String("reason", rejected.Reason))
+	}
+	log.Info("Imported users", slog.Int("total", report.Total), slog.Int("accepted", len(report.Accepted)), slog.Int("rejected", len(report.Rejected)))
+
+	out := os.Stdout
+	if *reportPath != "" {
+		out, err = os.Create(*reportPath)
+		if err != nil {
+			log.Error("Failed to create report file", sl.Err(err))
+			return
+		}
+		defer out.Close()
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Error("Failed to write report", sl.Err(err))
+	}
+}
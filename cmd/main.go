@@ -14,6 +14,7 @@ import (
 	"github.com/Util787/user-manager-api/internal/handlers"
 	"github.com/Util787/user-manager-api/internal/logger/handlers/slogpretty"
 	"github.com/Util787/user-manager-api/internal/logger/sl"
+	"github.com/Util787/user-manager-api/internal/notifier"
 	"github.com/Util787/user-manager-api/internal/repository"
 	service "github.com/Util787/user-manager-api/internal/services"
 )
@@ -27,11 +28,41 @@ const (
 // @title           User manager api
 // @version         1.0
 // @description     Rest api for managing users crud operations
+// @description
+// @description     Errors are RFC 7807 problem details sent as application/problem+json. `code` is stable and can be checked by clients:
+// @description     invalid_body (400), invalid_parameter (400), invalid_filter (400), validation_failed (400, invalid fields are listed in `errors`),
+// @description     bulk_limit_exceeded (400), not_found (404), conflict (409), patch_failed (409), payload_too_large (413), unsupported_media_type (415),
+// @description     too_many_requests (429), internal_error (500), enrichment_failed (502).
+// @description     `instance` is the id of the request operation, the same as in server logs
+// @description     Titles, details and field messages are in english or russian, language is chosen by Accept-Language header, english by default.
+// @description     Field errors have their own stable `code`: name_too_short, name_too_long, name_invalid_chars, name_mixed_scripts, name_invalid_separators,
+// @description     name_not_title_case, name_not_capitalized, invalid_gender, required_gender, negative_age, no_fields, self_merge, unknown_field, invalid_prefer,
+// @description     required, invalid_type, read_only, not_in_enum, pattern_mismatch, invalid_attribute_name, invalid_attribute_type, invalid_pattern, pattern_not_string,
+// @description     invalid_tag, tag_added_and_removed, parent_group_not_found, group_cycle, invalid_group_role, invalid_email, invalid_phone,
+// @description     invalid_contact_type, invalid_verification_code
 
 // @host      localhost:8000
 // @BasePath  /api
 
 func main() {
+	// subcommands run instead of the server
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import":
+			runImport(os.Args[2:])
+			return
+		case "export":
+			runExport(os.Args[2:])
+			return
+		case "reindex":
+			runReindex(os.Args[2:])
+			return
+		case "uniqueness":
+			runUniqueness(os.Args[2:])
+			return
+		}
+	}
+
 	servConfig := config.InitServerConfig()
 
 	log := setupLogger(servConfig.Env)
@@ -54,9 +85,22 @@ func main() {
 	}
 	log.Info("Connected to redis successfully")
 
+	namePolicy, err := service.NewNamePolicy(*config.InitNamePolicyConfig())
+	if err != nil {
+		log.Error("Invalid name policy config", sl.Err(err))
+		return
+	}
+
+	codeNotifier, err := notifier.New(*config.InitNotifierConfig(), log)
+	if err != nil {
+		log.Error("Invalid notifier config", sl.Err(err))
+		return
+	}
+	verificationCfg := config.InitVerificationConfig()
+
 	//layers
 	repos := repository.NewRepository(postgresDB, redis)
-	services := service.NewService(repos)
+	services := service.NewService(repos, namePolicy, codeNotifier, verificationCfg.Secret, log)
 	handlers := handlers.NewHandlers(services, log)
 
 	//server start
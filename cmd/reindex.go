@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+
+	"github.com/Util787/user-manager-api/internal/config"
+	"github.com/Util787/user-manager-api/internal/logger/sl"
+	"github.com/Util787/user-manager-api/internal/repository"
+)
+
+// runReindex fills search keys of existing users, run it once after 000004_add_search_keys migration
+//
+// Example: go run ./cmd reindex -batch 500
+func runReindex(args []string) {
+	flags := flag.NewFlagSet("reindex", flag.ExitOnError)
+	batchSize := flags.Int("batch", 1000, "number of users updated in one transaction")
+	flags.Parse(args)
+
+	servConfig := config.InitServerConfig()
+	log := setupLogger(servConfig.Env)
+
+	if *batchSize <= 0 {
+		log.Error("-batch must be positive")
+		return
+	}
+
+	dbConfig := config.InitDbConfig()
+	postgresDB, err := repository.NewPostgresDB(*dbConfig)
+	if err != nil {
+		log.Error("Failed to connect to db", sl.Err(err))
+		return
+	}
+	defer postgresDB.Close()
+
+	log.Info("Reindexing search keys", slog.Int("batch", *batchSize))
+	updated, err := repository.NewUserRepository(postgresDB).ReindexSearchKeys(context.Background(), *batchSize)
+	if err != nil {
+		log.Error("Failed to reindex search keys", slog.Int("updated", updated), sl.Err(err))
+		return
+	}
+	log.Info("Reindexed search keys successfully", slog.Int("updated", updated))
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+
+	"github.com/Util787/user-manager-api/internal/config"
+	"github.com/Util787/user-manager-api/internal/logger/sl"
+	"github.com/Util787/user-manager-api/internal/repository"
+)
+
+// runUniqueness changes which users must have unique full names, 000006_add_full_name_unique_index sets patronymic scope
+//
+// Example: go run ./cmd uniqueness -scope all
+func runUniqueness(args []string) {
+	flags := flag.NewFlagSet("uniqueness", flag.ExitOnError)
+	scope := flags.String("scope", repository.FullNameUniqueWithPatronymic, "patronymic - only users with patronymic, all - every user, off - no check")
+	flags.Parse(args)
+
+	servConfig := config.InitServerConfig()
+	log := setupLogger(servConfig.Env)
+
+	dbConfig := config.InitDbConfig()
+	postgresDB, err := repository.NewPostgresDB(*dbConfig)
+	if err != nil {
+		log.Error("Failed to connect to db", sl.Err(err))
+		return
+	}
+	defer postgresDB.Close()
+
+	log.Info("Changing full name uniqueness", slog.String("scope", *scope))
+	err = repository.NewUserRepository(postgresDB).SetFullNameUniqueness(context.Background(), *scope)
+	if err != nil {
+		log.Error("Failed to change full name uniqueness", sl.Err(err))
+		return
+	}
+	log.Info("Changed full name uniqueness successfully", slog.String("scope", *scope))
+}
@@ -15,9 +15,35 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/users": {
+        "/attributes": {
             "get": {
-                "description": "Get users using flexible query filters and pagination. You can provide partial values for ` + "`" + `name` + "`" + `, ` + "`" + `surname` + "`" + `, or ` + "`" + `patronymic` + "`" + ` — filtering will still work. Each of these parameters is optional and can be used independently or in combination.\n\nExample: ?page=5\u0026page_size=10\nResponse: 10 users with offset=40\n\nExample2: ?name=al\nResponse: Alex, Alina, etc.\n\nExample3: ?name=al\u0026surname=sh\nResponse: Alexandr Shprot, Alina Sham, etc.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attributes"
+                ],
+                "summary": "get all attribute definitions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.AttributeDefinition"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Defines a custom attribute of users. Values of attributes are checked against definitions when users are created and updated: type (string, number or boolean), required, enum of allowed values and pattern (RE2, the whole string value must match).\nUsers without a new required attribute are checked on their next change of attributes",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,65 +51,232 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "attributes"
                 ],
-                "summary": "get all users with optionally filters and pagination",
+                "summary": "create attribute definition",
+                "parameters": [
+                    {
+                        "description": "created_at and updated_at are ignored",
+                        "name": "definition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.AttributeDefinition"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.AttributeDefinition"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_body, validation_failed: invalid fields are listed in errors",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: attribute with the same name exists",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/attributes/{name}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attributes"
+                ],
+                "summary": "get attribute definition",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "name filter",
+                        "description": "attribute name",
                         "name": "name",
-                        "in": "query"
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.AttributeDefinition"
+                        }
                     },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "All fields of the definition are replaced, name is taken from the path. Existing values of users are not checked against the changed definition, they are checked on the next change of user attributes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attributes"
+                ],
+                "summary": "replace attribute definition",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "surname filter",
-                        "name": "surname",
-                        "in": "query"
+                        "description": "attribute name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "patronymic filter",
-                        "name": "patronymic",
-                        "in": "query"
+                        "description": "name, created_at and updated_at are ignored",
+                        "name": "definition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.AttributeDefinition"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.AttributeDefinition"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_body, validation_failed: invalid fields are listed in errors",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "The attribute is removed from all users. Cached users may return the removed attribute until their cache expires",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attributes"
+                ],
+                "summary": "delete attribute definition",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "gender filter can be only male or female",
-                        "name": "gender",
-                        "in": "query"
+                        "description": "attribute name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups": {
+            "get": {
+                "description": "Groups ordered by id with page number pagination, ` + "`" + `parent_id` + "`" + ` lists only direct subgroups of the group, 0 lists top level groups",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "get groups",
+                "parameters": [
                     {
                         "type": "integer",
-                        "description": "min:5",
+                        "description": "min:5, max:50",
                         "name": "page_size",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "min:1",
+                        "description": "page number",
                         "name": "page",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "parent group id, 0 for top level groups",
+                        "name": "parent_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entities.User"
-                            }
+                            "$ref": "#/definitions/entities.GroupsPage"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "invalid_parameter",
                         "schema": {
                             "$ref": "#/definitions/internal_handlers.errorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "internal_error",
                         "schema": {
                             "$ref": "#/definitions/internal_handlers.errorResponse"
                         }
@@ -91,7 +284,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "creating new user with provided name, surname, patronymic(optional)",
+                "description": "Creates a group, parent_id makes it a subgroup. Names are trimmed and must be unique among subgroups of the same parent (case insensitive)",
                 "consumes": [
                     "application/json"
                 ],
@@ -99,38 +292,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "groups"
                 ],
-                "summary": "create user",
+                "summary": "create group",
                 "parameters": [
                     {
-                        "description": "Users fullname: name, surname, patronymic(optional)",
-                        "name": "fullname",
+                        "description": "group to create",
+                        "name": "group",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entities.FullName"
+                            "$ref": "#/definitions/entities.CreateGroupParams"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "message with created user's id",
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/entities.Group"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "invalid_body, validation_failed: invalid fields are listed in errors",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: group with the same name exists in the parent",
                         "schema": {
                             "$ref": "#/definitions/internal_handlers.errorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "internal_error",
                         "schema": {
                             "$ref": "#/definitions/internal_handlers.errorResponse"
                         }
@@ -138,21 +334,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{user_id}": {
+        "/groups/{group_id}": {
             "get": {
-                "description": "recieve user info by providing id in path",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "groups"
                 ],
-                "summary": "get user by id",
+                "summary": "get group by id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "user_id",
-                        "name": "user_id",
+                        "description": "group_id",
+                        "name": "group_id",
                         "in": "path",
                         "required": true
                     }
@@ -161,17 +356,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entities.User"
+                            "$ref": "#/definitions/entities.Group"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
                         "schema": {
                             "$ref": "#/definitions/internal_handlers.errorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "internal_error",
                         "schema": {
                             "$ref": "#/definitions/internal_handlers.errorResponse"
                         }
@@ -179,22 +380,19 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "deleting user by id if exists",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Memberships of the group are deleted too. Groups with subgroups cant be deleted, move or delete subgroups first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "groups"
                 ],
-                "summary": "delete user by id",
+                "summary": "delete group by id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "user_id",
-                        "name": "user_id",
+                        "description": "group_id",
+                        "name": "group_id",
                         "in": "path",
                         "required": true
                     }
@@ -210,13 +408,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: group has subgroups",
                         "schema": {
                             "$ref": "#/definitions/internal_handlers.errorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "internal_error",
                         "schema": {
                             "$ref": "#/definitions/internal_handlers.errorResponse"
                         }
@@ -224,7 +434,7 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "updating user info by id provided in path. In request body you can optionally provide: name, surname, patronymic, age, gender, nationality. Update_at will change automatically",
+                "description": "Only provided fields are changed. parent_id moves the group with all its subgroups, 0 moves it to the top level.\nThe group cant be moved into itself or its subgroups",
                 "consumes": [
                     "application/json"
                 ],
@@ -232,78 +442,3035 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "groups"
                 ],
-                "summary": "update user info by id",
+                "summary": "update group by id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "user_id",
-                        "name": "user_id",
+                        "description": "group_id",
+                        "name": "group_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "parameters for update",
-                        "name": "user",
+                        "description": "fields to change",
+                        "name": "group",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entities.UpdateUserParams"
+                            "$ref": "#/definitions/entities.UpdateGroupParams"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "message about user update",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/entities.Group"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: group with the same name exists in the parent",
                         "schema": {
                             "$ref": "#/definitions/internal_handlers.errorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "internal_error",
                         "schema": {
                             "$ref": "#/definitions/internal_handlers.errorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entities.FullName": {
-            "type": "object",
-            "required": [
-                "name",
-                "surname"
-            ],
-            "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "patronymic": {
-                    "type": "string"
+        },
+        "/groups/{group_id}/members": {
+            "get": {
+                "description": "Members of the group with their roles and user data, deleted users are skipped.\nWith ` + "`" + `recursive=true` + "`" + ` members of all subgroups are listed too, group_id of a member is the group the user is a direct member of",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "get members of group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "group_id",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include members of subgroups",
+                        "name": "recursive",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.GroupMember"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{group_id}/members/{user_id}": {
+            "put": {
+                "description": "Adds the user to the group with the role (member if empty) or changes the role of existing member. Roles are owner, admin and member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "add user to group or change role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "group_id",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "role of the user",
+                        "name": "member",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/entities.GroupMemberParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.GroupMember"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: group or user doesnt exist",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Only direct membership is removed, the user stays in subgroups of the group",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "remove user from group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "group_id",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successful removing message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: user isnt a member of the group",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Get users using flexible query filters and pagination. You can provide partial values for ` + "`" + `name` + "`" + `, ` + "`" + `surname` + "`" + `, or ` + "`" + `patronymic` + "`" + ` — filtering will still work. Each of these parameters is optional and can be used independently or in combination.\n\nExample: ?page=5\u0026page_size=10\nResponse: 10 users with offset=40\n\nExample2: ?name=al\nResponse: Alex, Alina, etc.\n\nExample3: ?name=al\u0026surname=sh\nResponse: Alexandr Shprot, Alina Sham, etc.\n\nName filters ignore the alphabet: ?name=ivan finds Ivan and Иван, ?name=Юрий finds Yuriy and Iurii.\n\n` + "`" + `filter` + "`" + ` is an expression for arbitrary boolean combinations, it is combined with other filters by and.\nFields: id, age, name, surname, patronymic, gender, nationality, created_at, updated_at.\nOperators: == != \u003e \u003e= \u003c \u003c= (numbers and dates), ~ (text contains), in [...], not in [...], and, or, not, parentheses. Strings and dates are quoted.\nExample6: ?filter=age\u003e30 and (gender==\"female\" or nationality in [\"BY\",\"UA\"])\n\n` + "`" + `fields` + "`" + ` limits returned user fields (id, created_at, updated_at, name, surname, patronymic, age, gender, nationality, attributes), only these columns are read from db.\nExample7: ?fields=id,name,surname\n\n` + "`" + `ids` + "`" + ` returns the listed users in the same order in ` + "`" + `data` + "`" + ` and not found ids in ` + "`" + `missing` + "`" + `, other filters and pagination are ignored. Same as POST /users/lookup.\nExample8: ?ids=3,1,2\n\n` + "`" + `tag` + "`" + ` filters by comma separated tags, ` + "`" + `tag_mode=any` + "`" + ` (default) returns users with at least one of them, ` + "`" + `tag_mode=all` + "`" + ` users with every tag.\nExample10: ?tag=vip,newsletter\u0026tag_mode=all\n\n` + "`" + `attr.\u003cname\u003e` + "`" + ` filters by custom attributes, comma separated values mean any of them, several attributes are combined by and.\nExample9: ?attr.department=sales,support\u0026attr.remote=true\n\nExample3.1: ?surname=ov\u0026match=contains\u0026nationality=BY,RU\u0026age_gte=18\u0026age_lte=30\u0026created_gte=2025-01-01\nResponse: users from Belarus or Russia aged 18-30 created since 2025 whose surname contains \"ov\"\n\nResponse is an array of users, navigation links are sent in RFC 8288 ` + "`" + `Link` + "`" + ` header.\nWith ` + "`" + `Accept: application/vnd.user-manager.envelope+json` + "`" + ` header or ` + "`" + `?envelope=true` + "`" + ` response is an object (entities.UsersPage) with ` + "`" + `data` + "`" + `, ` + "`" + `page` + "`" + `, ` + "`" + `page_size` + "`" + `, ` + "`" + `total_count` + "`" + `, ` + "`" + `total_pages` + "`" + ` and navigation ` + "`" + `links` + "`" + `.\n\nUsers are ordered by id by default, ` + "`" + `sort` + "`" + ` accepts comma separated fields (id, name, surname, age, created_at, updated_at, nationality), \"-\" prefix means descending order.\nExample4: ?sort=surname,-created_at\n\nCursor pagination is used if ` + "`" + `cursor` + "`" + ` or ` + "`" + `limit` + "`" + ` is provided, it is faster on deep pages than page numbers. Response is an object with ` + "`" + `data` + "`" + `, ` + "`" + `next_cursor` + "`" + `, ` + "`" + `prev_cursor` + "`" + ` and ` + "`" + `total_count` + "`" + ` (only with ` + "`" + `with_total=true` + "`" + `).\nExample5: ?limit=10, then ?limit=10\u0026cursor={next_cursor from previous response}, cursor works only with the same sort",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "get all users with optionally filters and pagination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name filter",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "surname filter",
+                        "name": "surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "patronymic filter",
+                        "name": "patronymic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "gender filter can be only male or female",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "how name filters are matched: prefix (default), exact or contains",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated country codes, example: BY,RU",
+                        "name": "nationality",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "min age, inclusive",
+                        "name": "age_gte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max age, inclusive",
+                        "name": "age_lte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created at or after, date (2006-01-02) or RFC 3339 time",
+                        "name": "created_gte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created at or before, date means the end of that day",
+                        "name": "created_lte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "updated at or after, date (2006-01-02) or RFC 3339 time",
+                        "name": "updated_gte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "updated at or before, date means the end of that day",
+                        "name": "updated_lte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only users with (true) or without (false) patronymic",
+                        "name": "has_patronymic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated tags, example: vip,newsletter",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "any (default) or all of tags",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "custom attribute filter, name is the attribute name, example: attr.department=sales,support",
+                        "name": "attr.name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter expression, example: age\u003e30 and (gender==\\",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "min:5",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "min:1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: surname,-created_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor from previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "cursor page size, min:1 max:50",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "count total number of users in cursor mode",
+                        "name": "with_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true returns an object with data and page metadata instead of a bare array",
+                        "name": "envelope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return, example: id,name,surname",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated ids, max 100, returns entities.UsersLookup instead of a page",
+                        "name": "ids",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "entities.UsersPage with envelope=true",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.User"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links: first, prev, next, last"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_filter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "creating new user with provided name, surname, patronymic(optional)\nName parts are normalized before validation: spaces are trimmed and collapsed, names typed in one case become title case (\" ivan \" is saved as \"Ivan\")",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "create user",
+                "parameters": [
+                    {
+                        "description": "Users fullname: name, surname, patronymic(optional)",
+                        "name": "fullname",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.FullName"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "message with created user's id",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_body, validation_failed: invalid fields are listed in errors",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: user with the same full name exists",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "enrichment_failed",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes every user matched by the filter. Filters are the same as in get all users plus ` + "`" + `ids` + "`" + `, at least one of them is required.\nBy default users are soft deleted (deleted_at is set), ` + "`" + `hard=true` + "`" + ` removes rows completely.\nThe delete runs in one transaction and is rolled back if it touches more than ` + "`" + `max_affected` + "`" + ` rows.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "bulk delete users by filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated ids, example: 1,2,3",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name filter",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "surname filter",
+                        "name": "surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "patronymic filter",
+                        "name": "patronymic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "gender filter can be only male or female",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "custom attribute filter, name is the attribute name, example: attr.department=sales,support",
+                        "name": "attr.name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "remove rows instead of soft delete",
+                        "name": "hard",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only count affected users and return a sample",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max:1000",
+                        "name": "max_affected",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.BulkResult"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_filter, invalid_parameter, bulk_limit_exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Applies the same update to every user matched by the filter. Filters are the same as in get all users plus ` + "`" + `ids` + "`" + `, at least one of them is required.\nThe update runs in one transaction and is rolled back if it touches more than ` + "`" + `max_affected` + "`" + ` rows.\n` + "`" + `attributes` + "`" + ` cant be changed in bulk because they are saved as a whole map, they are rejected with not_allowed_in_bulk.\n\nExample: ?surname=iv\u0026gender=male\u0026dry_run=true\nResponse: number of users that would be updated and a few of them as a sample",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "bulk update users by filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated ids, example: 1,2,3",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name filter",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "surname filter",
+                        "name": "surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "patronymic filter",
+                        "name": "patronymic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "gender filter can be only male or female",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "custom attribute filter, name is the attribute name, example: attr.department=sales,support",
+                        "name": "attr.name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only count affected users and return a sample",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max:1000",
+                        "name": "max_affected",
+                        "in": "query"
+                    },
+                    {
+                        "description": "parameters for update",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.UpdateUserParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.BulkResult"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_filter, invalid_parameter, invalid_body, validation_failed, bulk_limit_exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: update would make full names of users equal",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/duplicates": {
+            "get": {
+                "description": "Returns pairs of users that are probably the same person, ordered by ` + "`" + `score` + "`" + ` from 0 to 1.\nScore is weighted similarity of transliterated name parts (surname 0.4, name 0.3, patronymic 0.1)\nplus matching enrichment data (nationality 0.1, gender 0.05, age ±1 year 0.05), ` + "`" + `matches` + "`" + ` lists equal fields.\nOnly users with similar surnames are compared.\n\nFound pairs can be merged with POST /users/merge",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "find probable duplicates",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "min score, from 0 to 1, default 0.7",
+                        "name": "threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 20, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only duplicates of this user",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.DuplicatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/export": {
+            "get": {
+                "description": "Streams all users matched by filters (same as in get all users) without pagination. Rows are read from db by cursor so the size of export is not limited by memory.\n\nExample: ?format=csv\u0026surname=iv",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "export users as csv, ndjson or json",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv, ndjson or json (default)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name filter",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "surname filter",
+                        "name": "surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "patronymic filter",
+                        "name": "patronymic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "gender filter can be only male or female",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "custom attribute filter, name is the attribute name, example: attr.department=sales,support",
+                        "name": "attr.name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_filter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/import": {
+            "post": {
+                "description": "Creates users from the request body row by row with the same validation as create user. Invalid rows and duplicates dont stop the import, they are returned in the report with reasons.\n\ncsv: first row is a header, name and surname columns are required, patronymic, age, gender, nationality and attributes (json object) are optional.\nndjson: one json object per line with the same fields, attributes are an object like in create user.\nAttributes are checked against attribute definitions, so rows without required attributes are rejected.\n\nIf the file cant be read to the end (too large, broken csv quoting, ndjson line over 64 KB) rows imported before the error are kept and returned in ` + "`" + `report` + "`" + ` of the error.\n\nWithout enrichment gender is required for every row. Format is taken from ` + "`" + `format` + "`" + ` query or Content-Type (text/csv, application/x-ndjson).",
+                "consumes": [
+                    "text/plain"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "import users from csv or ndjson",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv or ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "fill missing age, gender and nationality from external apis",
+                        "name": "enrich",
+                        "in": "query"
+                    },
+                    {
+                        "description": "csv or ndjson content",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_body: rows imported before the error are listed in report",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "payload_too_large: rows imported before the error are listed in report",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/lookup": {
+            "post": {
+                "description": "Returns users in the same order as requested ids, ids of users that dont exist are listed in ` + "`" + `missing` + "`" + `. Repeated ids are returned once.\nThe same lookup is available as GET /users?ids=1,2,3",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "get many users by ids",
+                "parameters": [
+                    {
+                        "description": "max 100 ids",
+                        "name": "ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.LookupUsersParams"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return, example: id,name,surname",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.UsersLookup"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_body, invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/merge": {
+            "post": {
+                "description": "Merges source user into target field by field, source user is soft deleted.\n` + "`" + `prefer` + "`" + ` tells whose value to keep for name, surname, patronymic, age, gender and nationality: target or source.\nFields that arent listed keep target value, empty target values are taken from source.\nBoth users are saved to merge history as they were before the merge.\n\nExample: {\"target_id\":1,\"source_id\":2,\"prefer\":{\"patronymic\":\"source\",\"age\":\"source\"}}",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "merge duplicate users",
+                "parameters": [
+                    {
+                        "description": "users to merge",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.MergeUsersParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "target user after merge",
+                        "schema": {
+                            "$ref": "#/definitions/entities.User"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_body, validation_failed",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: target or source user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: another user already has merged full name",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/search": {
+            "get": {
+                "description": "Typo tolerant search across name, surname and patronymic using trigram similarity (pg_trgm), works for cyrillic names as well.\nNames are compared by transliterated latin keys, so latin query finds cyrillic names and vice versa.\nUsers are ordered by ` + "`" + `score` + "`" + ` - the best similarity of name, surname or patronymic to the query from 0 to 1.\n\nExample: ?q=Ивонов\nResponse: Иванов, Ивонин, Ivanov, etc.\n\nLower ` + "`" + `threshold` + "`" + ` returns more users with worse matches.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "fuzzy search users by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "min similarity, from 0 to 1, default 0.3",
+                        "name": "threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 20, max 50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.UserSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/stats": {
+            "get": {
+                "description": "Counts by gender, nationality and tag, average age per nationality, age histogram and time series of created users.\nAccepts the same filters as GET /users, deleted users are not counted.\n\n` + "`" + `age_buckets` + "`" + ` are ascending lower bounds of age buckets, bucket from 0 is added if the first bound isnt 0.\nExample: ?age_buckets=18,30,60 gives buckets 0-18, 18-30, 30-60 and 60+, ` + "`" + `to` + "`" + ` of the last bucket is null.\n\n` + "`" + `interval` + "`" + ` of created series is day or week (weeks start on monday), periods are in UTC and periods without created users are skipped.\n\nResults are cached until any user is created, updated, deleted or tagged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "aggregated users statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated lower bounds of age buckets, default 18,25,35,45,55,65, max 20 bounds",
+                        "name": "age_buckets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "day or week, default day",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name filter",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "surname filter",
+                        "name": "surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "patronymic filter",
+                        "name": "patronymic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "gender filter can be only male or female",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "how name filters are matched: prefix (default), exact or contains",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated country codes, example: BY,RU",
+                        "name": "nationality",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated tags, example: vip,newsletter",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "any (default) or all of tags",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "min age, inclusive",
+                        "name": "age_gte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max age, inclusive",
+                        "name": "age_lte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created at or after, date (2006-01-02) or RFC 3339 time",
+                        "name": "created_gte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created at or before, date means the end of that day",
+                        "name": "created_lte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "updated at or after, date (2006-01-02) or RFC 3339 time",
+                        "name": "updated_gte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "updated at or before, date means the end of that day",
+                        "name": "updated_lte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only users with (true) or without (false) patronymic",
+                        "name": "has_patronymic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter expression, same as for GET /users",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "custom attribute filter, name is the attribute name, example: attr.department=sales,support",
+                        "name": "attr.name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.UserStats"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_filter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/tags": {
+            "post": {
+                "description": "Adds and removes tags of every user matched by the filter. Filters are the same as in get all users plus ` + "`" + `ids` + "`" + `, at least one of them is required.\nTags are changed in one transaction that is rolled back if more than ` + "`" + `max_affected` + "`" + ` users are matched.\n\nExample: ?nationality=BY\u0026age_gte=18 with body {\"add\":[\"campaign_2025\"],\"remove\":[\"trial\"]}",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "bulk tag users by filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated ids, example: 1,2,3",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name filter",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "surname filter",
+                        "name": "surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "gender filter can be only male or female",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "custom attribute filter, name is the attribute name, example: attr.department=sales,support",
+                        "name": "attr.name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated tags, example: vip,newsletter",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "any (default) or all of tags",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only count matched users and return a sample",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max:1000",
+                        "name": "max_affected",
+                        "in": "query"
+                    },
+                    {
+                        "description": "tags to add and remove",
+                        "name": "tags",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.BulkTagsParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.BulkResult"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_filter, invalid_body, validation_failed, bulk_limit_exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}": {
+            "get": {
+                "description": "recieve user info by providing id in path\nOnly requested fields are returned if ` + "`" + `fields` + "`" + ` is provided: id, created_at, updated_at, name, surname, patronymic, age, gender, nationality",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "get user by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return, example: id,name,surname",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.User"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "replacing all editable fields of user by id provided in path. name, surname, age and gender are required,\nmissing patronymic and nationality are cleared. Update_at will change automatically. Name parts are normalized the same way as on create",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "replace user by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "all editable fields of user",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.ReplaceUserParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message about user replacement",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: user with the same full name exists",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "soft deletes user by id if exists (deleted_at is set), the same as bulk delete without hard.\nBefore soft delete was added the row was removed, now it is kept and hidden from all endpoints, a repeated delete returns 404.\nUse bulk delete with ` + "`" + `ids` + "`" + ` and ` + "`" + `hard=true` + "`" + ` to remove the row completely",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "delete user by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successful deleting message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "updating user info by id provided in path, format of body is chosen by Content-Type:\napplication/json: you can optionally provide name, surname, patronymic, age, gender, nationality, only provided fields are changed.\napplication/merge-patch+json (RFC 7386): fields are merged into the user, null removes a field, so {\"patronymic\":null} clears patronymic.\napplication/json-patch+json (RFC 6902): operations (add, remove, replace, move, copy, test) are applied to the user as it is returned by GET,\ne.g. [{\"op\":\"test\",\"path\":\"/updated_at\",\"value\":\"...\"},{\"op\":\"replace\",\"path\":\"/age\",\"value\":30}]. If any operation fails nothing is changed.\nMerge and json patches must leave a valid full user (like PUT), id, created_at and updated_at are read only. They return the patched user.\nUpdate_at will change automatically. Name parts are normalized the same way as on create",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "update user info by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "parameters for update, merge patch or json patch",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.UpdateUserParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "patched user for merge and json patches, message about user update for application/json",
+                        "schema": {
+                            "$ref": "#/definitions/entities.User"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: user with the same full name exists, patch_failed: test operation failed or path doesnt exist",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported_media_type",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/emails": {
+            "get": {
+                "description": "Contacts of the user, the primary one first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "get emails or phones of user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Contact"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Emails must be RFC 5322 addresses without display name, domains are lowercased. Phones must be in international format, spaces, dashes, dots and parentheses are removed and they are saved in E.164 (+79991234567).\nTypes of emails are personal, work and other, types of phones are mobile, home, work and other (other if empty).\nEmails (case insensitive) and phones are unique across all users. The first contact of a kind is primary, primary=true moves the flag from the current primary contact",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "add email or phone to user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "contact to add",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.CreateContactParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Contact"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: the email or phone is used by a user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/emails/{contact_id}": {
+            "delete": {
+                "description": "If the primary contact is deleted the oldest remaining contact of the kind becomes primary",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "delete email or phone of user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "contact_id",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successful deleting message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Only type and primary flag can be changed, add a new contact to change the value. primary=true makes the contact primary instead of the current one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "update email or phone of user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "contact_id",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "fields to change",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.UpdateContactParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Contact"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/emails/{contact_id}/verification": {
+            "post": {
+                "description": "Sends a one-time 6 digit code to the contact, the code expires in 10 minutes and allows 5 attempts. A new code replaces the previous one and can be requested once a minute",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "send verification code to email or phone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "contact_id",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entities.VerificationSent"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: contact is already verified",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "too_many_requests: code was sent less than a minute ago",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/emails/{contact_id}/verification/confirm": {
+            "post": {
+                "description": "Marks the contact verified if the code is right. Wrong and expired codes are validation errors of code, after 5 wrong attempts a new code must be requested",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "verify email or phone by code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "contact_id",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "code from the message",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.VerifyContactParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Contact"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_body, validation_failed: invalid_verification_code",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: contact is already verified",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/groups": {
+            "get": {
+                "description": "Groups the user is a direct member of with the roles. With ` + "`" + `recursive=true` + "`" + ` all their ancestor groups are listed too,\nthey have inherited set and the role of the nearest direct membership",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "get groups of user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include ancestors of groups",
+                        "name": "recursive",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.UserGroup"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/phones": {
+            "get": {
+                "description": "Contacts of the user, the primary one first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "get emails or phones of user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Contact"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Emails must be RFC 5322 addresses without display name, domains are lowercased. Phones must be in international format, spaces, dashes, dots and parentheses are removed and they are saved in E.164 (+79991234567).\nTypes of emails are personal, work and other, types of phones are mobile, home, work and other (other if empty).\nEmails (case insensitive) and phones are unique across all users. The first contact of a kind is primary, primary=true moves the flag from the current primary contact",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "add email or phone to user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "contact to add",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.CreateContactParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Contact"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: the email or phone is used by a user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/phones/{contact_id}": {
+            "delete": {
+                "description": "If the primary contact is deleted the oldest remaining contact of the kind becomes primary",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "delete email or phone of user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "contact_id",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successful deleting message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Only type and primary flag can be changed, add a new contact to change the value. primary=true makes the contact primary instead of the current one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "update email or phone of user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "contact_id",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "fields to change",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.UpdateContactParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Contact"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/phones/{contact_id}/verification": {
+            "post": {
+                "description": "Sends a one-time 6 digit code to the contact, the code expires in 10 minutes and allows 5 attempts. A new code replaces the previous one and can be requested once a minute",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "send verification code to email or phone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "contact_id",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entities.VerificationSent"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: contact is already verified",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "too_many_requests: code was sent less than a minute ago",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/phones/{contact_id}/verification/confirm": {
+            "post": {
+                "description": "Marks the contact verified if the code is right. Wrong and expired codes are validation errors of code, after 5 wrong attempts a new code must be requested",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "verify email or phone by code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "contact_id",
+                        "name": "contact_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "code from the message",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.VerifyContactParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Contact"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_body, validation_failed: invalid_verification_code",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "conflict: contact is already verified",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/tags": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "get tags of user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.UserTags"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Tags are trimmed and lowercased, missing tags are created. Tags that the user already has are skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "add tags to user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "tags to add",
+                        "name": "tags",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.TagsParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "all tags of the user after the change",
+                        "schema": {
+                            "$ref": "#/definitions/entities.UserTags"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, invalid_body, validation_failed: invalid tags are listed in errors",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/tags/{tag}": {
+            "delete": {
+                "description": "Removing a tag that the user doesnt have isnt an error",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "remove tag from user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "all tags of the user after the change",
+                        "schema": {
+                            "$ref": "#/definitions/entities.UserTags"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_parameter, validation_failed",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handlers.errorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entities.AgeBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.AttributeDefinition": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enum": {
+                    "description": "allowed values, any value of the type if empty",
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "name": {
+                    "description": "the key in user attributes, lowercase latin letters, digits and underscores",
+                    "type": "string",
+                    "example": "department"
+                },
+                "pattern": {
+                    "description": "regular expression (RE2 syntax) for string values, the whole value must match",
+                    "type": "string",
+                    "example": "^[A-Z]{2}-[0-9]+$"
+                },
+                "required": {
+                    "description": "users must have the attribute. Existing users are checked on their next change of attributes",
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "number",
+                        "boolean"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.BulkResult": {
+            "type": "object",
+            "properties": {
+                "affected": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "sample": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.User"
+                    }
+                }
+            }
+        },
+        "entities.BulkTagsParams": {
+            "type": "object",
+            "properties": {
+                "add": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "campaign_2025"
+                    ]
+                },
+                "remove": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "trial"
+                    ]
+                }
+            }
+        },
+        "entities.Contact": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "primary": {
+                    "description": "primary contact of the kind, only one contact of every kind is primary",
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "work"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "email or phone in E.164 format",
+                    "type": "string",
+                    "example": "ivan@example.com"
+                },
+                "verified_at": {
+                    "description": "nil until the contact is verified by one-time code",
+                    "type": "string"
+                }
+            }
+        },
+        "entities.CreateContactParams": {
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "primary": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "work"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "ivan@example.com"
+                }
+            }
+        },
+        "entities.CreateGroupParams": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Backend"
+                },
+                "parent_id": {
+                    "description": "top level group if empty",
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.DuplicateCandidate": {
+            "type": "object",
+            "properties": {
+                "first": {
+                    "$ref": "#/definitions/entities.User"
+                },
+                "matches": {
+                    "description": "fields with equal values: name, surname, patronymic (compared by search keys), age (±1 year), gender, nationality",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "score": {
+                    "type": "number"
+                },
+                "second": {
+                    "$ref": "#/definitions/entities.User"
+                }
+            }
+        },
+        "entities.DuplicatesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.DuplicateCandidate"
+                    }
+                },
+                "threshold": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.FullName": {
+            "type": "object",
+            "required": [
+                "name",
+                "surname"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "object"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "patronymic": {
+                    "type": "string"
+                },
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.GenderStats": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "gender": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Group": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Backend"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.GroupMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "admin",
+                        "member"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "description": "live user, filled by listings of members",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.User"
+                        }
+                    ]
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.GroupMemberParams": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "admin",
+                        "member"
+                    ]
+                }
+            }
+        },
+        "entities.GroupsPage": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Group"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/entities.PageLinks"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.ImportReport": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ImportedRow"
+                    }
+                },
+                "rejected": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.RejectedRow"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.ImportedRow": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.LookupUsersParams": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "entities.MergeUsersParams": {
+            "type": "object",
+            "required": [
+                "source_id",
+                "target_id"
+            ],
+            "properties": {
+                "prefer": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "source_id": {
+                    "type": "integer"
+                },
+                "target_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.NationalityStats": {
+            "type": "object",
+            "properties": {
+                "avg_age": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "nationality": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.PageLinks": {
+            "type": "object",
+            "properties": {
+                "first": {
+                    "type": "string"
+                },
+                "last": {
+                    "type": "string"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "prev": {
+                    "type": "string"
+                },
+                "self": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.RejectedRow": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "the same code as the api returns for the error of a single user, reason is only for people",
+                    "type": "string",
+                    "enum": [
+                        "invalid_body",
+                        "validation_failed",
+                        "conflict",
+                        "enrichment_failed",
+                        "internal_error"
+                    ]
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.ReplaceUserParams": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "attributes": {
+                    "description": "missing attributes are cleared",
+                    "type": "object"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "patronymic": {
+                    "type": "string"
                 },
                 "surname": {
                     "type": "string"
                 }
             }
         },
+        "entities.TagStats": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.TagsParams": {
+            "type": "object",
+            "required": [
+                "tags"
+            ],
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vip",
+                        "newsletter"
+                    ]
+                }
+            }
+        },
+        "entities.TimeSeriesPoint": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "period": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.UpdateContactParams": {
+            "type": "object",
+            "properties": {
+                "primary": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "personal"
+                }
+            }
+        },
+        "entities.UpdateGroupParams": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Backend"
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "entities.UpdateUserParams": {
             "type": "object",
             "properties": {
                 "age": {
                     "type": "integer"
                 },
+                "attributes": {
+                    "description": "replaces all attributes, use merge patch to change some of them. Not allowed in bulk update",
+                    "type": "object"
+                },
                 "gender": {
                     "type": "string"
                 },
@@ -331,9 +3498,16 @@ const docTemplate = `{
                 "age": {
                     "type": "integer"
                 },
+                "attributes": {
+                    "description": "custom attributes described by AttributeDefinition",
+                    "type": "object"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "type": "string"
+                },
                 "gender": {
                     "type": "string"
                 },
@@ -357,13 +3531,261 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_handlers.errorResponse": {
+        "entities.UserGroup": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inherited": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Backend"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "admin",
+                        "member"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.UserSearchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.UserSearchResult"
+                    }
+                },
+                "query": {
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.UserSearchResult": {
+            "type": "object",
+            "required": [
+                "name",
+                "surname"
+            ],
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "attributes": {
+                    "description": "custom attributes described by AttributeDefinition",
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "patronymic": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "surname": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.UserStats": {
+            "type": "object",
+            "properties": {
+                "age_histogram": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.AgeBucket"
+                    }
+                },
+                "by_gender": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.GenderStats"
+                    }
+                },
+                "by_nationality": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.NationalityStats"
+                    }
+                },
+                "by_tag": {
+                    "description": "number of matched users with every tag, tags without matched users are skipped",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.TagStats"
+                    }
+                },
+                "created_series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.TimeSeriesPoint"
+                    }
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.UserTags": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.UsersLookup": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.User"
+                    }
+                },
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "entities.VerificationSent": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.VerifyContactParams": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "github_com_Util787_user-manager-api_internal_services.FieldError": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
                 "message": {
                     "type": "string"
                 }
             }
+        },
+        "internal_handlers.errorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "enum": [
+                        "invalid_body",
+                        "invalid_parameter",
+                        "invalid_filter",
+                        "validation_failed",
+                        "not_found",
+                        "conflict",
+                        "bulk_limit_exceeded",
+                        "payload_too_large",
+                        "enrichment_failed",
+                        "patch_failed",
+                        "unsupported_media_type",
+                        "too_many_requests",
+                        "internal_error"
+                    ]
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "description": "invalid fields of validation errors and invalid query params of invalid_filter and invalid_parameter",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Util787_user-manager-api_internal_services.FieldError"
+                    }
+                },
+                "instance": {
+                    "description": "op id of the request, the same as in logs",
+                    "type": "string"
+                },
+                "report": {
+                    "description": "rows handled before import failed, they stay imported",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.ImportReport"
+                        }
+                    ]
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -375,7 +3797,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/api",
 	Schemes:          []string{},
 	Title:            "User manager api",
-	Description:      "Rest api for managing users crud operations",
+	Description:      "Rest api for managing users crud operations\n\nErrors are RFC 7807 problem details sent as application/problem+json. `code` is stable and can be checked by clients:\ninvalid_body (400), invalid_parameter (400), invalid_filter (400), validation_failed (400, invalid fields are listed in `errors`),\nbulk_limit_exceeded (400), not_found (404), conflict (409), patch_failed (409), payload_too_large (413), unsupported_media_type (415),\ntoo_many_requests (429), internal_error (500), enrichment_failed (502).\n`instance` is the id of the request operation, the same as in server logs\nTitles, details and field messages are in english or russian, language is chosen by Accept-Language header, english by default.\nField errors have their own stable `code`: name_too_short, name_too_long, name_invalid_chars, name_mixed_scripts, name_invalid_separators,\nname_not_title_case, name_not_capitalized, invalid_gender, required_gender, negative_age, no_fields, self_merge, unknown_field, invalid_prefer,\nrequired, invalid_type, read_only, not_in_enum, pattern_mismatch, invalid_attribute_name, invalid_attribute_type, invalid_pattern, pattern_not_string,\ninvalid_tag, tag_added_and_removed, parent_group_not_found, group_cycle, invalid_group_role, invalid_email, invalid_phone,\ninvalid_contact_type, invalid_verification_code",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
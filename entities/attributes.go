@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// types of custom attribute values
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeNumber  = "number"
+	AttributeTypeBoolean = "boolean"
+)
+
+// AttributeDefinition describes one custom attribute of users, like department or employee number.
+// Values of attributes are checked against definitions on every create and update
+type AttributeDefinition struct {
+	// the key in user attributes, lowercase latin letters, digits and underscores
+	Name string `json:"name" db:"name" example:"department"`
+	Type string `json:"type" db:"type" enums:"string,number,boolean"`
+	// users must have the attribute. Existing users are checked on their next change of attributes
+	Required bool `json:"required" db:"required"`
+	// allowed values, any value of the type if empty
+	Enum JSONValues `json:"enum" db:"enum" swaggertype:"array,object"`
+	// regular expression (RE2 syntax) for string values, the whole value must match
+	Pattern     string    `json:"pattern" db:"pattern" example:"^[A-Z]{2}-[0-9]+$"`
+	Description string    `json:"description" db:"description"`
+	Created_at  time.Time `json:"created_at" db:"created_at"`
+	Updated_at  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Attributes are custom attributes of a user stored as jsonb, values are strings, float64 numbers or booleans
+type Attributes map[string]any
+
+func (a Attributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(a)
+	return string(data), err
+}
+
+func (a *Attributes) Scan(src any) error {
+	return scanJSON(src, a)
+}
+
+// JSONValues is a json array stored as jsonb
+type JSONValues []any
+
+func (v JSONValues) Value() (driver.Value, error) {
+	if v == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+func (v *JSONValues) Scan(src any) error {
+	return scanJSON(src, v)
+}
+
+func scanJSON(src any, dest any) error {
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(data, dest)
+	case string:
+		return json.Unmarshal([]byte(data), dest)
+	default:
+		return fmt.Errorf("cant scan %T as json", src)
+	}
+}
+
+// AttributeFilter matches users whose attribute is equal to any of values.
+// Values are strings from the query until they are converted to the type of attribute definition
+type AttributeFilter struct {
+	Name   string
+	Values []any
+}
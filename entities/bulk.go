@@ -0,0 +1,21 @@
+package entities
+
+// BulkFilter selects users for bulk operations, ids and UserFilter are combined with AND
+type BulkFilter struct {
+	Ids []int32
+	UserFilter
+}
+
+type BulkOptions struct {
+	DryRun bool
+	// operation is rolled back if it touches more rows than this
+	MaxAffected int
+}
+
+type BulkResult struct {
+	Affected int    `json:"affected"`
+	DryRun   bool   `json:"dry_run"`
+	Sample   []User `json:"sample,omitempty"`
+	// ids of changed users, they are removed from cache
+	Ids []int32 `json:"-"`
+}
@@ -0,0 +1,57 @@
+package entities
+
+import "time"
+
+// kinds of contacts, every kind is stored in its own table
+const (
+	ContactKindEmail = "email"
+	ContactKindPhone = "phone"
+)
+
+// types of contacts, emails can be personal, work or other and phones mobile, home, work or other
+const (
+	ContactTypePersonal = "personal"
+	ContactTypeMobile   = "mobile"
+	ContactTypeHome     = "home"
+	ContactTypeWork     = "work"
+	ContactTypeOther    = "other"
+)
+
+// Contact is an email or a phone of a user
+type Contact struct {
+	Id     int32 `json:"id" db:"id"`
+	UserId int32 `json:"user_id" db:"user_id"`
+	// email or phone in E.164 format
+	Value string `json:"value" db:"value" example:"ivan@example.com"`
+	Type  string `json:"type" db:"type" example:"work"`
+	// primary contact of the kind, only one contact of every kind is primary
+	Primary bool `json:"primary" db:"is_primary"`
+	// nil until the contact is verified by one-time code
+	Verified_at *time.Time `json:"verified_at" db:"verified_at"`
+	Created_at  time.Time  `json:"created_at" db:"created_at"`
+	Updated_at  time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CreateContactParams are fields of a new contact, type is other if empty.
+// The first contact of a kind is primary even if Primary is false
+type CreateContactParams struct {
+	Value   string `json:"value" binding:"required" example:"ivan@example.com"`
+	Type    string `json:"type" example:"work"`
+	Primary bool   `json:"primary"`
+}
+
+// UpdateContactParams changes only provided fields, value cant be changed.
+// Primary true makes the contact primary instead of the current one, false is ignored
+type UpdateContactParams struct {
+	Type    *string `json:"type" example:"personal"`
+	Primary bool    `json:"primary"`
+}
+
+type VerifyContactParams struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+// VerificationSent tells when the sent code expires
+type VerificationSent struct {
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,30 @@
+package entities
+
+// DuplicateCandidate is a pair of users that are probably the same person, First has the smaller id
+type DuplicateCandidate struct {
+	First  User    `json:"first"`
+	Second User    `json:"second"`
+	Score  float64 `json:"score"`
+	// fields with equal values: name, surname, patronymic (compared by search keys), age (±1 year), gender, nationality
+	Matches []string `json:"matches"`
+}
+
+type DuplicatesResponse struct {
+	Threshold float64              `json:"threshold"`
+	Data      []DuplicateCandidate `json:"data"`
+}
+
+// values of MergeUsersParams.Prefer
+const (
+	MergePreferTarget = "target"
+	MergePreferSource = "source"
+)
+
+// MergeUsersParams merges source user into target. Prefer tells whose value to keep for every field:
+// name, surname, patronymic, age, gender, nationality or attributes. Fields that arent listed keep target value,
+// if it is empty source value is taken. Attributes are merged by keys, prefer chooses values of keys that both users have
+type MergeUsersParams struct {
+	TargetId int32             `json:"target_id" binding:"required"`
+	SourceId int32             `json:"source_id" binding:"required"`
+	Prefer   map[string]string `json:"prefer"`
+}
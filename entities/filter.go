@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+// match modes for name, surname and patronymic filters
+const (
+	MatchPrefix   = "prefix"
+	MatchExact    = "exact"
+	MatchContains = "contains"
+)
+
+// UserFilter is the set of filters used for users listing, empty fields are not applied.
+// Name filters are case insensitive and work as prefixes unless Match says otherwise, all ranges are inclusive
+type UserFilter struct {
+	Name       string
+	Surname    string
+	Patronymic string
+	Match      string
+	Gender     string
+
+	Nationalities []string
+
+	AgeGte *int
+	AgeLte *int
+
+	CreatedGte *time.Time
+	CreatedLte *time.Time
+	UpdatedGte *time.Time
+	UpdatedLte *time.Time
+
+	HasPatronymic *bool
+
+	// parsed ?filter= expression, combined with other filters by and
+	Expr FilterExpr
+
+	// ?attr.<name>= filters, all of them must match
+	Attributes []AttributeFilter
+
+	// lowercase tag names, users must have any or all of them depending on TagMode
+	Tags    []string
+	TagMode string
+}
+
+// IsEmpty reports whether no filter is set, match mode alone doesnt filter anything
+func (f UserFilter) IsEmpty() bool {
+	return f.Name == "" && f.Surname == "" && f.Patronymic == "" && f.Gender == "" &&
+		len(f.Nationalities) == 0 &&
+		f.AgeGte == nil && f.AgeLte == nil &&
+		f.CreatedGte == nil && f.CreatedLte == nil && f.UpdatedGte == nil && f.UpdatedLte == nil &&
+		f.HasPatronymic == nil && f.Expr == nil && len(f.Attributes) == 0 && len(f.Tags) == 0
+}
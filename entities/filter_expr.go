@@ -0,0 +1,48 @@
+package entities
+
+// FilterExpr is a node of parsed filter expression, see service.ParseFilterExpr
+//
+// Example: age>30 and (gender=="female" or nationality in ["BY","UA"])
+type FilterExpr interface {
+	filterExpr()
+}
+
+// logical operators
+const (
+	FilterAnd = "and"
+	FilterOr  = "or"
+)
+
+// comparison operators, FilterContains is case insensitive substring match
+const (
+	FilterEq       = "=="
+	FilterNotEq    = "!="
+	FilterGt       = ">"
+	FilterGtOrEq   = ">="
+	FilterLt       = "<"
+	FilterLtOrEq   = "<="
+	FilterContains = "~"
+	FilterIn       = "in"
+	FilterNotIn    = "not in"
+)
+
+type FilterLogical struct {
+	Op    string
+	Left  FilterExpr
+	Right FilterExpr
+}
+
+type FilterNot struct {
+	Expr FilterExpr
+}
+
+// FilterComparison compares field with Value which type matches the field: int, string or time.Time, for in and not in it is a slice of them
+type FilterComparison struct {
+	Field string
+	Op    string
+	Value any
+}
+
+func (FilterLogical) filterExpr()    {}
+func (FilterNot) filterExpr()        {}
+func (FilterComparison) filterExpr() {}
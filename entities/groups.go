@@ -0,0 +1,75 @@
+package entities
+
+import "time"
+
+// roles of group members, from the strongest to the weakest
+const (
+	GroupRoleOwner  = "owner"
+	GroupRoleAdmin  = "admin"
+	GroupRoleMember = "member"
+)
+
+// Group is a node of the groups tree, ParentId is nil for top level groups
+type Group struct {
+	Id          int32     `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name" example:"Backend"`
+	Description string    `json:"description" db:"description"`
+	ParentId    *int32    `json:"parent_id" db:"parent_id"`
+	Created_at  time.Time `json:"created_at" db:"created_at"`
+	Updated_at  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CreateGroupParams struct {
+	Name        string `json:"name" binding:"required" example:"Backend"`
+	Description string `json:"description"`
+	// top level group if empty
+	ParentId *int32 `json:"parent_id"`
+}
+
+// UpdateGroupParams changes only provided fields, parent_id 0 moves the group to the top level
+type UpdateGroupParams struct {
+	Name        *string `json:"name" example:"Backend"`
+	Description *string `json:"description"`
+	ParentId    *int32  `json:"parent_id"`
+}
+
+// GroupFilter of groups listing, ParentId lists only direct subgroups of the group
+type GroupFilter struct {
+	ParentId *int32
+	// top level groups only
+	TopLevel bool
+}
+
+// GroupsPage is the response of page number pagination of groups
+type GroupsPage struct {
+	Data       []Group   `json:"data"`
+	Page       int       `json:"page"`
+	PageSize   int       `json:"page_size"`
+	TotalCount int       `json:"total_count"`
+	TotalPages int       `json:"total_pages"`
+	Links      PageLinks `json:"links"`
+}
+
+// GroupMemberParams sets role of a user in a group, member if empty
+type GroupMemberParams struct {
+	Role string `json:"role" enums:"owner,admin,member"`
+}
+
+// GroupMember is membership of a user in a group. In recursive listings GroupId can be a subgroup of the requested group
+type GroupMember struct {
+	GroupId    int32     `json:"group_id" db:"group_id"`
+	UserId     int32     `json:"user_id" db:"user_id"`
+	Role       string    `json:"role" db:"role" enums:"owner,admin,member"`
+	Created_at time.Time `json:"created_at" db:"created_at"`
+	Updated_at time.Time `json:"updated_at" db:"updated_at"`
+	// live user, filled by listings of members
+	User *User `json:"user,omitempty" db:"-"`
+}
+
+// UserGroup is a group the user belongs to. In recursive listings ancestors of direct groups are included,
+// they have Inherited set and the role of the nearest direct membership
+type UserGroup struct {
+	Group
+	Role      string `json:"role" db:"role" enums:"owner,admin,member"`
+	Inherited bool   `json:"inherited" db:"inherited"`
+}
@@ -0,0 +1,30 @@
+package entities
+
+// ImportRecord is one row of csv or ndjson import, age, gender and nationality are optional if enrichment is enabled
+type ImportRecord struct {
+	Name        string     `json:"name"`
+	Surname     string     `json:"surname"`
+	Patronymic  string     `json:"patronymic"`
+	Age         *int       `json:"age"`
+	Gender      string     `json:"gender"`
+	Nationality string     `json:"nationality"`
+	Attributes  Attributes `json:"attributes"`
+}
+
+type ImportedRow struct {
+	Row int   `json:"row"`
+	Id  int32 `json:"id"`
+}
+
+type RejectedRow struct {
+	Row int `json:"row"`
+	// the same code as the api returns for the error of a single user, reason is only for people
+	Code   string `json:"code" enums:"invalid_body,validation_failed,conflict,enrichment_failed,internal_error"`
+	Reason string `json:"reason"`
+}
+
+type ImportReport struct {
+	Total    int           `json:"total"`
+	Accepted []ImportedRow `json:"accepted"`
+	Rejected []RejectedRow `json:"rejected"`
+}
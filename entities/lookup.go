@@ -0,0 +1,11 @@
+package entities
+
+type LookupUsersParams struct {
+	Ids []int32 `json:"ids" binding:"required"`
+}
+
+// UsersLookup keeps the order of requested ids, ids of users that dont exist or are deleted are listed in Missing
+type UsersLookup struct {
+	Data    []User  `json:"data"`
+	Missing []int32 `json:"missing"`
+}
@@ -0,0 +1,35 @@
+package entities
+
+// Cursor keeps sort key values of the edge row of a page, Backward cursor is used to get previous page.
+// Cursor is valid only for the sort it was created with
+type Cursor struct {
+	Values   map[string]string `json:"v"`
+	Sort     string            `json:"s,omitempty"`
+	Backward bool              `json:"b,omitempty"`
+}
+
+type UsersCursorPage struct {
+	Data       []User `json:"data"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	// only with with_total=true
+	TotalCount *int `json:"total_count,omitempty"`
+}
+
+// UsersPage is the response of page number pagination
+type UsersPage struct {
+	Data       []User    `json:"data"`
+	Page       int       `json:"page"`
+	PageSize   int       `json:"page_size"`
+	TotalCount int       `json:"total_count"`
+	TotalPages int       `json:"total_pages"`
+	Links      PageLinks `json:"links"`
+}
+
+type PageLinks struct {
+	Self  string `json:"self"`
+	First string `json:"first"`
+	Last  string `json:"last"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+}
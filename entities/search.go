@@ -0,0 +1,36 @@
+package entities
+
+import "encoding/json"
+
+// UserSearchResult is a user found by fuzzy search with the best trigram similarity of name, surname and patronymic
+type UserSearchResult struct {
+	User
+	Score float64 `json:"score" db:"score"`
+}
+
+// User.MarshalJSON is promoted from embedded User, so score has to be added explicitly
+func (r UserSearchResult) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(r.User)
+	if err != nil {
+		return nil, err
+	}
+	score, err := json.Marshal(r.Score)
+	if err != nil {
+		return nil, err
+	}
+
+	// data is a json object, score is added as the last key
+	data = data[:len(data)-1]
+	if len(data) > 1 {
+		data = append(data, ',')
+	}
+	data = append(data, `"score":`...)
+	data = append(data, score...)
+	return append(data, '}'), nil
+}
+
+type UserSearchResponse struct {
+	Query     string             `json:"query"`
+	Threshold float64            `json:"threshold"`
+	Data      []UserSearchResult `json:"data"`
+}
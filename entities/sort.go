@@ -0,0 +1,6 @@
+package entities
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
@@ -0,0 +1,53 @@
+package entities
+
+import "time"
+
+// intervals of created users time series
+const (
+	StatsIntervalDay  = "day"
+	StatsIntervalWeek = "week"
+)
+
+// StatsOptions configures aggregations of UserStats
+type StatsOptions struct {
+	// ascending lower bounds of age buckets, ages below the first bound go to the bucket from 0
+	AgeBuckets []int `json:"age_buckets"`
+	// day or week, weeks start on monday
+	Interval string `json:"interval"`
+}
+
+// UserStats is aggregated over users matching the filter, deleted users are not counted
+type UserStats struct {
+	Total         int                `json:"total"`
+	ByGender      []GenderStats      `json:"by_gender"`
+	ByNationality []NationalityStats `json:"by_nationality"`
+	// number of matched users with every tag, tags without matched users are skipped
+	ByTag         []TagStats        `json:"by_tag"`
+	AgeHistogram  []AgeBucket       `json:"age_histogram"`
+	Interval      string            `json:"interval"`
+	CreatedSeries []TimeSeriesPoint `json:"created_series"`
+}
+
+type GenderStats struct {
+	Gender string `json:"gender" db:"gender"`
+	Count  int    `json:"count" db:"count"`
+}
+
+type NationalityStats struct {
+	Nationality string  `json:"nationality" db:"nationality"`
+	Count       int     `json:"count" db:"count"`
+	AvgAge      float64 `json:"avg_age" db:"avg_age"`
+}
+
+// AgeBucket counts users with From <= age < To, To is nil for the last bucket
+type AgeBucket struct {
+	From  int  `json:"from"`
+	To    *int `json:"to"`
+	Count int  `json:"count"`
+}
+
+// TimeSeriesPoint counts users created during the day or week starting at Period (UTC), periods without users are skipped
+type TimeSeriesPoint struct {
+	Period time.Time `json:"period" db:"period"`
+	Count  int       `json:"count" db:"count"`
+}
@@ -0,0 +1,31 @@
+package entities
+
+// modes of ?tag= filter
+const (
+	// users with at least one of tags
+	TagModeAny = "any"
+	// users with every tag
+	TagModeAll = "all"
+)
+
+// TagsParams are tags to add to a user, missing tags are created
+type TagsParams struct {
+	Tags []string `json:"tags" binding:"required" example:"vip,newsletter"`
+}
+
+// BulkTagsParams are tags to add to and remove from every user matched by the filter
+type BulkTagsParams struct {
+	Add    []string `json:"add" example:"campaign_2025"`
+	Remove []string `json:"remove" example:"trial"`
+}
+
+// UserTags are all tags of a user ordered by name
+type UserTags struct {
+	UserId int32    `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+type TagStats struct {
+	Tag   string `json:"tag" db:"tag"`
+	Count int    `json:"count" db:"count"`
+}
@@ -1,25 +1,97 @@
 package entities
 
 import (
+	"bytes"
+	"encoding/json"
 	"time"
 )
 
 type User struct {
-	Id          int32     `json:"id" db:"id"`
-	Created_at  time.Time `json:"created_at" db:"created_at"`
-	Updated_at  time.Time `json:"updated_at" db:"updated_at"`
-	Name        string    `json:"name" db:"name" binding:"required"`
-	Surname     string    `json:"surname" db:"surname" binding:"required"`
-	Patronymic  string    `json:"patronymic" db:"patronymic"`
-	Age         int       `json:"age" db:"age"`
-	Gender      string    `json:"gender" db:"gender"`
-	Nationality string    `json:"nationality" db:"nationality"`
+	Id          int32      `json:"id" db:"id"`
+	Created_at  time.Time  `json:"created_at" db:"created_at"`
+	Updated_at  time.Time  `json:"updated_at" db:"updated_at"`
+	Deleted_at  *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	Name        string     `json:"name" db:"name" binding:"required"`
+	Surname     string     `json:"surname" db:"surname" binding:"required"`
+	Patronymic  string     `json:"patronymic" db:"patronymic"`
+	Age         int        `json:"age" db:"age"`
+	Gender      string     `json:"gender" db:"gender"`
+	Nationality string     `json:"nationality" db:"nationality"`
+	// custom attributes described by AttributeDefinition
+	Attributes Attributes `json:"attributes,omitempty" db:"attributes" swaggertype:"object"`
+
+	// normalized latin search keys of name parts, set by repository on write
+	NameKey       string `json:"-" db:"name_key"`
+	SurnameKey    string `json:"-" db:"surname_key"`
+	PatronymicKey string `json:"-" db:"patronymic_key"`
+
+	// normalized keys of name parts for equality checks, set by repository on write
+	NameNorm       string `json:"-" db:"name_norm"`
+	SurnameNorm    string `json:"-" db:"surname_norm"`
+	PatronymicNorm string `json:"-" db:"patronymic_norm"`
+
+	// json names of fields to serialize, all fields if empty, see WithFields
+	fields []string
+}
+
+// WithFields returns a copy of user that is serialized to json only with given fields in the same order
+func (u User) WithFields(fields []string) User {
+	u.fields = fields
+	return u
+}
+
+func (u User) MarshalJSON() ([]byte, error) {
+	// type without methods to avoid recursion
+	type user User
+
+	data, err := json.Marshal(user(u))
+	if err != nil || len(u.fields) == 0 {
+		return data, err
+	}
+
+	var all map[string]json.RawMessage
+	err = json.Unmarshal(data, &all)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for _, field := range u.fields {
+		value, ok := all[field]
+		if !ok {
+			continue
+		}
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(field)
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
 }
 
 type FullName struct {
-	Name       string `json:"name" binding:"required"`
-	Surname    string `json:"surname" binding:"required"`
-	Patronymic string `json:"patronymic"`
+	Name       string     `json:"name" binding:"required"`
+	Surname    string     `json:"surname" binding:"required"`
+	Patronymic string     `json:"patronymic"`
+	Attributes Attributes `json:"attributes" swaggertype:"object"`
+}
+
+// ReplaceUserParams are all editable fields of a user, full replacement clears missing patronymic and nationality
+type ReplaceUserParams struct {
+	Name        string `json:"name"`
+	Surname     string `json:"surname"`
+	Patronymic  string `json:"patronymic"`
+	Age         *int   `json:"age"`
+	Gender      string `json:"gender"`
+	Nationality string `json:"nationality"`
+	// missing attributes are cleared
+	Attributes Attributes `json:"attributes" swaggertype:"object"`
 }
 
 type UpdateUserParams struct {
@@ -29,4 +101,6 @@ type UpdateUserParams struct {
 	Age         *int    `json:"age"`
 	Gender      *string `json:"gender"`
 	Nationality *string `json:"nationality"`
+	// replaces all attributes, use merge patch to change some of them. Not allowed in bulk update
+	Attributes *Attributes `json:"attributes" swaggertype:"object"`
 }
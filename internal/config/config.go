@@ -78,3 +78,62 @@ func InitRedisConfig() *RedisConfig {
 
 	return redisCfg
 }
+
+// NamePolicyConfig sets which names, surnames and patronymics are valid, see service.NamePolicy
+type NamePolicyConfig struct {
+	Scripts      []string `env:"NAME_SCRIPTS" envDefault:"Latin,Cyrillic" envSeparator:","`
+	SingleScript bool     `env:"NAME_SINGLE_SCRIPT" envDefault:"true"`
+	Separators   string   `env:"NAME_SEPARATORS" envDefault:"-'’ "`
+	MinLength    int      `env:"NAME_MIN_LENGTH" envDefault:"2"`
+	MaxLength    int      `env:"NAME_MAX_LENGTH" envDefault:"100"`
+	Case         string   `env:"NAME_CASE" envDefault:"capital"`
+}
+
+// didnt use godotenv.Load() here, init only after InitServerConfig()!
+func InitNamePolicyConfig() *NamePolicyConfig {
+	nameCfg := &NamePolicyConfig{}
+
+	if err := env.Parse(nameCfg); err != nil {
+		panic("Failed to parse name policy config. " + err.Error())
+	}
+
+	return nameCfg
+}
+
+// VerificationConfig keeps the server secret of verification code hashes, codes sent before it is changed stop working
+type VerificationConfig struct {
+	Secret string `env:"VERIFICATION_SECRET"`
+}
+
+// didnt use godotenv.Load() here, init only after InitServerConfig()!
+func InitVerificationConfig() *VerificationConfig {
+	verificationCfg := &VerificationConfig{}
+
+	if err := env.Parse(verificationCfg); err != nil {
+		panic("Failed to parse verification config. " + err.Error())
+	}
+
+	if verificationCfg.Secret == "" {
+		panic("VERIFICATION_SECRET is not set")
+	}
+
+	return verificationCfg
+}
+
+// NotifierConfig chooses where verification codes are sent, log and file sinks are for local use
+type NotifierConfig struct {
+	Sink string `env:"NOTIFIER" envDefault:"log"`
+	// json lines file of file sink
+	File string `env:"NOTIFIER_FILE" envDefault:"notifications.log"`
+}
+
+// didnt use godotenv.Load() here, init only after InitServerConfig()!
+func InitNotifierConfig() *NotifierConfig {
+	notifierCfg := &NotifierConfig{}
+
+	if err := env.Parse(notifierCfg); err != nil {
+		panic("Failed to parse notifier config. " + err.Error())
+	}
+
+	return notifierCfg
+}
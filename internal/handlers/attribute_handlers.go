@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/gin-gonic/gin"
+)
+
+// createAttribute godoc
+// @Summary      create attribute definition
+// @Description  Defines a custom attribute of users. Values of attributes are checked against definitions when users are created and updated: type (string, number or boolean), required, enum of allowed values and pattern (RE2, the whole string value must match).
+// @Description  Users without a new required attribute are checked on their next change of attributes
+// @Tags         attributes
+// @Accept       json
+// @Produce      json
+// @Param        definition  body  entities.AttributeDefinition  true  "created_at and updated_at are ignored"
+// @Success      201  {object}  entities.AttributeDefinition
+// @Failure      400  {object}  errorResponse  "invalid_body, validation_failed: invalid fields are listed in errors"
+// @Failure      409  {object}  errorResponse  "conflict: attribute with the same name exists"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /attributes [post]
+func (h *Handler) createAttribute(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	var def entities.AttributeDefinition
+	err := c.ShouldBindJSON(&def)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
+		return
+	}
+
+	log.Info("Creating attribute definition", slog.String("name", def.Name))
+	def, err = h.services.AttributeService.CreateDefinition(def)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to create attribute", err)
+		return
+	}
+
+	log.Info("Attribute definition created successfully", slog.String("name", def.Name))
+	c.JSON(http.StatusCreated, def)
+}
+
+// getAttributes godoc
+// @Summary      get all attribute definitions
+// @Tags         attributes
+// @Produce      json
+// @Success      200  {array}   entities.AttributeDefinition
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /attributes [get]
+func (h *Handler) getAttributes(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	defs, err := h.services.AttributeService.GetDefinitions()
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to get attributes", err)
+		return
+	}
+
+	log.Info("Got attribute definitions successfully", slog.Int("count", len(defs)))
+	c.JSON(http.StatusOK, defs)
+}
+
+// getAttribute godoc
+// @Summary      get attribute definition
+// @Tags         attributes
+// @Produce      json
+// @Param        name  path  string  true  "attribute name"
+// @Success      200  {object}  entities.AttributeDefinition
+// @Failure      404  {object}  errorResponse  "not_found"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /attributes/{name} [get]
+func (h *Handler) getAttribute(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	name := c.Param("name")
+	def, err := h.services.AttributeService.GetDefinition(name)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to get attribute", err)
+		return
+	}
+
+	log.Info("Got attribute definition successfully", slog.String("name", name))
+	c.JSON(http.StatusOK, def)
+}
+
+// updateAttribute godoc
+// @Summary      replace attribute definition
+// @Description  All fields of the definition are replaced, name is taken from the path. Existing values of users are not checked against the changed definition, they are checked on the next change of user attributes
+// @Tags         attributes
+// @Accept       json
+// @Produce      json
+// @Param        name        path  string                        true  "attribute name"
+// @Param        definition  body  entities.AttributeDefinition  true  "name, created_at and updated_at are ignored"
+// @Success      200  {object}  entities.AttributeDefinition
+// @Failure      400  {object}  errorResponse  "invalid_body, validation_failed: invalid fields are listed in errors"
+// @Failure      404  {object}  errorResponse  "not_found"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /attributes/{name} [put]
+func (h *Handler) updateAttribute(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	var def entities.AttributeDefinition
+	err := c.ShouldBindJSON(&def)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
+		return
+	}
+	def.Name = c.Param("name")
+
+	log.Info("Updating attribute definition", slog.String("name", def.Name))
+	def, err = h.services.AttributeService.UpdateDefinition(def)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to update attribute", err)
+		return
+	}
+
+	log.Info("Attribute definition updated successfully", slog.String("name", def.Name))
+	c.JSON(http.StatusOK, def)
+}
+
+// deleteAttribute godoc
+// @Summary      delete attribute definition
+// @Description  The attribute is removed from all users. Cached users may return the removed attribute until their cache expires
+// @Tags         attributes
+// @Produce      json
+// @Param        name  path  string  true  "attribute name"
+// @Success      200  {object}  map[string]string
+// @Failure      404  {object}  errorResponse  "not_found"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /attributes/{name} [delete]
+func (h *Handler) deleteAttribute(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	name := c.Param("name")
+	log.Info("Deleting attribute definition", slog.String("name", name))
+	err := h.services.AttributeService.DeleteDefinition(name)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to delete attribute", err)
+		return
+	}
+
+	log.Info("Attribute definition deleted successfully", slog.String("name", name))
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Attribute %s deleted successfully", name)})
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/logger/handlers/slogdiscard"
+	"github.com/Util787/user-manager-api/internal/repository"
+	service "github.com/Util787/user-manager-api/internal/services"
+	serviceMock "github.com/Util787/user-manager-api/internal/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_attributes(t *testing.T) {
+	department := entities.AttributeDefinition{Name: "department", Type: entities.AttributeTypeString, Enum: entities.JSONValues{"sales", "support"}}
+
+	tests := []struct {
+		testname           string
+		method             string
+		path               string
+		body               string
+		mockBehavior       func(s *serviceMock.MockAttributeService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname: "Create",
+			method:   "POST",
+			path:     "/attributes",
+			body:     `{"name":"department","type":"string","enum":["sales","support"]}`,
+			mockBehavior: func(s *serviceMock.MockAttributeService) {
+				s.On("CreateDefinition", department).Return(department, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse:   `"name":"department","type":"string","required":false,"enum":["sales","support"]`,
+		},
+		{
+			testname:           "Create with malformed body",
+			method:             "POST",
+			path:               "/attributes",
+			body:               `{"name":`,
+			mockBehavior:       func(s *serviceMock.MockAttributeService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"code":"invalid_body"`,
+		},
+		{
+			testname: "Create invalid definition",
+			method:   "POST",
+			path:     "/attributes",
+			body:     `{"name":"Department","type":"date"}`,
+			mockBehavior: func(s *serviceMock.MockAttributeService) {
+				s.On("CreateDefinition", entities.AttributeDefinition{Name: "Department", Type: "date"}).Return(entities.AttributeDefinition{},
+					&service.ValidationError{Fields: []service.FieldError{{Field: "type", Code: service.FieldErrInvalidAttributeType, Message: "must be string, number or boolean"}}})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"errors":[{"field":"type","code":"invalid_attribute_type","message":"must be string, number or boolean"}]`,
+		},
+		{
+			testname: "Create existing",
+			method:   "POST",
+			path:     "/attributes",
+			body:     `{"name":"department","type":"string","enum":["sales","support"]}`,
+			mockBehavior: func(s *serviceMock.MockAttributeService) {
+				s.On("CreateDefinition", department).Return(entities.AttributeDefinition{}, fmt.Errorf("%w: %w", service.ErrConflict, repository.ErrAttributeExists))
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `"code":"conflict"`,
+		},
+		{
+			testname: "Get all",
+			method:   "GET",
+			path:     "/attributes",
+			mockBehavior: func(s *serviceMock.MockAttributeService) {
+				s.On("GetDefinitions").Return([]entities.AttributeDefinition{department}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `[{"name":"department"`,
+		},
+		{
+			testname: "Get all with db error",
+			method:   "GET",
+			path:     "/attributes",
+			mockBehavior: func(s *serviceMock.MockAttributeService) {
+				s.On("GetDefinitions").Return(nil, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   "Failed to get attributes",
+		},
+		{
+			testname: "Get one",
+			method:   "GET",
+			path:     "/attributes/department",
+			mockBehavior: func(s *serviceMock.MockAttributeService) {
+				s.On("GetDefinition", "department").Return(department, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"name":"department"`,
+		},
+		{
+			testname: "Get missing",
+			method:   "GET",
+			path:     "/attributes/salary",
+			mockBehavior: func(s *serviceMock.MockAttributeService) {
+				s.On("GetDefinition", "salary").Return(entities.AttributeDefinition{}, fmt.Errorf("%w: %w", service.ErrNotFound, repository.ErrAttributeNotFound))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `"code":"not_found"`,
+		},
+		{
+			testname: "Update takes name from path",
+			method:   "PUT",
+			path:     "/attributes/department",
+			body:     `{"name":"other","type":"string","enum":["sales","support"]}`,
+			mockBehavior: func(s *serviceMock.MockAttributeService) {
+				s.On("UpdateDefinition", department).Return(department, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"name":"department"`,
+		},
+		{
+			testname: "Delete",
+			method:   "DELETE",
+			path:     "/attributes/department",
+			mockBehavior: func(s *serviceMock.MockAttributeService) {
+				s.On("DeleteDefinition", "department").Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   "Attribute department deleted successfully",
+		},
+		{
+			testname: "Delete missing",
+			method:   "DELETE",
+			path:     "/attributes/salary",
+			mockBehavior: func(s *serviceMock.MockAttributeService) {
+				s.On("DeleteDefinition", "salary").Return(fmt.Errorf("%w: %w", service.ErrNotFound, repository.ErrAttributeNotFound))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   "Failed to delete attribute",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockAttributeService := serviceMock.NewMockAttributeService(t)
+			router := setupAttributesTestRouter(mockAttributeService)
+
+			test.mockBehavior(mockAttributeService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.path, bytes.NewBufferString(test.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
+
+func setupAttributesTestRouter(mockAttributeService *serviceMock.MockAttributeService) *gin.Engine {
+	logger := slogdiscard.NewDiscardLogger()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	h := NewHandlers(&service.Service{AttributeService: mockAttributeService}, logger)
+
+	router.GET("/attributes", h.getAttributes)
+	router.POST("/attributes", h.createAttribute)
+	router.GET("/attributes/:name", h.getAttribute)
+	router.PUT("/attributes/:name", h.updateAttribute)
+	router.DELETE("/attributes/:name", h.deleteAttribute)
+
+	return router
+}
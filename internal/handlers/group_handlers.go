@@ -0,0 +1,420 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/gin-gonic/gin"
+)
+
+// createGroup godoc
+// @Summary      create group
+// @Description  Creates a group, parent_id makes it a subgroup. Names are trimmed and must be unique among subgroups of the same parent (case insensitive)
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        group  body      entities.CreateGroupParams  true  "group to create"
+// @Success      201    {object}  entities.Group
+// @Failure      400    {object}  errorResponse  "invalid_body, validation_failed: invalid fields are listed in errors"
+// @Failure      409    {object}  errorResponse  "conflict: group with the same name exists in the parent"
+// @Failure      500    {object}  errorResponse  "internal_error"
+// @Router       /groups [post]
+func (h *Handler) createGroup(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	var params entities.CreateGroupParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
+		return
+	}
+
+	log.Info("Creating group", slog.Any("params", params))
+	group, err := h.services.GroupService.CreateGroup(params)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to create group", err)
+		return
+	}
+
+	log.Info("Group created successfully", slog.Int("group_id", int(group.Id)))
+	c.JSON(http.StatusCreated, group)
+}
+
+// getGroups godoc
+// @Summary      get groups
+// @Description  Groups ordered by id with page number pagination, `parent_id` lists only direct subgroups of the group, 0 lists top level groups
+// @Tags         groups
+// @Produce      json
+// @Param        page_size  query     int  false  "min:5, max:50"
+// @Param        page       query     int  false  "page number"
+// @Param        parent_id  query     int  false  "parent group id, 0 for top level groups"
+// @Success      200        {object}  entities.GroupsPage
+// @Failure      400        {object}  errorResponse  "invalid_parameter"
+// @Failure      500        {object}  errorResponse  "internal_error"
+// @Router       /groups [get]
+func (h *Handler) getGroups(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	var filter entities.GroupFilter
+	if parentIdStr, ok := c.GetQuery("parent_id"); ok {
+		parentId, err := parseInt32(parentIdStr)
+		if err != nil {
+			newErrorResponse(c, log, codeInvalidParameter, "Parent id should be number", err)
+			return
+		}
+		if parentId == 0 {
+			filter.TopLevel = true
+		} else {
+			filter.ParentId = &parentId
+		}
+	}
+
+	pageSizeStr := c.DefaultQuery("page_size", "5")
+	pageSize, err := strconv.Atoi(pageSizeStr)
+	if err != nil || pageSize < 5 {
+		pageSize = 5
+		log.Debug("Invalid page_size value, set to 5", slog.String("user's page_size", pageSizeStr))
+	}
+	if pageSize > 50 {
+		pageSize = 50
+		log.Debug("page_size is greater than 50, set to 50", slog.String("user's page_size", pageSizeStr))
+	}
+
+	pageStr := c.DefaultQuery("page", "1")
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page <= 0 {
+		page = 1
+		log.Debug("Invalid page value, set to 1", slog.String("page", pageStr))
+	}
+
+	log.Info("Getting groups", slog.Int("page_size", pageSize), slog.Int("page", page), slog.Any("filter", filter))
+	groups, totalCount, err := h.services.GroupService.GetGroups(pageSize, page, filter)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to get groups", err)
+		return
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(pageSize)))
+	if page > max(totalPages, 1) {
+		newErrorResponse(c, log, codeInvalidParameter, "Page exceeds total number of pages", errors.New("page exceeds max of pages"))
+		return
+	}
+
+	log.Info("Got groups successfully", slog.Int("count", len(groups)))
+
+	links := pageLinks(c, page, totalPages)
+	setLinkHeader(c, map[string]string{"self": links.Self, "first": links.First, "prev": links.Prev, "next": links.Next, "last": links.Last})
+
+	c.JSON(http.StatusOK, entities.GroupsPage{
+		Data:       groups,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+		Links:      links,
+	})
+}
+
+// getGroupById godoc
+// @Summary      get group by id
+// @Tags         groups
+// @Produce      json
+// @Param        group_id  path      int  true  "group_id"
+// @Success      200       {object}  entities.Group
+// @Failure      400       {object}  errorResponse  "invalid_parameter"
+// @Failure      404       {object}  errorResponse  "not_found"
+// @Failure      500       {object}  errorResponse  "internal_error"
+// @Router       /groups/{group_id} [get]
+func (h *Handler) getGroupById(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	groupId32, err := parseInt32(c.Param("group_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
+		return
+	}
+
+	group, err := h.services.GroupService.GetGroupById(groupId32)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to get group", err)
+		return
+	}
+
+	log.Info("Got group successfully", slog.Int("group_id", int(groupId32)))
+	c.JSON(http.StatusOK, group)
+}
+
+// updateGroup godoc
+// @Summary      update group by id
+// @Description  Only provided fields are changed. parent_id moves the group with all its subgroups, 0 moves it to the top level.
+// @Description  The group cant be moved into itself or its subgroups
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        group_id  path      int                         true  "group_id"
+// @Param        group     body      entities.UpdateGroupParams  true  "fields to change"
+// @Success      200       {object}  entities.Group
+// @Failure      400       {object}  errorResponse  "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors"
+// @Failure      404       {object}  errorResponse  "not_found"
+// @Failure      409       {object}  errorResponse  "conflict: group with the same name exists in the parent"
+// @Failure      500       {object}  errorResponse  "internal_error"
+// @Router       /groups/{group_id} [patch]
+func (h *Handler) updateGroup(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	groupId32, err := parseInt32(c.Param("group_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
+		return
+	}
+
+	var params entities.UpdateGroupParams
+	err = c.ShouldBindJSON(&params)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
+		return
+	}
+
+	log.Info("Updating group with parameters", slog.Int("group_id", int(groupId32)), slog.Any("update_params", params))
+	group, err := h.services.GroupService.UpdateGroup(groupId32, params)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to update group", err)
+		return
+	}
+
+	log.Info("Updated group successfully", slog.Int("group_id", int(groupId32)))
+	c.JSON(http.StatusOK, group)
+}
+
+// deleteGroup godoc
+// @Summary      delete group by id
+// @Description  Memberships of the group are deleted too. Groups with subgroups cant be deleted, move or delete subgroups first
+// @Tags         groups
+// @Produce      json
+// @Param        group_id  path      int  true  "group_id"
+// @Success      200       {object}  map[string]string  "successful deleting message"
+// @Failure      400       {object}  errorResponse  "invalid_parameter"
+// @Failure      404       {object}  errorResponse  "not_found"
+// @Failure      409       {object}  errorResponse  "conflict: group has subgroups"
+// @Failure      500       {object}  errorResponse  "internal_error"
+// @Router       /groups/{group_id} [delete]
+func (h *Handler) deleteGroup(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	groupIdStr := c.Param("group_id")
+	groupId32, err := parseInt32(groupIdStr)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
+		return
+	}
+
+	log.Info("Deleting group", slog.Int("group_id", int(groupId32)))
+	err = h.services.GroupService.DeleteGroup(groupId32)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to delete group", err)
+		return
+	}
+
+	log.Info("Deleted group successfully", slog.Int("group_id", int(groupId32)))
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Group with id:%s deleted successfully", groupIdStr)})
+}
+
+// getGroupMembers godoc
+// @Summary      get members of group
+// @Description  Members of the group with their roles and user data, deleted users are skipped.
+// @Description  With `recursive=true` members of all subgroups are listed too, group_id of a member is the group the user is a direct member of
+// @Tags         groups
+// @Produce      json
+// @Param        group_id   path      int   true   "group_id"
+// @Param        recursive  query     bool  false  "include members of subgroups"
+// @Success      200        {array}   entities.GroupMember
+// @Failure      400        {object}  errorResponse  "invalid_parameter"
+// @Failure      404        {object}  errorResponse  "not_found"
+// @Failure      500        {object}  errorResponse  "internal_error"
+// @Router       /groups/{group_id}/members [get]
+func (h *Handler) getGroupMembers(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	groupId32, err := parseInt32(c.Param("group_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
+		return
+	}
+	recursive, err := parseRecursive(c)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "recursive can be only true or false", err)
+		return
+	}
+
+	log.Info("Getting group members", slog.Int("group_id", int(groupId32)), slog.Bool("recursive", recursive))
+	members, err := h.services.GroupService.GetGroupMembers(groupId32, recursive)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to get group members", err)
+		return
+	}
+
+	log.Info("Got group members successfully", slog.Int("group_id", int(groupId32)), slog.Int("count", len(members)))
+	c.JSON(http.StatusOK, members)
+}
+
+// setGroupMember godoc
+// @Summary      add user to group or change role
+// @Description  Adds the user to the group with the role (member if empty) or changes the role of existing member. Roles are owner, admin and member
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        group_id  path      int                         true   "group_id"
+// @Param        user_id   path      int                         true   "user_id"
+// @Param        member    body      entities.GroupMemberParams  false  "role of the user"
+// @Success      200       {object}  entities.GroupMember
+// @Failure      400       {object}  errorResponse  "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors"
+// @Failure      404       {object}  errorResponse  "not_found: group or user doesnt exist"
+// @Failure      500       {object}  errorResponse  "internal_error"
+// @Router       /groups/{group_id}/members/{user_id} [put]
+func (h *Handler) setGroupMember(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	groupId32, userId32, ok := parseGroupMemberIds(c, log)
+	if !ok {
+		return
+	}
+
+	var params entities.GroupMemberParams
+	if c.Request.ContentLength != 0 {
+		err := c.ShouldBindJSON(&params)
+		if err != nil {
+			newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
+			return
+		}
+	}
+
+	log.Info("Setting group member", slog.Int("group_id", int(groupId32)), slog.Int("user_id", int(userId32)), slog.String("role", params.Role))
+	member, err := h.services.GroupService.SetGroupMember(groupId32, userId32, params)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to set group member", err)
+		return
+	}
+
+	log.Info("Set group member successfully", slog.Int("group_id", int(groupId32)), slog.Int("user_id", int(userId32)))
+	c.JSON(http.StatusOK, member)
+}
+
+// removeGroupMember godoc
+// @Summary      remove user from group
+// @Description  Only direct membership is removed, the user stays in subgroups of the group
+// @Tags         groups
+// @Produce      json
+// @Param        group_id  path      int  true  "group_id"
+// @Param        user_id   path      int  true  "user_id"
+// @Success      200       {object}  map[string]string  "successful removing message"
+// @Failure      400       {object}  errorResponse  "invalid_parameter"
+// @Failure      404       {object}  errorResponse  "not_found: user isnt a member of the group"
+// @Failure      500       {object}  errorResponse  "internal_error"
+// @Router       /groups/{group_id}/members/{user_id} [delete]
+func (h *Handler) removeGroupMember(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	groupId32, userId32, ok := parseGroupMemberIds(c, log)
+	if !ok {
+		return
+	}
+
+	log.Info("Removing group member", slog.Int("group_id", int(groupId32)), slog.Int("user_id", int(userId32)))
+	err := h.services.GroupService.RemoveGroupMember(groupId32, userId32)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to remove group member", err)
+		return
+	}
+
+	log.Info("Removed group member successfully", slog.Int("group_id", int(groupId32)), slog.Int("user_id", int(userId32)))
+	c.JSON(http.StatusOK, gin.H{"message": "User removed from group successfully"})
+}
+
+// getUserGroups godoc
+// @Summary      get groups of user
+// @Description  Groups the user is a direct member of with the roles. With `recursive=true` all their ancestor groups are listed too,
+// @Description  they have inherited set and the role of the nearest direct membership
+// @Tags         groups
+// @Produce      json
+// @Param        user_id    path      int   true   "user_id"
+// @Param        recursive  query     bool  false  "include ancestors of groups"
+// @Success      200        {array}   entities.UserGroup
+// @Failure      400        {object}  errorResponse  "invalid_parameter"
+// @Failure      404        {object}  errorResponse  "not_found"
+// @Failure      500        {object}  errorResponse  "internal_error"
+// @Router       /users/{user_id}/groups [get]
+func (h *Handler) getUserGroups(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	userId32, err := parseInt32(c.Param("user_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
+		return
+	}
+	recursive, err := parseRecursive(c)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "recursive can be only true or false", err)
+		return
+	}
+
+	log.Info("Getting user groups", slog.Int("user_id", int(userId32)), slog.Bool("recursive", recursive))
+	groups, err := h.services.GroupService.GetUserGroups(userId32, recursive)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to get user groups", err)
+		return
+	}
+
+	log.Info("Got user groups successfully", slog.Int("user_id", int(userId32)), slog.Int("count", len(groups)))
+	c.JSON(http.StatusOK, groups)
+}
+
+// parseGroupMemberIds sends error response if group_id or user_id isnt a number
+func parseGroupMemberIds(c *gin.Context, log *slog.Logger) (groupId, userId int32, ok bool) {
+	groupId, err := parseInt32(c.Param("group_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Group id should be number", err)
+		return 0, 0, false
+	}
+	userId, err = parseInt32(c.Param("user_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "User id should be number", err)
+		return 0, 0, false
+	}
+	return groupId, userId, true
+}
+
+func parseRecursive(c *gin.Context) (bool, error) {
+	return strconv.ParseBool(c.DefaultQuery("recursive", "false"))
+}
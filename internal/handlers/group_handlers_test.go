@@ -0,0 +1,363 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/logger/handlers/slogdiscard"
+	"github.com/Util787/user-manager-api/internal/repository"
+	service "github.com/Util787/user-manager-api/internal/services"
+	serviceMock "github.com/Util787/user-manager-api/internal/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_groups(t *testing.T) {
+	parentId := int32(1)
+	backend := entities.Group{Id: 2, Name: "Backend", ParentId: &parentId}
+	newName := "Platform"
+	topLevel := int32(0)
+
+	tests := []struct {
+		testname           string
+		method             string
+		path               string
+		body               string
+		mockBehavior       func(s *serviceMock.MockGroupService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname: "Create",
+			method:   "POST",
+			path:     "/groups",
+			body:     `{"name":"Backend","parent_id":1}`,
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("CreateGroup", entities.CreateGroupParams{Name: "Backend", ParentId: &parentId}).Return(backend, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse:   `"id":2,"name":"Backend","description":"","parent_id":1`,
+		},
+		{
+			testname:           "Create without name",
+			method:             "POST",
+			path:               "/groups",
+			body:               `{"parent_id":1}`,
+			mockBehavior:       func(s *serviceMock.MockGroupService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"code":"invalid_body"`,
+		},
+		{
+			testname: "Create with missing parent",
+			method:   "POST",
+			path:     "/groups",
+			body:     `{"name":"Backend","parent_id":1}`,
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("CreateGroup", entities.CreateGroupParams{Name: "Backend", ParentId: &parentId}).Return(entities.Group{}, &service.ValidationError{
+					Fields: []service.FieldError{{Field: "parent_id", Code: service.FieldErrParentGroupNotFound, Message: "group doesnt exist"}},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"errors":[{"field":"parent_id","code":"parent_group_not_found"`,
+		},
+		{
+			testname: "Create existing",
+			method:   "POST",
+			path:     "/groups",
+			body:     `{"name":"Backend"}`,
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("CreateGroup", entities.CreateGroupParams{Name: "Backend"}).Return(entities.Group{}, fmt.Errorf("%w: %w", service.ErrConflict, repository.ErrGroupExists))
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `"code":"conflict"`,
+		},
+		{
+			testname: "Get all top level",
+			method:   "GET",
+			path:     "/groups?parent_id=0",
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("GetGroups", 5, 1, entities.GroupFilter{TopLevel: true}).Return([]entities.Group{{Id: 1, Name: "Engineering"}}, 1, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"page":1,"page_size":5,"total_count":1,"total_pages":1`,
+		},
+		{
+			testname: "Get all empty",
+			method:   "GET",
+			path:     "/groups",
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("GetGroups", 5, 1, entities.GroupFilter{}).Return([]entities.Group{}, 0, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"data":[],"page":1`,
+		},
+		{
+			testname:           "Get all with invalid parent id",
+			method:             "GET",
+			path:               "/groups?parent_id=abc",
+			mockBehavior:       func(s *serviceMock.MockGroupService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Parent id should be number",
+		},
+		{
+			testname: "Get by id",
+			method:   "GET",
+			path:     "/groups/2",
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("GetGroupById", int32(2)).Return(backend, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"name":"Backend"`,
+		},
+		{
+			testname: "Get missing",
+			method:   "GET",
+			path:     "/groups/9",
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("GetGroupById", int32(9)).Return(entities.Group{}, fmt.Errorf("%w: %w", service.ErrNotFound, repository.ErrGroupNotFound))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `"title":"Resource not found","status":404,"detail":"Failed to get group"`,
+		},
+		{
+			testname:           "Get with invalid id",
+			method:             "GET",
+			path:               "/groups/abc",
+			mockBehavior:       func(s *serviceMock.MockGroupService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Id should be number",
+		},
+		{
+			testname: "Update",
+			method:   "PATCH",
+			path:     "/groups/2",
+			body:     `{"name":"Platform","parent_id":0}`,
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("UpdateGroup", int32(2), entities.UpdateGroupParams{Name: &newName, ParentId: &topLevel}).Return(entities.Group{Id: 2, Name: newName}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"name":"Platform","description":"","parent_id":null`,
+		},
+		{
+			testname: "Update into subgroup",
+			method:   "PATCH",
+			path:     "/groups/1",
+			body:     `{"parent_id":2}`,
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("UpdateGroup", int32(1), entities.UpdateGroupParams{ParentId: &backend.Id}).Return(entities.Group{}, &service.ValidationError{
+					Fields: []service.FieldError{{Field: "parent_id", Code: service.FieldErrGroupCycle, Message: "cant be the group itself or its subgroup"}},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"code":"group_cycle"`,
+		},
+		{
+			testname: "Delete",
+			method:   "DELETE",
+			path:     "/groups/2",
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("DeleteGroup", int32(2)).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   "Group with id:2 deleted successfully",
+		},
+		{
+			testname: "Delete with subgroups",
+			method:   "DELETE",
+			path:     "/groups/1",
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("DeleteGroup", int32(1)).Return(fmt.Errorf("%w: %w", service.ErrConflict, repository.ErrGroupHasSubgroups))
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `"title":"Conflict with existing data","status":409,"detail":"Failed to delete group"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockGroupService := serviceMock.NewMockGroupService(t)
+			router := setupGroupsTestRouter(mockGroupService)
+
+			test.mockBehavior(mockGroupService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.path, bytes.NewBufferString(test.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
+
+func TestHandler_groupMembers(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	user := entities.User{Id: 7, Name: "Ivan", Surname: "Ivanov"}
+
+	tests := []struct {
+		testname           string
+		method             string
+		path               string
+		body               string
+		mockBehavior       func(s *serviceMock.MockGroupService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname: "Get members recursively",
+			method:   "GET",
+			path:     "/groups/1/members?recursive=true",
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("GetGroupMembers", int32(1), true).Return([]entities.GroupMember{
+					{GroupId: 2, UserId: 7, Role: entities.GroupRoleAdmin, Created_at: createdAt, Updated_at: createdAt, User: &user},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `[{"group_id":2,"user_id":7,"role":"admin","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z","user":{"id":7`,
+		},
+		{
+			testname: "Get direct members",
+			method:   "GET",
+			path:     "/groups/1/members",
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("GetGroupMembers", int32(1), false).Return([]entities.GroupMember{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `[]`,
+		},
+		{
+			testname:           "Get members with invalid recursive",
+			method:             "GET",
+			path:               "/groups/1/members?recursive=deep",
+			mockBehavior:       func(s *serviceMock.MockGroupService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "recursive can be only true or false",
+		},
+		{
+			testname: "Set member with role",
+			method:   "PUT",
+			path:     "/groups/1/members/7",
+			body:     `{"role":"owner"}`,
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("SetGroupMember", int32(1), int32(7), entities.GroupMemberParams{Role: "owner"}).Return(entities.GroupMember{GroupId: 1, UserId: 7, Role: entities.GroupRoleOwner}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"group_id":1,"user_id":7,"role":"owner"`,
+		},
+		{
+			testname: "Set member without body",
+			method:   "PUT",
+			path:     "/groups/1/members/7",
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("SetGroupMember", int32(1), int32(7), entities.GroupMemberParams{}).Return(entities.GroupMember{GroupId: 1, UserId: 7, Role: entities.GroupRoleMember}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"role":"member"`,
+		},
+		{
+			testname:           "Set member with invalid user id",
+			method:             "PUT",
+			path:               "/groups/1/members/abc",
+			mockBehavior:       func(s *serviceMock.MockGroupService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "User id should be number",
+		},
+		{
+			testname: "Set member of missing user",
+			method:   "PUT",
+			path:     "/groups/1/members/9",
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("SetGroupMember", int32(1), int32(9), entities.GroupMemberParams{}).Return(entities.GroupMember{}, fmt.Errorf("%w: %w", service.ErrNotFound, repository.ErrUserNotFound))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `"code":"not_found"`,
+		},
+		{
+			testname: "Remove member",
+			method:   "DELETE",
+			path:     "/groups/1/members/7",
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("RemoveGroupMember", int32(1), int32(7)).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   "User removed from group successfully",
+		},
+		{
+			testname:           "Remove member with invalid group id",
+			method:             "DELETE",
+			path:               "/groups/abc/members/7",
+			mockBehavior:       func(s *serviceMock.MockGroupService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Group id should be number",
+		},
+		{
+			testname: "Get user groups recursively",
+			method:   "GET",
+			path:     "/users/7/groups?recursive=true",
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("GetUserGroups", int32(7), true).Return([]entities.UserGroup{
+					{Group: entities.Group{Id: 1, Name: "Engineering"}, Role: entities.GroupRoleAdmin, Inherited: true},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"name":"Engineering","description":"","parent_id":null,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","role":"admin","inherited":true`,
+		},
+		{
+			testname: "Get user groups with db error",
+			method:   "GET",
+			path:     "/users/7/groups",
+			mockBehavior: func(s *serviceMock.MockGroupService) {
+				s.On("GetUserGroups", int32(7), false).Return(nil, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   "Failed to get user groups",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockGroupService := serviceMock.NewMockGroupService(t)
+			router := setupGroupsTestRouter(mockGroupService)
+
+			test.mockBehavior(mockGroupService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.path, bytes.NewBufferString(test.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
+
+func setupGroupsTestRouter(mockGroupService *serviceMock.MockGroupService) *gin.Engine {
+	logger := slogdiscard.NewDiscardLogger()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	h := NewHandlers(&service.Service{GroupService: mockGroupService}, logger)
+
+	router.GET("/groups", h.getGroups)
+	router.POST("/groups", h.createGroup)
+	router.GET("/groups/:group_id", h.getGroupById)
+	router.PATCH("/groups/:group_id", h.updateGroup)
+	router.DELETE("/groups/:group_id", h.deleteGroup)
+	router.GET("/groups/:group_id/members", h.getGroupMembers)
+	router.PUT("/groups/:group_id/members/:user_id", h.setGroupMember)
+	router.DELETE("/groups/:group_id/members/:user_id", h.removeGroupMember)
+	router.GET("/users/:user_id/groups", h.getUserGroups)
+
+	return router
+}
@@ -4,6 +4,7 @@ import (
 	"log/slog"
 
 	_ "github.com/Util787/user-manager-api/docs"
+	"github.com/Util787/user-manager-api/entities"
 	"github.com/Util787/user-manager-api/internal/handlers/middleware"
 	service "github.com/Util787/user-manager-api/internal/services"
 	"github.com/gin-gonic/gin"
@@ -39,9 +40,61 @@ func (h *Handler) InitRoutes(env string) *gin.Engine {
 		{
 			users.GET("/", h.getAllUsers)
 			users.POST("/", h.createUser)
+			users.PATCH("/", h.bulkUpdateUsers)
+			users.DELETE("/", h.bulkDeleteUsers)
+			users.POST("/import", h.importUsers)
+			users.GET("/export", h.exportUsers)
+			users.GET("/search", h.searchUsers)
+			users.POST("/lookup", h.lookupUsers)
+			users.GET("/stats", h.getUserStats)
+			users.GET("/duplicates", h.findDuplicates)
+			users.POST("/merge", h.mergeUsers)
+			users.POST("/tags", h.bulkTagUsers)
 			users.GET("/:user_id", h.getUserById)
+			users.PUT("/:user_id", h.replaceUser)
 			users.PATCH("/:user_id", h.updateUser)
 			users.DELETE("/:user_id", h.deleteUser)
+			users.GET("/:user_id/tags", h.getUserTags)
+			users.POST("/:user_id/tags", h.addUserTags)
+			users.DELETE("/:user_id/tags/:tag", h.removeUserTag)
+			users.GET("/:user_id/groups", h.getUserGroups)
+
+			emails := users.Group("/:user_id/emails", withContactKind(entities.ContactKindEmail))
+			emails.GET("", h.getContacts)
+			emails.POST("", h.createContact)
+			emails.PATCH("/:contact_id", h.updateContact)
+			emails.DELETE("/:contact_id", h.deleteContact)
+			emails.POST("/:contact_id/verification", h.sendVerificationCode)
+			emails.POST("/:contact_id/verification/confirm", h.verifyContact)
+
+			phones := users.Group("/:user_id/phones", withContactKind(entities.ContactKindPhone))
+			phones.GET("", h.getContacts)
+			phones.POST("", h.createContact)
+			phones.PATCH("/:contact_id", h.updateContact)
+			phones.DELETE("/:contact_id", h.deleteContact)
+			phones.POST("/:contact_id/verification", h.sendVerificationCode)
+			phones.POST("/:contact_id/verification/confirm", h.verifyContact)
+		}
+
+		attributes := api.Group("/attributes")
+		{
+			attributes.GET("/", h.getAttributes)
+			attributes.POST("/", h.createAttribute)
+			attributes.GET("/:name", h.getAttribute)
+			attributes.PUT("/:name", h.updateAttribute)
+			attributes.DELETE("/:name", h.deleteAttribute)
+		}
+
+		groups := api.Group("/groups")
+		{
+			groups.GET("/", h.getGroups)
+			groups.POST("/", h.createGroup)
+			groups.GET("/:group_id", h.getGroupById)
+			groups.PATCH("/:group_id", h.updateGroup)
+			groups.DELETE("/:group_id", h.deleteGroup)
+			groups.GET("/:group_id/members", h.getGroupMembers)
+			groups.PUT("/:group_id/members/:user_id", h.setGroupMember)
+			groups.DELETE("/:group_id/members/:user_id", h.removeGroupMember)
 		}
 	}
 	return router
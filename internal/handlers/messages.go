@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"fmt"
+
+	service "github.com/Util787/user-manager-api/internal/services"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// first language is used if Accept-Language is missing or has no supported languages
+var supportedLanguages = []language.Tag{language.English, language.Russian}
+
+var languageMatcher = language.NewMatcher(supportedLanguages)
+
+// catalogs translate english messages. Titles are keyed by error code, field messages by field error code
+// and details by english detail format. Missing messages are left in english, messages_test.go checks that every
+// title, field code and detail passed to newErrorResponse, newParamErrorResponse or newServiceErrorResponse is translated
+var catalogs = map[language.Tag]map[string]string{
+	language.Russian: {
+		// titles
+		codeInvalidBody:       "Некорректное тело запроса",
+		codeInvalidParameter:  "Некорректный параметр пути или запроса",
+		codeInvalidFilter:     "Некорректный фильтр",
+		codeValidationFailed:  "Ошибка валидации",
+		codeNotFound:          "Ресурс не найден",
+		codeConflict:          "Конфликт с существующими данными",
+		codeBulkLimitExceeded: "Фильтру соответствует слишком много пользователей",
+		codePayloadTooLarge:   "Слишком большой размер запроса",
+		codeEnrichmentFailed:  "Внешние сервисы обогащения недоступны",
+		codePatchFailed:       "Патч нельзя применить к пользователю",
+		codeUnsupportedMedia:  "Неподдерживаемый тип содержимого",
+		codeTooManyRequests:   "Слишком много запросов",
+		codeInternal:          "Внутренняя ошибка сервера",
+
+		// field messages
+		service.FieldErrNameTooShort:            "должно быть не короче %d символов",
+		service.FieldErrNameTooLong:             "должно быть не длиннее %d символов",
+		service.FieldErrNameInvalidChars:        "может содержать только буквы разрешенных алфавитов и разделители между ними",
+		service.FieldErrNameMixedScripts:        "не должно смешивать буквы разных алфавитов",
+		service.FieldErrNameInvalidSeparators:   "должно начинаться и заканчиваться буквой, между буквами допускается только один разделитель",
+		service.FieldErrNameNotTitleCase:        "каждая часть должна начинаться с заглавной буквы, остальные буквы строчные",
+		service.FieldErrNameNotCapitalized:      "должно начинаться с заглавной буквы",
+		service.FieldErrInvalidGender:           "должен быть male или female",
+		service.FieldErrRequiredGender:          "обязателен и должен быть male или female",
+		service.FieldErrNegativeAge:             "не может быть отрицательным",
+		service.FieldErrNoFields:                "нет полей для обновления",
+		service.FieldErrSelfMerge:               "пользователя нельзя объединить с самим собой",
+		service.FieldErrUnknownField:            "неизвестное поле",
+		service.FieldErrInvalidPrefer:           "должно быть target или source",
+		service.FieldErrRequired:                "обязательное поле",
+		service.FieldErrInvalidType:             "имеет неверный тип",
+		service.FieldErrReadOnly:                "нельзя изменить",
+		service.FieldErrNotInEnum:               "должно быть одним из: %s",
+		service.FieldErrPatternMismatch:         "должно соответствовать шаблону %s",
+		service.FieldErrInvalidAttributeName:    "должно начинаться со строчной латинской буквы, за которой следуют строчные латинские буквы, цифры или подчеркивания, не длиннее 63 символов",
+		service.FieldErrInvalidAttributeType:    "должен быть string, number или boolean",
+		service.FieldErrInvalidPattern:          "не является корректным регулярным выражением",
+		service.FieldErrPatternNotString:        "можно задать только для строковых атрибутов",
+		service.FieldErrInvalidTag:              "должен начинаться со строчной буквы или цифры, за которыми следуют строчные буквы, цифры, _ или -, не длиннее 50 символов",
+		service.FieldErrTagAddedAndRemoved:      "нельзя одновременно добавить и удалить",
+		service.FieldErrParentGroupNotFound:     "группа не существует",
+		service.FieldErrGroupCycle:              "не может быть самой группой или ее подгруппой",
+		service.FieldErrInvalidGroupRole:        "должна быть owner, admin или member",
+		service.FieldErrInvalidEmail:            "должен быть корректным адресом электронной почты без отображаемого имени",
+		service.FieldErrInvalidPhone:            "должен быть номером телефона в международном формате, например +79991234567",
+		service.FieldErrInvalidContactType:      "должен быть одним из: %s",
+		service.FieldErrInvalidVerificationCode: "неверный или просроченный, запросите новый код",
+		service.FieldErrNotAllowedInBulk:        "нельзя изменить массовым обновлением, обновляйте пользователей по одному",
+
+		// query params and filters
+		service.FieldErrInvalidMatch:          "может быть только prefix, exact или contains",
+		service.FieldErrInvalidNationality:    "должно быть списком двухбуквенных кодов стран через запятую",
+		service.FieldErrNotNonNegativeInt:     "должно быть неотрицательным числом",
+		service.FieldErrInvalidAgeRange:       "не должно быть больше age_lte",
+		service.FieldErrInvalidTime:           "должно быть датой (2006-01-02) или временем RFC 3339",
+		service.FieldErrNotBoolean:            "должно быть true или false",
+		service.FieldErrInvalidTagMode:        "может быть только any или all",
+		service.FieldErrNoTags:                "должно быть списком тегов через запятую",
+		service.FieldErrInvalidIds:            "должно быть списком чисел через запятую",
+		service.FieldErrNoBulkFilter:          "нужно указать хотя бы один фильтр или ids",
+		service.FieldErrTooManyBounds:         "допускается не больше %d границ",
+		service.FieldErrInvalidBound:          "граница %q не является неотрицательным целым числом",
+		service.FieldErrBoundsNotAscending:    "границы должны идти по возрастанию",
+		service.FieldErrNoAttributeName:       "фильтр по атрибуту должен иметь вид attr.<name>=<values>",
+		service.FieldErrUnknownAttribute:      "неизвестный атрибут",
+		service.FieldErrInvalidAttributeValue: "%q не является значением типа %s",
+
+		// filter expression
+		service.FieldErrExprTooLong:            "длиннее %d символов",
+		service.FieldErrExprUnterminatedString: "содержит незакрытую строку в позиции %d",
+		service.FieldErrExprUnexpectedBang:     `содержит неожиданный "!" в позиции %d, используйте "not" или "!="`,
+		service.FieldErrExprUnexpectedToken:    "содержит неожиданный %q в позиции %d",
+		service.FieldErrExprUnexpectedEnd:      "неожиданно заканчивается",
+		service.FieldErrExprUnknownField:       "содержит неизвестное поле %q в позиции %d",
+		service.FieldErrExprTooManyComparisons: "содержит больше %d сравнений",
+		service.FieldErrExprOrderOfText:        "%s нельзя использовать с текстовым полем %s в позиции %d",
+		service.FieldErrExprContainsNotText:    "~ можно использовать только с текстовыми полями, позиция %d",
+		service.FieldErrExprListTooLong:        "список значений %s длиннее %d",
+		service.FieldErrExprNotInteger:         "%s нужно сравнивать с целым числом, позиция %d",
+		service.FieldErrExprNotDate:            "%s нужно сравнивать с датой в кавычках (2006-01-02) или временем RFC 3339, позиция %d",
+		service.FieldErrExprNotString:          "%s нужно сравнивать со строкой в кавычках, позиция %d",
+
+		// details
+		detailValidationFailed:                       "Некоторые поля заполнены неверно, они перечислены в errors",
+		detailInvalidFilter:                          "Некоторые фильтры некорректны, они перечислены в errors",
+		detailInvalidParameter:                       "Некоторые параметры некорректны, они перечислены в errors",
+		"Failed to parse json":                       "Не удалось разобрать json",
+		"Failed to parse json in updateUser handler": "Не удалось разобрать json",
+		"Failed to read import file":                 "Не удалось прочитать файл импорта",
+		"Import file is too large":                   "Файл импорта слишком большой",
+		"Format must be csv or ndjson":               "Формат должен быть csv или ndjson",
+		"Format must be csv, ndjson or json":         "Формат должен быть csv, ndjson или json",
+		"Invalid stats options":                      "Некорректные параметры статистики",
+		"Invalid cursor":                             "Некорректный курсор",
+		"Invalid fields, available fields: %s":       "Некорректные поля, доступные поля: %s",
+		"Invalid sort, sortable fields: id, name, surname, age, created_at, updated_at, nationality": "Некорректная сортировка, доступные поля: id, name, surname, age, created_at, updated_at, nationality",
+		"Id should be number":                      "Id должен быть числом",
+		"User id should be number":                 "Id пользователя должен быть числом",
+		"At least one id must be provided":         "Нужно указать хотя бы один id",
+		"Too many ids, max is %d":                  "Слишком много id, максимум %d",
+		"Interval can be only day or week":         "Интервал может быть только day или week",
+		"Page exceeds total number of pages":       "Номер страницы больше числа страниц",
+		"Search query q is required":               "Нужен поисковый запрос q",
+		"Threshold should be a number from 0 to 1": "Порог должен быть числом от 0 до 1",
+		"Failed to create user":                    "Не удалось создать пользователя",
+		"Failed to get user":                       "Не удалось получить пользователя",
+		"Failed to get users":                      "Не удалось получить пользователей",
+		"Failed to get users stats":                "Не удалось получить статистику пользователей",
+		"Failed to update user":                    "Не удалось обновить пользователя",
+		"Failed to replace user":                   "Не удалось заменить пользователя",
+		"Failed to patch user":                     "Не удалось применить патч к пользователю",
+		"Failed to read patch":                     "Не удалось прочитать патч",
+		"Content type must be application/json, application/merge-patch+json or application/json-patch+json": "Тип содержимого должен быть application/json, application/merge-patch+json или application/json-patch+json",
+		"Failed to update users":              "Не удалось обновить пользователей",
+		"Failed to delete user":               "Не удалось удалить пользователя",
+		"Failed to delete users":              "Не удалось удалить пользователей",
+		"Failed to search users":              "Не удалось найти пользователей",
+		"Failed to find duplicates":           "Не удалось найти дубликаты",
+		"Failed to merge users":               "Не удалось объединить пользователей",
+		"Failed to export users":              "Не удалось экспортировать пользователей",
+		"Failed to create attribute":          "Не удалось создать атрибут",
+		"Failed to get attribute":             "Не удалось получить атрибут",
+		"Failed to get attributes":            "Не удалось получить атрибуты",
+		"Failed to update attribute":          "Не удалось обновить атрибут",
+		"Failed to delete attribute":          "Не удалось удалить атрибут",
+		"Failed to get user tags":             "Не удалось получить теги пользователя",
+		"Failed to add user tags":             "Не удалось добавить теги пользователю",
+		"Failed to remove user tag":           "Не удалось удалить тег пользователя",
+		"Failed to tag users":                 "Не удалось изменить теги пользователей",
+		"Failed to create group":              "Не удалось создать группу",
+		"Failed to get group":                 "Не удалось получить группу",
+		"Failed to get groups":                "Не удалось получить группы",
+		"Failed to update group":              "Не удалось обновить группу",
+		"Failed to delete group":              "Не удалось удалить группу",
+		"Failed to get group members":         "Не удалось получить участников группы",
+		"Failed to set group member":          "Не удалось добавить участника группы",
+		"Failed to remove group member":       "Не удалось удалить участника группы",
+		"Failed to get user groups":           "Не удалось получить группы пользователя",
+		"Group id should be number":           "Id группы должен быть числом",
+		"Parent id should be number":          "Id родительской группы должен быть числом",
+		"recursive can be only true or false": "recursive может быть только true или false",
+		"Contact id should be number":         "Id контакта должен быть числом",
+		"Failed to get contacts":              "Не удалось получить контакты",
+		"Failed to create contact":            "Не удалось добавить контакт",
+		"Failed to update contact":            "Не удалось обновить контакт",
+		"Failed to delete contact":            "Не удалось удалить контакт",
+		"Failed to send verification code":    "Не удалось отправить код подтверждения",
+		"Failed to verify contact":            "Не удалось подтвердить контакт",
+	},
+}
+
+const (
+	detailValidationFailed = "Some fields are invalid, they are listed in errors"
+	detailInvalidFilter    = "Some filters are invalid, they are listed in errors"
+	detailInvalidParameter = "Some parameters are invalid, they are listed in errors"
+)
+
+// requestLanguage picks supported language from Accept-Language header
+func requestLanguage(c *gin.Context) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	if err != nil || len(tags) == 0 {
+		return supportedLanguages[0]
+	}
+	_, index, confidence := languageMatcher.Match(tags...)
+	if confidence == language.No {
+		return supportedLanguages[0]
+	}
+	return supportedLanguages[index]
+}
+
+// translate returns message of key in lang or fallback if catalog doesnt have it
+func translate(lang language.Tag, key, fallback string) string {
+	if message, ok := catalogs[lang][key]; ok {
+		return message
+	}
+	return fallback
+}
+
+// translateDetail formats detail in lang, args are not translated
+func translateDetail(lang language.Tag, detail string, args ...any) string {
+	detail = translate(lang, detail, detail)
+	if len(args) == 0 {
+		return detail
+	}
+	return fmt.Sprintf(detail, args...)
+}
+
+func translateFields(lang language.Tag, fields []service.FieldError) []service.FieldError {
+	translated := make([]service.FieldError, len(fields))
+	for i, f := range fields {
+		if message, ok := catalogs[lang][f.Code]; ok {
+			f.Message = message
+			if len(f.Args) > 0 {
+				f.Message = fmt.Sprintf(message, f.Args...)
+			}
+		}
+		translated[i] = f
+	}
+	return translated
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// index of detail argument of functions that send problems
+var detailArgIndex = map[string]int{
+	"newErrorResponse":        3,
+	"newServiceErrorResponse": 2,
+	"newImportErrorResponse":  3,
+	"newParamErrorResponse":   3,
+}
+
+// every detail sent by handlers must be translated, details are found in the source of the package
+func TestCatalogsHaveAllDetails(t *testing.T) {
+	details := make(map[string]token.Position)
+	consts := make(map[string]string)
+
+	fset := token.NewFileSet()
+	files := parsePackageFiles(t, fset, ".")
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.ValueSpec)
+			if !ok {
+				return true
+			}
+			for i, name := range spec.Names {
+				if i < len(spec.Values) {
+					if value, ok := stringLiteral(spec.Values[i]); ok {
+						consts[name.Name] = value
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			fn, ok := call.Fun.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			index, ok := detailArgIndex[fn.Name]
+			if !ok || len(call.Args) <= index {
+				return true
+			}
+
+			arg := call.Args[index]
+			detail, ok := stringLiteral(arg)
+			if ident, isIdent := arg.(*ast.Ident); !ok && isIdent {
+				detail, ok = consts[ident.Name]
+			}
+			if assert.True(t, ok, "detail at %s must be a string literal or constant", fset.Position(arg.Pos())) {
+				details[detail] = fset.Position(arg.Pos())
+			}
+			return true
+		})
+	}
+	require.NotEmpty(t, details)
+
+	for lang, catalog := range catalogs {
+		for detail, pos := range details {
+			translated, ok := catalog[detail]
+			if assert.True(t, ok, "%s catalog has no detail %q used at %s", lang, detail, pos) {
+				assert.Equal(t, strings.Count(detail, "%"), strings.Count(translated, "%"), "%s detail %q has other args", lang, detail)
+			}
+		}
+	}
+}
+
+func TestCatalogsHaveAllTitles(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for code := range problemTypes {
+			assert.Contains(t, catalog, code, "%s catalog has no title of %s", lang, code)
+		}
+	}
+}
+
+// field error codes are taken from FieldErr constants of services
+func TestCatalogsHaveAllFieldMessages(t *testing.T) {
+	codes := make(map[string]string)
+
+	fset := token.NewFileSet()
+	for _, file := range parsePackageFiles(t, fset, filepath.Join("..", "services")) {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.ValueSpec)
+			if !ok {
+				return true
+			}
+			for i, name := range spec.Names {
+				if !strings.HasPrefix(name.Name, "FieldErr") || i >= len(spec.Values) {
+					continue
+				}
+				if code, ok := stringLiteral(spec.Values[i]); ok {
+					codes[name.Name] = code
+				}
+			}
+			return true
+		})
+	}
+	require.NotEmpty(t, codes)
+
+	for lang, catalog := range catalogs {
+		for name, code := range codes {
+			assert.Contains(t, catalog, code, "%s catalog has no message of service.%s", lang, name)
+		}
+	}
+}
+
+func parsePackageFiles(t *testing.T, fset *token.FileSet, dir string) []*ast.File {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	require.NoError(t, err)
+
+	var files []*ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		require.NoError(t, err)
+		files = append(files, file)
+	}
+	return files
+}
+
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
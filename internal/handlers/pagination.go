@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/gin-gonic/gin"
+)
+
+// bare array of users is returned by default, clients that expect page metadata have to ask for it explicitly
+const envelopeListMediaType = "application/vnd.user-manager.envelope+json"
+
+func wantsEnvelope(c *gin.Context) bool {
+	return c.Query("envelope") == "true" || strings.Contains(c.GetHeader("Accept"), envelopeListMediaType)
+}
+
+// pageLinks keeps all query params of the request and changes only page
+func pageLinks(c *gin.Context, page, totalPages int) entities.PageLinks {
+	link := func(p int) string {
+		return urlWithQuery(c, "page", strconv.Itoa(p))
+	}
+
+	links := entities.PageLinks{
+		Self:  link(page),
+		First: link(1),
+		Last:  link(max(totalPages, 1)),
+	}
+	if page > 1 {
+		links.Prev = link(page - 1)
+	}
+	if page < totalPages {
+		links.Next = link(page + 1)
+	}
+	return links
+}
+
+func urlWithQuery(c *gin.Context, key, value string) string {
+	query := c.Request.URL.Query()
+	query.Set(key, value)
+	u := url.URL{Path: c.Request.URL.Path, RawQuery: query.Encode()}
+	return u.String()
+}
+
+// setLinkHeader sets RFC 8288 Link header, empty links are skipped
+func setLinkHeader(c *gin.Context, links map[string]string) {
+	// fixed order of relations, map iteration is random
+	var parts []string
+	for _, rel := range []string{"self", "first", "prev", "next", "last"} {
+		if target := links[rel]; target != "" {
+			parts = append(parts, fmt.Sprintf(`<%s>; rel="%s"`, target, rel))
+		}
+	}
+	if len(parts) > 0 {
+		c.Header("Link", strings.Join(parts, ", "))
+	}
+}
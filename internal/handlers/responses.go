@@ -1,17 +1,163 @@
 package handlers
 
 import (
+	"errors"
 	"log/slog"
+	"net/http"
 
+	"github.com/Util787/user-manager-api/entities"
 	"github.com/Util787/user-manager-api/internal/logger/sl"
+	"github.com/Util787/user-manager-api/internal/repository"
+	service "github.com/Util787/user-manager-api/internal/services"
 	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
 )
 
+const problemContentType = "application/problem+json"
+
+// error codes are stable, clients should check them instead of title and detail
+const (
+	codeInvalidBody       = "invalid_body"
+	codeInvalidParameter  = "invalid_parameter"
+	codeInvalidFilter     = "invalid_filter"
+	codeValidationFailed  = "validation_failed"
+	codeNotFound          = "not_found"
+	codeConflict          = "conflict"
+	codeBulkLimitExceeded = "bulk_limit_exceeded"
+	codePayloadTooLarge   = "payload_too_large"
+	codeEnrichmentFailed  = "enrichment_failed"
+	codePatchFailed       = "patch_failed"
+	codeUnsupportedMedia  = "unsupported_media_type"
+	codeTooManyRequests   = "too_many_requests"
+	codeInternal          = "internal_error"
+)
+
+type problemType struct {
+	status int
+	title  string
+}
+
+// every code has one status and title, detail describes the particular occurrence
+var problemTypes = map[string]problemType{
+	codeInvalidBody:       {http.StatusBadRequest, "Request body is malformed"},
+	codeInvalidParameter:  {http.StatusBadRequest, "Invalid path or query parameter"},
+	codeInvalidFilter:     {http.StatusBadRequest, "Invalid filter"},
+	codeValidationFailed:  {http.StatusBadRequest, "Validation failed"},
+	codeNotFound:          {http.StatusNotFound, "Resource not found"},
+	codeConflict:          {http.StatusConflict, "Conflict with existing data"},
+	codeBulkLimitExceeded: {http.StatusBadRequest, "Too many users match the filter"},
+	codePayloadTooLarge:   {http.StatusRequestEntityTooLarge, "Payload is too large"},
+	codeEnrichmentFailed:  {http.StatusBadGateway, "Enrichment apis are unreachable"},
+	codePatchFailed:       {http.StatusConflict, "Patch cant be applied to the user"},
+	codeUnsupportedMedia:  {http.StatusUnsupportedMediaType, "Unsupported content type"},
+	codeTooManyRequests:   {http.StatusTooManyRequests, "Too many requests"},
+	codeInternal:          {http.StatusInternalServerError, "Internal server error"},
+}
+
+// errorResponse is RFC 7807 problem details, it is sent with application/problem+json content type
 type errorResponse struct {
-	Message string `json:"message"`
+	Code   string `json:"code" enums:"invalid_body,invalid_parameter,invalid_filter,validation_failed,not_found,conflict,bulk_limit_exceeded,payload_too_large,enrichment_failed,patch_failed,unsupported_media_type,too_many_requests,internal_error"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+	// op id of the request, the same as in logs
+	Instance string `json:"instance,omitempty"`
+	// invalid fields of validation errors and invalid query params of invalid_filter and invalid_parameter
+	Errors []service.FieldError `json:"errors,omitempty"`
+	// rows handled before import failed, they stay imported
+	Report *entities.ImportReport `json:"report,omitempty"`
+}
+
+// newErrorResponse sends problem with code, detail is english format of the message with args.
+// Title, detail and field messages are translated to the language from Accept-Language
+func newErrorResponse(c *gin.Context, log *slog.Logger, code string, detail string, err error, args ...any) {
+	writeProblem(c, log, code, nil, err, detail, args...)
+}
+
+// newParamErrorResponse sends problem about invalid query params, they are listed in errors with typed codes
+// like invalid fields, so detail has no english text of err
+func newParamErrorResponse(c *gin.Context, log *slog.Logger, code string, detail string, err error) {
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		writeProblem(c, log, code, validationErr.Fields, err, detail)
+		return
+	}
+	writeProblem(c, log, code, nil, err, detail)
+}
+
+// newServiceErrorResponse maps errors returned by services to codes, unknown errors are internal.
+// detail tells what operation failed, validation errors are described by their fields instead
+func newServiceErrorResponse(c *gin.Context, log *slog.Logger, detail string, err error) {
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		// filters checked by services are listed the same way as filters checked by handlers
+		if serviceErrorCode(err) == codeInvalidFilter {
+			writeProblem(c, log, codeInvalidFilter, validationErr.Fields, err, detailInvalidFilter)
+			return
+		}
+		writeProblem(c, log, codeValidationFailed, validationErr.Fields, err, detailValidationFailed)
+		return
+	}
+	writeProblem(c, log, serviceErrorCode(err), nil, err, detail)
+}
+
+func serviceErrorCode(err error) string {
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		return codeNotFound
+	case errors.Is(err, service.ErrConflict):
+		return codeConflict
+	case errors.Is(err, service.ErrEnrichmentFailed):
+		return codeEnrichmentFailed
+	case errors.Is(err, repository.ErrBulkLimitExceeded):
+		return codeBulkLimitExceeded
+	case errors.Is(err, service.ErrInvalidPatch):
+		return codeInvalidBody
+	case errors.Is(err, service.ErrPatchFailed):
+		return codePatchFailed
+	case errors.Is(err, service.ErrUnsupportedPatchFormat):
+		return codeUnsupportedMedia
+	case errors.Is(err, service.ErrInvalidAttributeFilter), errors.Is(err, service.ErrInvalidFilterExpr):
+		return codeInvalidFilter
+	case errors.Is(err, service.ErrVerificationCooldown):
+		return codeTooManyRequests
+	case errors.Is(err, service.ErrInvalidStatsOptions),
+		errors.Is(err, service.ErrUnsupportedImportFormat),
+		errors.Is(err, service.ErrUnsupportedExportFormat):
+		return codeInvalidParameter
+	default:
+		return codeInternal
+	}
+}
+
+func writeProblem(c *gin.Context, log *slog.Logger, code string, fields []service.FieldError, err error, detail string, args ...any) {
+	sendProblem(c, buildProblem(c, log, code, fields, err, detail, args...))
+}
+
+// buildProblem logs the error and translates the problem, it is sent by sendProblem
+func buildProblem(c *gin.Context, log *slog.Logger, code string, fields []service.FieldError, err error, detail string, args ...any) errorResponse {
+	problem, ok := problemTypes[code]
+	if !ok {
+		code, problem = codeInternal, problemTypes[codeInternal]
+	}
+	log.Error(translateDetail(language.English, detail, args...), slog.String("code", code), sl.Err(err))
+
+	op, _ := c.Get("op")
+	instance, _ := op.(string)
+	lang := requestLanguage(c)
+
+	c.Header("Content-Language", lang.String())
+	return errorResponse{
+		Code:     code,
+		Title:    translate(lang, code, problem.title),
+		Status:   problem.status,
+		Detail:   translateDetail(lang, detail, args...),
+		Instance: instance,
+		Errors:   translateFields(lang, fields),
+	}
 }
 
-func newErrorResponse(c *gin.Context, log *slog.Logger, statusCode int, message string, err error) {
-	log.Error(message, sl.Err(err))
-	c.AbortWithStatusJSON(statusCode, errorResponse{message})
+func sendProblem(c *gin.Context, problem errorResponse) {
+	c.Header("Content-Type", problemContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
 }
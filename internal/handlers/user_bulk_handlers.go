@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Util787/user-manager-api/entities"
+	service "github.com/Util787/user-manager-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// upper bound for rows touched by one bulk request, client can only lower it with max_affected
+const maxBulkAffected = 1000
+
+// bulkUpdateUsers godoc
+// @Summary      bulk update users by filter
+// @Description  Applies the same update to every user matched by the filter. Filters are the same as in get all users plus `ids`, at least one of them is required.
+// @Description  The update runs in one transaction and is rolled back if it touches more than `max_affected` rows.
+// @Description  `attributes` cant be changed in bulk because they are saved as a whole map, they are rejected with not_allowed_in_bulk.
+// @Description
+// @Description  Example: ?surname=iv&gender=male&dry_run=true
+// @Description  Response: number of users that would be updated and a few of them as a sample
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        ids           query     string                     false "comma separated ids, example: 1,2,3"
+// @Param        name          query     string                     false "name filter"
+// @Param        surname       query     string                     false "surname filter"
+// @Param        patronymic    query     string                     false "patronymic filter"
+// @Param        gender        query     string                     false "gender filter can be only male or female"
+// @Param        attr.name     query     string                     false "custom attribute filter, name is the attribute name, example: attr.department=sales,support"
+// @Param        dry_run       query     bool                       false "only count affected users and return a sample"
+// @Param        max_affected  query     int                        false "max:1000"
+// @Param        user          body      entities.UpdateUserParams  true  "parameters for update"
+// @Success      200  {object}  entities.BulkResult
+// @Failure      400  {object}  errorResponse  "invalid_filter, invalid_parameter, invalid_body, validation_failed, bulk_limit_exceeded"
+// @Failure      409  {object}  errorResponse  "conflict: update would make full names of users equal"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /users [patch]
+func (h *Handler) bulkUpdateUsers(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	filter, err := parseBulkFilter(c)
+	if err != nil {
+		newParamErrorResponse(c, log, codeInvalidFilter, detailInvalidFilter, err)
+		return
+	}
+	opts := parseBulkOptions(c, log)
+
+	var params entities.UpdateUserParams
+	err = c.ShouldBindJSON(&params)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
+		return
+	}
+
+	log.Info("Bulk updating users", slog.Any("filter", filter), slog.Any("options", opts), slog.Any("update_params", params))
+	result, err := h.services.UserService.BulkUpdateUsers(filter, params, opts)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to update users", err)
+		return
+	}
+	// dry run changes nothing and returns no ids
+	for _, id := range result.Ids {
+		h.deleteCachedUser(log, id)
+	}
+
+	log.Info("Bulk updated users successfully", slog.Int("affected", result.Affected), slog.Bool("dry_run", result.DryRun))
+
+	c.JSON(http.StatusOK, result)
+}
+
+// bulkDeleteUsers godoc
+// @Summary      bulk delete users by filter
+// @Description  Deletes every user matched by the filter. Filters are the same as in get all users plus `ids`, at least one of them is required.
+// @Description  By default users are soft deleted (deleted_at is set), `hard=true` removes rows completely.
+// @Description  The delete runs in one transaction and is rolled back if it touches more than `max_affected` rows.
+// @Tags         users
+// @Produce      json
+// @Param        ids           query     string  false "comma separated ids, example: 1,2,3"
+// @Param        name          query     string  false "name filter"
+// @Param        surname       query     string  false "surname filter"
+// @Param        patronymic    query     string  false "patronymic filter"
+// @Param        gender        query     string  false "gender filter can be only male or female"
+// @Param        attr.name     query     string  false "custom attribute filter, name is the attribute name, example: attr.department=sales,support"
+// @Param        hard          query     bool    false "remove rows instead of soft delete"
+// @Param        dry_run       query     bool    false "only count affected users and return a sample"
+// @Param        max_affected  query     int     false "max:1000"
+// @Success      200  {object}  entities.BulkResult
+// @Failure      400  {object}  errorResponse  "invalid_filter, invalid_parameter, bulk_limit_exceeded"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /users [delete]
+func (h *Handler) bulkDeleteUsers(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	filter, err := parseBulkFilter(c)
+	if err != nil {
+		newParamErrorResponse(c, log, codeInvalidFilter, detailInvalidFilter, err)
+		return
+	}
+	opts := parseBulkOptions(c, log)
+	hard := c.DefaultQuery("hard", "false") == "true"
+
+	log.Info("Bulk deleting users", slog.Any("filter", filter), slog.Any("options", opts), slog.Bool("hard", hard))
+	result, err := h.services.UserService.BulkDeleteUsers(filter, hard, opts)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to delete users", err)
+		return
+	}
+	// dry run changes nothing and returns no ids
+	for _, id := range result.Ids {
+		h.deleteCachedUser(log, id)
+	}
+
+	log.Info("Bulk deleted users successfully", slog.Int("affected", result.Affected), slog.Bool("dry_run", result.DryRun))
+
+	c.JSON(http.StatusOK, result)
+}
+
+// empty filter is rejected so that the whole table cant be changed by accident
+func parseBulkFilter(c *gin.Context) (entities.BulkFilter, error) {
+	userFilter, err := parseUserFilter(c)
+	if err != nil {
+		return entities.BulkFilter{}, err
+	}
+	filter := entities.BulkFilter{UserFilter: userFilter}
+
+	filter.Ids, err = parseIds(c.DefaultQuery("ids", ""))
+	if err != nil {
+		return entities.BulkFilter{}, err
+	}
+
+	if len(filter.Ids) == 0 && filter.UserFilter.IsEmpty() {
+		return entities.BulkFilter{}, service.NewParamError("", service.FieldErrNoBulkFilter)
+	}
+
+	return filter, nil
+}
+
+// comma separated ids, nil if idsStr is empty
+func parseIds(idsStr string) ([]int32, error) {
+	if idsStr == "" {
+		return nil, nil
+	}
+
+	var ids []int32
+	for _, idStr := range strings.Split(idsStr, ",") {
+		id, err := parseInt32(strings.TrimSpace(idStr))
+		if err != nil {
+			return nil, service.NewParamError("ids", service.FieldErrInvalidIds)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func parseBulkOptions(c *gin.Context, log *slog.Logger) entities.BulkOptions {
+	maxAffectedStr := c.DefaultQuery("max_affected", strconv.Itoa(maxBulkAffected))
+	maxAffected, err := strconv.Atoi(maxAffectedStr)
+	if err != nil || maxAffected <= 0 || maxAffected > maxBulkAffected {
+		maxAffected = maxBulkAffected
+		log.Debug("Invalid max_affected value, set to max", slog.String("user's max_affected", maxAffectedStr))
+	}
+
+	return entities.BulkOptions{
+		DryRun:      c.DefaultQuery("dry_run", "false") == "true",
+		MaxAffected: maxAffected,
+	}
+}
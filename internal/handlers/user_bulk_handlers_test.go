@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/repository"
+	service "github.com/Util787/user-manager-api/internal/services"
+	serviceMock "github.com/Util787/user-manager-api/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_bulkUpdateUsers(t *testing.T) {
+	gender := "male"
+
+	tests := []struct {
+		testname           string
+		queryStr           string
+		inputBody          string
+		mockBehavior       func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname:  "Ok",
+			queryStr:  "?ids=1,2&surname=Iv",
+			inputBody: `{"gender":"male"}`,
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkUpdateUsers",
+					entities.BulkFilter{Ids: []int32{1, 2}, UserFilter: entities.UserFilter{Surname: "Iv"}},
+					entities.UpdateUserParams{Gender: &gender},
+					entities.BulkOptions{MaxAffected: maxBulkAffected},
+				).Return(entities.BulkResult{Affected: 2, Ids: []int32{1, 2}}, nil)
+				r.On("Delete", mock.Anything, "user:1").Return(nil)
+				r.On("Delete", mock.Anything, "user:2").Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"affected":2,"dry_run":false}`,
+		},
+		{
+			testname:  "Dry run with lowered limit",
+			queryStr:  "?gender=female&dry_run=true&max_affected=10",
+			inputBody: `{"gender":"male"}`,
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkUpdateUsers",
+					entities.BulkFilter{UserFilter: entities.UserFilter{Gender: "female"}},
+					entities.UpdateUserParams{Gender: &gender},
+					entities.BulkOptions{DryRun: true, MaxAffected: 10},
+				).Return(entities.BulkResult{Affected: 1, DryRun: true, Sample: []entities.User{{Id: 7, Name: "Anna"}}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"dry_run":true,"sample":[{"id":7`,
+		},
+		{
+			testname:  "Attribute filter",
+			queryStr:  "?attr.department=sales,support",
+			inputBody: `{"gender":"male"}`,
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkUpdateUsers",
+					entities.BulkFilter{UserFilter: entities.UserFilter{Attributes: []entities.AttributeFilter{{Name: "department", Values: []any{"sales", "support"}}}}},
+					entities.UpdateUserParams{Gender: &gender},
+					entities.BulkOptions{MaxAffected: maxBulkAffected},
+				).Return(entities.BulkResult{Affected: 1, Ids: []int32{3}}, nil)
+				r.On("Delete", mock.Anything, "user:3").Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"affected":1,"dry_run":false}`,
+		},
+		{
+			testname:  "Unknown attribute filter",
+			queryStr:  "?attr.salary=100",
+			inputBody: `{"gender":"male"}`,
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkUpdateUsers", mock.Anything, mock.Anything, mock.Anything).Return(entities.BulkResult{}, fmt.Errorf("%w: unknown attribute %q", service.ErrInvalidAttributeFilter, "salary"))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"code":"invalid_filter"`,
+		},
+		{
+			testname:           "Empty filter",
+			queryStr:           "",
+			inputBody:          `{"gender":"male"}`,
+			mockBehavior:       func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "at least one filter or ids must be provided",
+		},
+		{
+			testname:           "Invalid ids",
+			queryStr:           "?ids=1,a",
+			inputBody:          `{"gender":"male"}`,
+			mockBehavior:       func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"errors":[{"field":"ids","code":"invalid_ids"`,
+		},
+		{
+			testname:  "No fields to update",
+			queryStr:  "?ids=1",
+			inputBody: `{}`,
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkUpdateUsers", mock.Anything, entities.UpdateUserParams{}, mock.Anything).Return(entities.BulkResult{}, &service.ValidationError{
+					Fields: []service.FieldError{{Code: service.FieldErrNoFields, Message: "no fields to update"}},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"errors":[{"code":"no_fields","message":"no fields to update"}]`,
+		},
+		{
+			testname:  "Invalid name",
+			queryStr:  "?ids=1",
+			inputBody: `{"name":"john"}`,
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkUpdateUsers", mock.Anything, mock.Anything, mock.Anything).Return(entities.BulkResult{}, &service.ValidationError{
+					Fields: []service.FieldError{{Field: "name", Code: service.FieldErrNameNotCapitalized, Message: "must start with a capital letter"}},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"errors":[{"field":"name"`,
+		},
+		{
+			testname:  "Limit exceeded",
+			queryStr:  "?gender=male",
+			inputBody: `{"gender":"male"}`,
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkUpdateUsers", mock.Anything, mock.Anything, mock.Anything).Return(entities.BulkResult{}, repository.ErrBulkLimitExceeded)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"code":"bulk_limit_exceeded"`,
+		},
+		{
+			testname:  "Full name conflict",
+			queryStr:  "?ids=1,2",
+			inputBody: `{"name":"Ivan"}`,
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkUpdateUsers", mock.Anything, mock.Anything, mock.Anything).Return(entities.BulkResult{}, fmt.Errorf("%w: %w", service.ErrConflict, repository.ErrUserExists))
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `"code":"conflict","title":"Conflict with existing data","status":409,"detail":"Failed to update users"`,
+		},
+		{
+			testname:  "Service error",
+			queryStr:  "?gender=male",
+			inputBody: `{"gender":"male"}`,
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkUpdateUsers", mock.Anything, mock.Anything, mock.Anything).Return(entities.BulkResult{}, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   "Failed to update users",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockUserService := serviceMock.NewMockUserService(t)
+			mockRedisService := serviceMock.NewMockRedisService(t)
+			router := setupTestRouter(mockUserService, nil, mockRedisService)
+
+			test.mockBehavior(mockUserService, mockRedisService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/users"+test.queryStr, bytes.NewBufferString(test.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
+
+func TestHandler_bulkDeleteUsers(t *testing.T) {
+	tests := []struct {
+		testname           string
+		queryStr           string
+		mockBehavior       func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname: "Soft delete by default",
+			queryStr: "?name=Al",
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkDeleteUsers", entities.BulkFilter{UserFilter: entities.UserFilter{Name: "Al"}}, false, entities.BulkOptions{MaxAffected: maxBulkAffected}).Return(entities.BulkResult{Affected: 3}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"affected":3,"dry_run":false}`,
+		},
+		{
+			testname: "Hard delete by ids",
+			queryStr: "?ids=4,5&hard=true",
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkDeleteUsers", entities.BulkFilter{Ids: []int32{4, 5}}, true, entities.BulkOptions{MaxAffected: maxBulkAffected}).Return(entities.BulkResult{Affected: 2, Ids: []int32{4, 5}}, nil)
+				r.On("Delete", mock.Anything, "user:4").Return(nil)
+				r.On("Delete", mock.Anything, "user:5").Return(errors.New("redis error"))
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"affected":2,"dry_run":false}`,
+		},
+		{
+			testname: "Max affected above cap is clamped",
+			queryStr: "?name=Al&max_affected=100000",
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkDeleteUsers", entities.BulkFilter{UserFilter: entities.UserFilter{Name: "Al"}}, false, entities.BulkOptions{MaxAffected: maxBulkAffected}).Return(entities.BulkResult{Affected: 3}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"affected":3`,
+		},
+		{
+			testname:           "Empty filter",
+			queryStr:           "?hard=true",
+			mockBehavior:       func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "at least one filter or ids must be provided",
+		},
+		{
+			testname:           "Invalid gender",
+			queryStr:           "?gender=unknown",
+			mockBehavior:       func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"errors":[{"field":"gender","code":"invalid_gender"`,
+		},
+		{
+			testname: "Limit exceeded",
+			queryStr: "?gender=male",
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkDeleteUsers", mock.Anything, false, mock.Anything).Return(entities.BulkResult{}, repository.ErrBulkLimitExceeded)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"code":"bulk_limit_exceeded"`,
+		},
+		{
+			testname: "Service error",
+			queryStr: "?gender=male",
+			mockBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("BulkDeleteUsers", mock.Anything, false, mock.Anything).Return(entities.BulkResult{}, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   "Failed to delete users",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockUserService := serviceMock.NewMockUserService(t)
+			mockRedisService := serviceMock.NewMockRedisService(t)
+			router := setupTestRouter(mockUserService, nil, mockRedisService)
+
+			test.mockBehavior(mockUserService, mockRedisService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/users"+test.queryStr, nil)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
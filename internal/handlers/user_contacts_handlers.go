@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/gin-gonic/gin"
+)
+
+// emails and phones share handlers, kind of contacts is set by the route
+const contactKindKey = "contact_kind"
+
+func withContactKind(kind string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(contactKindKey, kind)
+		c.Next()
+	}
+}
+
+// getContacts godoc
+// @Summary      get emails or phones of user
+// @Description  Contacts of the user, the primary one first
+// @Tags         contacts
+// @Produce      json
+// @Param        user_id  path      int  true "user_id"
+// @Success      200      {array}   entities.Contact
+// @Failure      400      {object}  errorResponse  "invalid_parameter"
+// @Failure      404      {object}  errorResponse  "not_found"
+// @Failure      500      {object}  errorResponse  "internal_error"
+// @Router       /users/{user_id}/emails [get]
+// @Router       /users/{user_id}/phones [get]
+func (h *Handler) getContacts(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+	kind := c.GetString(contactKindKey)
+
+	userId32, err := parseInt32(c.Param("user_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
+		return
+	}
+
+	contacts, err := h.services.ContactService.GetContacts(kind, userId32)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to get contacts", err)
+		return
+	}
+
+	log.Info("Got contacts successfully", slog.Int("user_id", int(userId32)), slog.String("kind", kind), slog.Int("count", len(contacts)))
+	c.JSON(http.StatusOK, contacts)
+}
+
+// createContact godoc
+// @Summary      add email or phone to user
+// @Description  Emails must be RFC 5322 addresses without display name, domains are lowercased. Phones must be in international format, spaces, dashes, dots and parentheses are removed and they are saved in E.164 (+79991234567).
+// @Description  Types of emails are personal, work and other, types of phones are mobile, home, work and other (other if empty).
+// @Description  Emails (case insensitive) and phones are unique across all users. The first contact of a kind is primary, primary=true moves the flag from the current primary contact
+// @Tags         contacts
+// @Accept       json
+// @Produce      json
+// @Param        user_id  path      int                           true "user_id"
+// @Param        contact  body      entities.CreateContactParams  true "contact to add"
+// @Success      201      {object}  entities.Contact
+// @Failure      400      {object}  errorResponse  "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors"
+// @Failure      404      {object}  errorResponse  "not_found"
+// @Failure      409      {object}  errorResponse  "conflict: the email or phone is used by a user"
+// @Failure      500      {object}  errorResponse  "internal_error"
+// @Router       /users/{user_id}/emails [post]
+// @Router       /users/{user_id}/phones [post]
+func (h *Handler) createContact(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+	kind := c.GetString(contactKindKey)
+
+	userId32, err := parseInt32(c.Param("user_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
+		return
+	}
+
+	var params entities.CreateContactParams
+	err = c.ShouldBindJSON(&params)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
+		return
+	}
+
+	log.Info("Creating contact", slog.Int("user_id", int(userId32)), slog.String("kind", kind), slog.String("type", params.Type))
+	contact, err := h.services.ContactService.CreateContact(kind, userId32, params)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to create contact", err)
+		return
+	}
+
+	log.Info("Contact created successfully", slog.Int("user_id", int(userId32)), slog.Int("contact_id", int(contact.Id)))
+	c.JSON(http.StatusCreated, contact)
+}
+
+// updateContact godoc
+// @Summary      update email or phone of user
+// @Description  Only type and primary flag can be changed, add a new contact to change the value. primary=true makes the contact primary instead of the current one
+// @Tags         contacts
+// @Accept       json
+// @Produce      json
+// @Param        user_id     path      int                           true "user_id"
+// @Param        contact_id  path      int                           true "contact_id"
+// @Param        contact     body      entities.UpdateContactParams  true "fields to change"
+// @Success      200         {object}  entities.Contact
+// @Failure      400         {object}  errorResponse  "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors"
+// @Failure      404         {object}  errorResponse  "not_found"
+// @Failure      500         {object}  errorResponse  "internal_error"
+// @Router       /users/{user_id}/emails/{contact_id} [patch]
+// @Router       /users/{user_id}/phones/{contact_id} [patch]
+func (h *Handler) updateContact(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+	kind := c.GetString(contactKindKey)
+
+	userId32, contactId32, ok := parseContactIds(c, log)
+	if !ok {
+		return
+	}
+
+	var params entities.UpdateContactParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
+		return
+	}
+
+	log.Info("Updating contact", slog.Int("user_id", int(userId32)), slog.Int("contact_id", int(contactId32)), slog.String("kind", kind), slog.Any("update_params", params))
+	contact, err := h.services.ContactService.UpdateContact(kind, userId32, contactId32, params)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to update contact", err)
+		return
+	}
+
+	log.Info("Updated contact successfully", slog.Int("user_id", int(userId32)), slog.Int("contact_id", int(contactId32)))
+	c.JSON(http.StatusOK, contact)
+}
+
+// deleteContact godoc
+// @Summary      delete email or phone of user
+// @Description  If the primary contact is deleted the oldest remaining contact of the kind becomes primary
+// @Tags         contacts
+// @Produce      json
+// @Param        user_id     path      int  true "user_id"
+// @Param        contact_id  path      int  true "contact_id"
+// @Success      200         {object}  map[string]string  "successful deleting message"
+// @Failure      400         {object}  errorResponse  "invalid_parameter"
+// @Failure      404         {object}  errorResponse  "not_found"
+// @Failure      500         {object}  errorResponse  "internal_error"
+// @Router       /users/{user_id}/emails/{contact_id} [delete]
+// @Router       /users/{user_id}/phones/{contact_id} [delete]
+func (h *Handler) deleteContact(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+	kind := c.GetString(contactKindKey)
+
+	userId32, contactId32, ok := parseContactIds(c, log)
+	if !ok {
+		return
+	}
+
+	log.Info("Deleting contact", slog.Int("user_id", int(userId32)), slog.Int("contact_id", int(contactId32)), slog.String("kind", kind))
+	err := h.services.ContactService.DeleteContact(kind, userId32, contactId32)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to delete contact", err)
+		return
+	}
+
+	log.Info("Deleted contact successfully", slog.Int("user_id", int(userId32)), slog.Int("contact_id", int(contactId32)))
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Contact with id:%d deleted successfully", contactId32)})
+}
+
+// sendVerificationCode godoc
+// @Summary      send verification code to email or phone
+// @Description  Sends a one-time 6 digit code to the contact, the code expires in 10 minutes and allows 5 attempts. A new code replaces the previous one and can be requested once a minute
+// @Tags         contacts
+// @Produce      json
+// @Param        user_id     path      int  true "user_id"
+// @Param        contact_id  path      int  true "contact_id"
+// @Success      202         {object}  entities.VerificationSent
+// @Failure      400         {object}  errorResponse  "invalid_parameter"
+// @Failure      404         {object}  errorResponse  "not_found"
+// @Failure      409         {object}  errorResponse  "conflict: contact is already verified"
+// @Failure      429         {object}  errorResponse  "too_many_requests: code was sent less than a minute ago"
+// @Failure      500         {object}  errorResponse  "internal_error"
+// @Router       /users/{user_id}/emails/{contact_id}/verification [post]
+// @Router       /users/{user_id}/phones/{contact_id}/verification [post]
+func (h *Handler) sendVerificationCode(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+	kind := c.GetString(contactKindKey)
+
+	userId32, contactId32, ok := parseContactIds(c, log)
+	if !ok {
+		return
+	}
+
+	log.Info("Sending verification code", slog.Int("user_id", int(userId32)), slog.Int("contact_id", int(contactId32)), slog.String("kind", kind))
+	sent, err := h.services.ContactService.SendVerificationCode(context.Background(), kind, userId32, contactId32)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to send verification code", err)
+		return
+	}
+
+	log.Info("Sent verification code successfully", slog.Int("user_id", int(userId32)), slog.Int("contact_id", int(contactId32)))
+	c.JSON(http.StatusAccepted, sent)
+}
+
+// verifyContact godoc
+// @Summary      verify email or phone by code
+// @Description  Marks the contact verified if the code is right. Wrong and expired codes are validation errors of code, after 5 wrong attempts a new code must be requested
+// @Tags         contacts
+// @Accept       json
+// @Produce      json
+// @Param        user_id     path      int                           true "user_id"
+// @Param        contact_id  path      int                           true "contact_id"
+// @Param        code        body      entities.VerifyContactParams  true "code from the message"
+// @Success      200         {object}  entities.Contact
+// @Failure      400         {object}  errorResponse  "invalid_parameter, invalid_body, validation_failed: invalid_verification_code"
+// @Failure      404         {object}  errorResponse  "not_found"
+// @Failure      409         {object}  errorResponse  "conflict: contact is already verified"
+// @Failure      500         {object}  errorResponse  "internal_error"
+// @Router       /users/{user_id}/emails/{contact_id}/verification/confirm [post]
+// @Router       /users/{user_id}/phones/{contact_id}/verification/confirm [post]
+func (h *Handler) verifyContact(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+	kind := c.GetString(contactKindKey)
+
+	userId32, contactId32, ok := parseContactIds(c, log)
+	if !ok {
+		return
+	}
+
+	var params entities.VerifyContactParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
+		return
+	}
+
+	log.Info("Verifying contact", slog.Int("user_id", int(userId32)), slog.Int("contact_id", int(contactId32)), slog.String("kind", kind))
+	contact, err := h.services.ContactService.VerifyContact(context.Background(), kind, userId32, contactId32, params.Code)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to verify contact", err)
+		return
+	}
+
+	log.Info("Verified contact successfully", slog.Int("user_id", int(userId32)), slog.Int("contact_id", int(contactId32)))
+	c.JSON(http.StatusOK, contact)
+}
+
+// parseContactIds sends error response if user_id or contact_id isnt a number
+func parseContactIds(c *gin.Context, log *slog.Logger) (userId, contactId int32, ok bool) {
+	userId, err := parseInt32(c.Param("user_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "User id should be number", err)
+		return 0, 0, false
+	}
+	contactId, err = parseInt32(c.Param("contact_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Contact id should be number", err)
+		return 0, 0, false
+	}
+	return userId, contactId, true
+}
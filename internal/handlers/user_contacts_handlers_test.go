@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/logger/handlers/slogdiscard"
+	"github.com/Util787/user-manager-api/internal/repository"
+	service "github.com/Util787/user-manager-api/internal/services"
+	serviceMock "github.com/Util787/user-manager-api/internal/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_contacts(t *testing.T) {
+	email := entities.Contact{Id: 3, UserId: 1, Value: "ivan@example.com", Type: entities.ContactTypeWork, Primary: true}
+	phone := entities.Contact{Id: 4, UserId: 1, Value: "+79991234567", Type: entities.ContactTypeMobile, Primary: true}
+	personal := entities.ContactTypePersonal
+
+	tests := []struct {
+		testname           string
+		method             string
+		path               string
+		body               string
+		mockBehavior       func(s *serviceMock.MockContactService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname: "Get emails",
+			method:   "GET",
+			path:     "/users/1/emails",
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("GetContacts", entities.ContactKindEmail, int32(1)).Return([]entities.Contact{email}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `[{"id":3,"user_id":1,"value":"ivan@example.com","type":"work","primary":true,"verified_at":null`,
+		},
+		{
+			testname: "Get phones of missing user",
+			method:   "GET",
+			path:     "/users/9/phones",
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("GetContacts", entities.ContactKindPhone, int32(9)).Return(nil, fmt.Errorf("%w: %w", service.ErrNotFound, repository.ErrUserNotFound))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `"code":"not_found"`,
+		},
+		{
+			testname: "Create phone",
+			method:   "POST",
+			path:     "/users/1/phones",
+			body:     `{"value":"+7 (999) 123-45-67","type":"mobile"}`,
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("CreateContact", entities.ContactKindPhone, int32(1), entities.CreateContactParams{Value: "+7 (999) 123-45-67", Type: "mobile"}).Return(phone, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse:   `"value":"+79991234567","type":"mobile","primary":true`,
+		},
+		{
+			testname:           "Create without value",
+			method:             "POST",
+			path:               "/users/1/emails",
+			body:               `{"type":"work"}`,
+			mockBehavior:       func(s *serviceMock.MockContactService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"code":"invalid_body"`,
+		},
+		{
+			testname: "Create invalid email",
+			method:   "POST",
+			path:     "/users/1/emails",
+			body:     `{"value":"Ivan <ivan@example.com>"}`,
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("CreateContact", entities.ContactKindEmail, int32(1), mock.Anything).Return(entities.Contact{}, &service.ValidationError{
+					Fields: []service.FieldError{{Field: "value", Code: service.FieldErrInvalidEmail, Message: "must be a valid email address without display name"}},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"errors":[{"field":"value","code":"invalid_email"`,
+		},
+		{
+			testname: "Create used email",
+			method:   "POST",
+			path:     "/users/1/emails",
+			body:     `{"value":"ivan@example.com"}`,
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("CreateContact", entities.ContactKindEmail, int32(1), mock.Anything).Return(entities.Contact{}, fmt.Errorf("%w: %w", service.ErrConflict, repository.ErrContactExists))
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   "Failed to create contact",
+		},
+		{
+			testname: "Update email",
+			method:   "PATCH",
+			path:     "/users/1/emails/3",
+			body:     `{"type":"personal","primary":true}`,
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("UpdateContact", entities.ContactKindEmail, int32(1), int32(3), entities.UpdateContactParams{Type: &personal, Primary: true}).
+					Return(entities.Contact{Id: 3, UserId: 1, Value: "ivan@example.com", Type: personal, Primary: true}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"type":"personal","primary":true`,
+		},
+		{
+			testname:           "Update with invalid contact id",
+			method:             "PATCH",
+			path:               "/users/1/emails/abc",
+			body:               `{"primary":true}`,
+			mockBehavior:       func(s *serviceMock.MockContactService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Contact id should be number",
+		},
+		{
+			testname: "Delete phone",
+			method:   "DELETE",
+			path:     "/users/1/phones/4",
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("DeleteContact", entities.ContactKindPhone, int32(1), int32(4)).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   "Contact with id:4 deleted successfully",
+		},
+		{
+			testname: "Delete missing contact",
+			method:   "DELETE",
+			path:     "/users/1/phones/8",
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("DeleteContact", entities.ContactKindPhone, int32(1), int32(8)).Return(fmt.Errorf("%w: %w", service.ErrNotFound, repository.ErrContactNotFound))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   "Failed to delete contact",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockContactService := serviceMock.NewMockContactService(t)
+			router := setupContactsTestRouter(mockContactService)
+
+			test.mockBehavior(mockContactService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.path, bytes.NewBufferString(test.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
+
+func TestHandler_contactVerification(t *testing.T) {
+	expiresAt := time.Date(2025, 1, 1, 12, 10, 0, 0, time.UTC)
+	verifiedAt := time.Date(2025, 1, 1, 12, 5, 0, 0, time.UTC)
+
+	tests := []struct {
+		testname           string
+		path               string
+		body               string
+		mockBehavior       func(s *serviceMock.MockContactService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname: "Send code",
+			path:     "/users/1/emails/3/verification",
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("SendVerificationCode", mock.Anything, entities.ContactKindEmail, int32(1), int32(3)).Return(entities.VerificationSent{ExpiresAt: expiresAt}, nil)
+			},
+			expectedStatusCode: http.StatusAccepted,
+			expectedResponse:   `{"expires_at":"2025-01-01T12:10:00Z"}`,
+		},
+		{
+			testname: "Send code too often",
+			path:     "/users/1/phones/4/verification",
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("SendVerificationCode", mock.Anything, entities.ContactKindPhone, int32(1), int32(4)).Return(entities.VerificationSent{}, service.ErrVerificationCooldown)
+			},
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedResponse:   `"code":"too_many_requests"`,
+		},
+		{
+			testname: "Send code to verified contact",
+			path:     "/users/1/emails/3/verification",
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("SendVerificationCode", mock.Anything, entities.ContactKindEmail, int32(1), int32(3)).Return(entities.VerificationSent{}, fmt.Errorf("%w: email is already verified", service.ErrConflict))
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   "Failed to send verification code",
+		},
+		{
+			testname: "Send code with notifier error",
+			path:     "/users/1/emails/3/verification",
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("SendVerificationCode", mock.Anything, entities.ContactKindEmail, int32(1), int32(3)).Return(entities.VerificationSent{}, errors.New("smtp error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `"code":"internal_error"`,
+		},
+		{
+			testname: "Verify",
+			path:     "/users/1/emails/3/verification/confirm",
+			body:     `{"code":"123456"}`,
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("VerifyContact", mock.Anything, entities.ContactKindEmail, int32(1), int32(3), "123456").
+					Return(entities.Contact{Id: 3, UserId: 1, Value: "ivan@example.com", Type: entities.ContactTypeWork, Verified_at: &verifiedAt}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"verified_at":"2025-01-01T12:05:00Z"`,
+		},
+		{
+			testname: "Verify with wrong code",
+			path:     "/users/1/phones/4/verification/confirm",
+			body:     `{"code":"000000"}`,
+			mockBehavior: func(s *serviceMock.MockContactService) {
+				s.On("VerifyContact", mock.Anything, entities.ContactKindPhone, int32(1), int32(4), "000000").Return(entities.Contact{}, &service.ValidationError{
+					Fields: []service.FieldError{{Field: "code", Code: service.FieldErrInvalidVerificationCode, Message: "is wrong or expired, request a new code"}},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"errors":[{"field":"code","code":"invalid_verification_code"`,
+		},
+		{
+			testname:           "Verify without code",
+			path:               "/users/1/phones/4/verification/confirm",
+			body:               `{}`,
+			mockBehavior:       func(s *serviceMock.MockContactService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"code":"invalid_body"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockContactService := serviceMock.NewMockContactService(t)
+			router := setupContactsTestRouter(mockContactService)
+
+			test.mockBehavior(mockContactService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", test.path, bytes.NewBufferString(test.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
+
+func setupContactsTestRouter(mockContactService *serviceMock.MockContactService) *gin.Engine {
+	logger := slogdiscard.NewDiscardLogger()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	h := NewHandlers(&service.Service{ContactService: mockContactService}, logger)
+
+	emails := router.Group("/users/:user_id/emails", withContactKind(entities.ContactKindEmail))
+	emails.GET("", h.getContacts)
+	emails.POST("", h.createContact)
+	emails.PATCH("/:contact_id", h.updateContact)
+	emails.DELETE("/:contact_id", h.deleteContact)
+	emails.POST("/:contact_id/verification", h.sendVerificationCode)
+	emails.POST("/:contact_id/verification/confirm", h.verifyContact)
+
+	phones := router.Group("/users/:user_id/phones", withContactKind(entities.ContactKindPhone))
+	phones.GET("", h.getContacts)
+	phones.POST("", h.createContact)
+	phones.PATCH("/:contact_id", h.updateContact)
+	phones.DELETE("/:contact_id", h.deleteContact)
+	phones.POST("/:contact_id/verification", h.sendVerificationCode)
+	phones.POST("/:contact_id/verification/confirm", h.verifyContact)
+
+	return router
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultDuplicatesThreshold = 0.7
+	defaultDuplicatesLimit     = 20
+	maxDuplicatesLimit         = 100
+)
+
+// findDuplicates godoc
+// @Summary      find probable duplicates
+// @Description  Returns pairs of users that are probably the same person, ordered by `score` from 0 to 1.
+// @Description  Score is weighted similarity of transliterated name parts (surname 0.4, name 0.3, patronymic 0.1)
+// @Description  plus matching enrichment data (nationality 0.1, gender 0.05, age ±1 year 0.05), `matches` lists equal fields.
+// @Description  Only users with similar surnames are compared.
+// @Description
+// @Description  Found pairs can be merged with POST /users/merge
+// @Tags         users
+// @Produce      json
+// @Param        threshold  query     number  false "min score, from 0 to 1, default 0.7"
+// @Param        limit      query     int     false "default 20, max 100"
+// @Param        user_id    query     int     false "only duplicates of this user"
+// @Success      200  {object}  entities.DuplicatesResponse
+// @Failure      400  {object}  errorResponse  "invalid_parameter"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /users/duplicates [get]
+func (h *Handler) findDuplicates(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	//validation
+	threshold := defaultDuplicatesThreshold
+	if thresholdStr := c.DefaultQuery("threshold", ""); thresholdStr != "" {
+		var err error
+		threshold, err = strconv.ParseFloat(thresholdStr, 64)
+		if err != nil || threshold < 0 || threshold > 1 {
+			newErrorResponse(c, log, codeInvalidParameter, "Threshold should be a number from 0 to 1", errors.New("invalid threshold"))
+			return
+		}
+	}
+
+	var userId int32
+	if userIdStr := c.DefaultQuery("user_id", ""); userIdStr != "" {
+		var err error
+		userId, err = parseInt32(userIdStr)
+		if err != nil {
+			newErrorResponse(c, log, codeInvalidParameter, "User id should be number", err)
+			return
+		}
+	}
+
+	limitStr := c.DefaultQuery("limit", strconv.Itoa(defaultDuplicatesLimit))
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 {
+		limit = defaultDuplicatesLimit
+		log.Debug("Invalid limit value, set to default", slog.String("user's limit", limitStr))
+	}
+	if limit > maxDuplicatesLimit {
+		limit = maxDuplicatesLimit
+		log.Debug("limit is greater than max, set to max", slog.String("user's limit", limitStr))
+	}
+
+	log.Info("Finding duplicates", slog.Float64("threshold", threshold), slog.Int("limit", limit), slog.Int("user_id", int(userId)))
+	candidates, err := h.services.UserService.FindDuplicates(threshold, limit, userId)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to find duplicates", err)
+		return
+	}
+
+	log.Info("Found duplicates successfully", slog.Int("count", len(candidates)))
+
+	c.JSON(http.StatusOK, entities.DuplicatesResponse{
+		Threshold: threshold,
+		Data:      candidates,
+	})
+}
+
+// mergeUsers godoc
+// @Summary      merge duplicate users
+// @Description  Merges source user into target field by field, source user is soft deleted.
+// @Description  `prefer` tells whose value to keep for name, surname, patronymic, age, gender and nationality: target or source.
+// @Description  Fields that arent listed keep target value, empty target values are taken from source.
+// @Description  Both users are saved to merge history as they were before the merge.
+// @Description
+// @Description  Example: {"target_id":1,"source_id":2,"prefer":{"patronymic":"source","age":"source"}}
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        merge  body      entities.MergeUsersParams  true  "users to merge"
+// @Success      200  {object}  entities.User  "target user after merge"
+// @Failure      400  {object}  errorResponse  "invalid_body, validation_failed"
+// @Failure      404  {object}  errorResponse  "not_found: target or source user"
+// @Failure      409  {object}  errorResponse  "conflict: another user already has merged full name"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /users/merge [post]
+func (h *Handler) mergeUsers(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	var params entities.MergeUsersParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
+		return
+	}
+
+	log.Info("Merging users", slog.Any("params", params))
+	user, err := h.services.UserService.MergeUsers(params)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to merge users", err)
+		return
+	}
+
+	//both users changed, cached copies are removed
+	for _, id := range []int32{params.TargetId, params.SourceId} {
+		h.deleteCachedUser(log, id)
+	}
+
+	log.Info("Merged users successfully", slog.Int("target_id", int(params.TargetId)), slog.Int("source_id", int(params.SourceId)))
+
+	c.JSON(http.StatusOK, user)
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/repository"
+	service "github.com/Util787/user-manager-api/internal/services"
+	serviceMock "github.com/Util787/user-manager-api/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_findDuplicates(t *testing.T) {
+	tests := []struct {
+		testname           string
+		queryStr           string
+		mockBehavior       func(s *serviceMock.MockUserService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname: "Ok with defaults",
+			queryStr: "",
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("FindDuplicates", defaultDuplicatesThreshold, defaultDuplicatesLimit, int32(0)).Return([]entities.DuplicateCandidate{
+					{
+						First:   entities.User{Id: 1, Name: "Ivan", Surname: "Ivanov"},
+						Second:  entities.User{Id: 7, Name: "Иван", Surname: "Иванов"},
+						Score:   0.9,
+						Matches: []string{"name", "surname"},
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"surname":"Иванов","patronymic":"","age":0,"gender":"","nationality":""},"score":0.9,"matches":["name","surname"]}]`,
+		},
+		{
+			testname: "Duplicates of one user with limit above max",
+			queryStr: "?user_id=5&threshold=0.5&limit=1000",
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("FindDuplicates", 0.5, maxDuplicatesLimit, int32(5)).Return([]entities.DuplicateCandidate{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"threshold":0.5,"data":[]}`,
+		},
+		{
+			testname:           "Threshold out of range",
+			queryStr:           "?threshold=-0.1",
+			mockBehavior:       func(s *serviceMock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Threshold should be a number from 0 to 1",
+		},
+		{
+			testname:           "Invalid user id",
+			queryStr:           "?user_id=abc",
+			mockBehavior:       func(s *serviceMock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "User id should be number",
+		},
+		{
+			testname: "Service error",
+			queryStr: "",
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("FindDuplicates", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   "Failed to find duplicates",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockUserService := serviceMock.NewMockUserService(t)
+			router := setupTestRouter(mockUserService, nil, nil)
+
+			test.mockBehavior(mockUserService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/users/duplicates"+test.queryStr, nil)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
+
+func TestHandler_mergeUsers(t *testing.T) {
+	tests := []struct {
+		testname           string
+		inputBody          string
+		mockBehavior       func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname:  "Ok",
+			inputBody: `{"target_id":1,"source_id":2,"prefer":{"age":"source"}}`,
+			mockBehavior: func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				u.On("MergeUsers", entities.MergeUsersParams{TargetId: 1, SourceId: 2, Prefer: map[string]string{"age": "source"}}).
+					Return(entities.User{Id: 1, Name: "Ivan", Age: 30}, nil)
+				r.On("Delete", mock.Anything, "user:1").Return(nil)
+				r.On("Delete", mock.Anything, "user:2").Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"name":"Ivan","surname":"","patronymic":"","age":30`,
+		},
+		{
+			testname:  "Cache error doesnt fail merge",
+			inputBody: `{"target_id":1,"source_id":2}`,
+			mockBehavior: func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				u.On("MergeUsers", mock.Anything).Return(entities.User{Id: 1}, nil)
+				r.On("Delete", mock.Anything, mock.Anything).Return(errors.New("redis error"))
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"id":1`,
+		},
+		{
+			testname:           "Missing source id",
+			inputBody:          `{"target_id":1}`,
+			mockBehavior:       func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Failed to parse json",
+		},
+		{
+			testname:  "Invalid merge",
+			inputBody: `{"target_id":1,"source_id":1}`,
+			mockBehavior: func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				u.On("MergeUsers", mock.Anything).Return(entities.User{}, &service.ValidationError{
+					Fields: []service.FieldError{{Field: "source_id", Code: service.FieldErrSelfMerge, Message: "user cant be merged into itself"}},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"errors":[{"field":"source_id","code":"self_merge","message":"user cant be merged into itself"}]`,
+		},
+		{
+			testname:  "User not found",
+			inputBody: `{"target_id":1,"source_id":2}`,
+			mockBehavior: func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				u.On("MergeUsers", mock.Anything).Return(entities.User{}, fmt.Errorf("%w: %w", service.ErrNotFound, repository.ErrUserNotFound))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `"code":"not_found","title":"Resource not found","status":404,"detail":"Failed to merge users"`,
+		},
+		{
+			testname:  "Merged full name is taken",
+			inputBody: `{"target_id":1,"source_id":2,"prefer":{"name":"source"}}`,
+			mockBehavior: func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				u.On("MergeUsers", mock.Anything).Return(entities.User{}, fmt.Errorf("%w: %w", service.ErrConflict, repository.ErrUserExists))
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `"code":"conflict"`,
+		},
+		{
+			testname:  "Service error",
+			inputBody: `{"target_id":1,"source_id":2}`,
+			mockBehavior: func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				u.On("MergeUsers", mock.Anything).Return(entities.User{}, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   "Failed to merge users",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockUserService := serviceMock.NewMockUserService(t)
+			mockRedisService := serviceMock.NewMockRedisService(t)
+			router := setupTestRouter(mockUserService, nil, mockRedisService)
+
+			test.mockBehavior(mockUserService, mockRedisService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/users/merge", bytes.NewBufferString(test.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
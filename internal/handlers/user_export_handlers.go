@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Util787/user-manager-api/internal/logger/sl"
+	service "github.com/Util787/user-manager-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+var exportContentTypes = map[string]string{
+	service.ExportFormatCSV:    "text/csv; charset=utf-8",
+	service.ExportFormatNDJSON: "application/x-ndjson",
+	service.ExportFormatJSON:   "application/json; charset=utf-8",
+}
+
+// exportUsers godoc
+// @Summary      export users as csv, ndjson or json
+// @Description  Streams all users matched by filters (same as in get all users) without pagination. Rows are read from db by cursor so the size of export is not limited by memory.
+// @Description
+// @Description  Example: ?format=csv&surname=iv
+// @Tags         users
+// @Produce      json
+// @Produce      plain
+// @Param        format      query     string  false "csv, ndjson or json (default)"
+// @Param        name        query     string  false "name filter"
+// @Param        surname     query     string  false "surname filter"
+// @Param        patronymic  query     string  false "patronymic filter"
+// @Param        gender      query     string  false "gender filter can be only male or female"
+// @Param        attr.name   query     string  false "custom attribute filter, name is the attribute name, example: attr.department=sales,support"
+// @Success      200  {array}   entities.User
+// @Failure      400  {object}  errorResponse  "invalid_parameter, invalid_filter"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /users/export [get]
+func (h *Handler) exportUsers(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	format := c.DefaultQuery("format", service.ExportFormatJSON)
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		newErrorResponse(c, log, codeInvalidParameter, "Format must be csv, ndjson or json", service.ErrUnsupportedExportFormat)
+		return
+	}
+
+	filter, err := parseUserFilter(c)
+	if err != nil {
+		newParamErrorResponse(c, log, codeInvalidFilter, detailInvalidFilter, err)
+		return
+	}
+
+	// big exports take longer than server write timeout
+	err = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	if err != nil {
+		log.Debug("Failed to reset write deadline", sl.Err(err))
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", `attachment; filename="users.`+format+`"`)
+	c.Status(http.StatusOK)
+
+	log.Info("Exporting users", slog.String("format", format), slog.Any("filter", filter))
+	err = h.services.ExportService.ExportUsers(c.Request.Context(), c.Writer, format, filter)
+	if err != nil {
+		// status and part of the body may be already sent, so only log here
+		if c.Writer.Written() {
+			log.Error("Export was interrupted", sl.Err(err))
+			c.Abort()
+			return
+		}
+		c.Header("Content-Disposition", "")
+		c.Header("Content-Type", "")
+		newServiceErrorResponse(c, log, "Failed to export users", err)
+		return
+	}
+
+	log.Info("Exported users successfully")
+}
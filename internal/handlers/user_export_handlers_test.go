@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/logger/handlers/slogdiscard"
+	service "github.com/Util787/user-manager-api/internal/services"
+	serviceMock "github.com/Util787/user-manager-api/internal/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_exportUsers(t *testing.T) {
+	tests := []struct {
+		testname            string
+		queryStr            string
+		mockBehavior        func(s *serviceMock.MockExportService)
+		expectedStatusCode  int
+		expectedContentType string
+		expectedResponse    string
+	}{
+		{
+			testname: "Ok json by default",
+			queryStr: "",
+			mockBehavior: func(s *serviceMock.MockExportService) {
+				s.On("ExportUsers", mock.Anything, mock.Anything, "json", entities.UserFilter{}).Run(func(args mock.Arguments) {
+					io.WriteString(args.Get(1).(io.Writer), `[{"id":1}]`)
+				}).Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedResponse:    `[{"id":1}]`,
+		},
+		{
+			testname: "Ok csv with filters",
+			queryStr: "?format=csv&surname=Iv&gender=male",
+			mockBehavior: func(s *serviceMock.MockExportService) {
+				s.On("ExportUsers", mock.Anything, mock.Anything, "csv", entities.UserFilter{Surname: "Iv", Gender: "male"}).Run(func(args mock.Arguments) {
+					io.WriteString(args.Get(1).(io.Writer), "id,name\n1,Ivan\n")
+				}).Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedResponse:    "id,name\n1,Ivan\n",
+		},
+		{
+			testname:            "Unsupported format",
+			queryStr:            "?format=xml",
+			mockBehavior:        func(s *serviceMock.MockExportService) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedContentType: "application/problem+json",
+			expectedResponse:    "Format must be csv, ndjson or json",
+		},
+		{
+			testname:            "Invalid gender",
+			queryStr:            "?format=ndjson&gender=unknown",
+			mockBehavior:        func(s *serviceMock.MockExportService) {},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedContentType: "application/problem+json",
+			expectedResponse:    `"errors":[{"field":"gender","code":"invalid_gender"`,
+		},
+		{
+			testname: "Service error before anything is written",
+			queryStr: "?format=ndjson",
+			mockBehavior: func(s *serviceMock.MockExportService) {
+				s.On("ExportUsers", mock.Anything, mock.Anything, "ndjson", entities.UserFilter{}).Return(errors.New("db error"))
+			},
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedContentType: "application/problem+json",
+			expectedResponse:    "Failed to export users",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockExportService := serviceMock.NewMockExportService(t)
+			router := setupExportTestRouter(mockExportService)
+
+			test.mockBehavior(mockExportService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/users/export"+test.queryStr, nil)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Equal(t, test.expectedContentType, resp.Header().Get("Content-Type"))
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
+
+func setupExportTestRouter(mockExportService *serviceMock.MockExportService) *gin.Engine {
+	logger := slogdiscard.NewDiscardLogger()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	h := NewHandlers(&service.Service{ExportService: mockExportService}, logger)
+
+	router.GET("/users/export", h.exportUsers)
+
+	return router
+}
@@ -8,17 +8,17 @@ import (
 	"math"
 	"net/http"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Util787/user-manager-api/entities"
 	"github.com/Util787/user-manager-api/internal/logger/sl"
+	service "github.com/Util787/user-manager-api/internal/services"
 	"github.com/gin-gonic/gin"
 )
 
-// for validating names, surnames, patronymics
-var nameRe = regexp.MustCompile(`^[A-ZА-ЯЁ][a-zа-яё]{1,49}$`)
-
 // getAllUsers godoc
 // @Summary      get all users with optionally filters and pagination
 // @Description  Get users using flexible query filters and pagination. You can provide partial values for `name`, `surname`, or `patronymic` — filtering will still work. Each of these parameters is optional and can be used independently or in combination.
@@ -31,6 +31,37 @@ var nameRe = regexp.MustCompile(`^[A-ZА-ЯЁ][a-zа-яё]{1,49}$`)
 // @Description
 // @Description  Example3: ?name=al&surname=sh
 // @Description  Response: Alexandr Shprot, Alina Sham, etc.
+// @Description
+// @Description  Name filters ignore the alphabet: ?name=ivan finds Ivan and Иван, ?name=Юрий finds Yuriy and Iurii.
+// @Description
+// @Description  `filter` is an expression for arbitrary boolean combinations, it is combined with other filters by and.
+// @Description  Fields: id, age, name, surname, patronymic, gender, nationality, created_at, updated_at.
+// @Description  Operators: == != > >= < <= (numbers and dates), ~ (text contains), in [...], not in [...], and, or, not, parentheses. Strings and dates are quoted.
+// @Description  Example6: ?filter=age>30 and (gender=="female" or nationality in ["BY","UA"])
+// @Description
+// @Description  `fields` limits returned user fields (id, created_at, updated_at, name, surname, patronymic, age, gender, nationality, attributes), only these columns are read from db.
+// @Description  Example7: ?fields=id,name,surname
+// @Description
+// @Description  `ids` returns the listed users in the same order in `data` and not found ids in `missing`, other filters and pagination are ignored. Same as POST /users/lookup.
+// @Description  Example8: ?ids=3,1,2
+// @Description
+// @Description  `tag` filters by comma separated tags, `tag_mode=any` (default) returns users with at least one of them, `tag_mode=all` users with every tag.
+// @Description  Example10: ?tag=vip,newsletter&tag_mode=all
+// @Description
+// @Description  `attr.<name>` filters by custom attributes, comma separated values mean any of them, several attributes are combined by and.
+// @Description  Example9: ?attr.department=sales,support&attr.remote=true
+// @Description
+// @Description  Example3.1: ?surname=ov&match=contains&nationality=BY,RU&age_gte=18&age_lte=30&created_gte=2025-01-01
+// @Description  Response: users from Belarus or Russia aged 18-30 created since 2025 whose surname contains "ov"
+// @Description
+// @Description  Response is an array of users, navigation links are sent in RFC 8288 `Link` header.
+// @Description  With `Accept: application/vnd.user-manager.envelope+json` header or `?envelope=true` response is an object (entities.UsersPage) with `data`, `page`, `page_size`, `total_count`, `total_pages` and navigation `links`.
+// @Description
+// @Description  Users are ordered by id by default, `sort` accepts comma separated fields (id, name, surname, age, created_at, updated_at, nationality), "-" prefix means descending order.
+// @Description  Example4: ?sort=surname,-created_at
+// @Description
+// @Description  Cursor pagination is used if `cursor` or `limit` is provided, it is faster on deep pages than page numbers. Response is an object with `data`, `next_cursor`, `prev_cursor` and `total_count` (only with `with_total=true`).
+// @Description  Example5: ?limit=10, then ?limit=10&cursor={next_cursor from previous response}, cursor works only with the same sort
 // @Tags         users
 // @Accept       json
 // @Produce      json
@@ -38,11 +69,32 @@ var nameRe = regexp.MustCompile(`^[A-ZА-ЯЁ][a-zа-яё]{1,49}$`)
 // @Param        surname     query     string  false "surname filter"
 // @Param        patronymic  query     string  false "patronymic filter"
 // @Param        gender      query     string  false  "gender filter can be only male or female"
+// @Param        match           query     string  false  "how name filters are matched: prefix (default), exact or contains"
+// @Param        nationality     query     string  false  "comma separated country codes, example: BY,RU"
+// @Param        age_gte         query     int     false  "min age, inclusive"
+// @Param        age_lte         query     int     false  "max age, inclusive"
+// @Param        created_gte     query     string  false  "created at or after, date (2006-01-02) or RFC 3339 time"
+// @Param        created_lte     query     string  false  "created at or before, date means the end of that day"
+// @Param        updated_gte     query     string  false  "updated at or after, date (2006-01-02) or RFC 3339 time"
+// @Param        updated_lte     query     string  false  "updated at or before, date means the end of that day"
+// @Param        has_patronymic  query     bool    false  "only users with (true) or without (false) patronymic"
+// @Param        tag             query     string  false  "comma separated tags, example: vip,newsletter"
+// @Param        tag_mode        query     string  false  "any (default) or all of tags"
+// @Param        attr.name       query     string  false  "custom attribute filter, name is the attribute name, example: attr.department=sales,support"
+// @Param        filter          query     string  false  "filter expression, example: age>30 and (gender==\"female\" or nationality in [\"BY\",\"UA\"])"
 // @Param        page_size       query     int     false  "min:5"
 // @Param        page      query     int     false  "min:1"
-// @Success      200  {array}  entities.User
-// @Failure      400  {object}  errorResponse
-// @Failure      500  {object}  errorResponse
+// @Param        sort        query     string  false "example: surname,-created_at"
+// @Param        cursor      query     string  false "next_cursor or prev_cursor from previous response"
+// @Param        limit       query     int     false "cursor page size, min:1 max:50"
+// @Param        with_total  query     bool    false "count total number of users in cursor mode"
+// @Param        envelope    query     bool    false "true returns an object with data and page metadata instead of a bare array"
+// @Param        fields      query     string  false "comma separated fields to return, example: id,name,surname"
+// @Param        ids         query     string  false "comma separated ids, max 100, returns entities.UsersLookup instead of a page"
+// @Success      200  {array}   entities.User  "entities.UsersPage with envelope=true"
+// @Header       200  {string}  Link  "RFC 8288 links: first, prev, next, last"
+// @Failure      400  {object}  errorResponse  "invalid_parameter, invalid_filter"
+// @Failure      500  {object}  errorResponse  "internal_error"
 // @Router       /users [get]
 func (h *Handler) getAllUsers(c *gin.Context) {
 	op, _ := c.Get("op")
@@ -50,12 +102,42 @@ func (h *Handler) getAllUsers(c *gin.Context) {
 		slog.Any("op", op),
 	)
 
-	name := c.DefaultQuery("name", "")
-	surname := c.DefaultQuery("surname", "")
-	patronymic := c.DefaultQuery("patronymic", "")
-	gender := c.DefaultQuery("gender", "")
+	sort, err := service.ParseSort(c.DefaultQuery("sort", ""))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Invalid sort, sortable fields: id, name, surname, age, created_at, updated_at, nationality", err)
+		return
+	}
+
+	fields, err := service.ParseFields(c.DefaultQuery("fields", ""))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Invalid fields, available fields: %s", err, availableUserFields)
+		return
+	}
+
+	if idsStr, ok := c.GetQuery("ids"); ok {
+		ids, err := parseIds(idsStr)
+		if err != nil {
+			newParamErrorResponse(c, log, codeInvalidParameter, detailInvalidParameter, err)
+			return
+		}
+		h.getUsersByIds(c, log, ids, fields)
+		return
+	}
+
+	_, hasCursor := c.GetQuery("cursor")
+	_, hasLimit := c.GetQuery("limit")
+	if hasCursor || hasLimit {
+		h.getUsersByCursor(c, log, sort, fields)
+		return
+	}
 
 	//validation
+	filter, err := parseUserFilter(c)
+	if err != nil {
+		newParamErrorResponse(c, log, codeInvalidFilter, detailInvalidFilter, err)
+		return
+	}
+
 	pageSizeStr := c.DefaultQuery("page_size", "5")
 	pageSize, err := strconv.Atoi(pageSizeStr)
 	if err != nil || pageSize < 5 {
@@ -74,12 +156,12 @@ func (h *Handler) getAllUsers(c *gin.Context) {
 		log.Debug("Invalid page value, set to 1", slog.String("page", pageStr))
 	}
 
-	log.Info("Getting all users with parameters", slog.Int("page_size", pageSize), slog.Int("page", page), slog.String("name", name), slog.String("surname", surname), slog.String("patronymic", patronymic), slog.String("gender", gender))
+	log.Info("Getting all users with parameters", slog.Int("page_size", pageSize), slog.Int("page", page), slog.Any("filter", filter), slog.String("sort", service.FormatSort(sort)), slog.Any("fields", fields))
 
 	//I think using cache here might be useless because of variations of keys due to many filters
-	allUsers, totalCount, err := h.services.UserService.GetAllUsers(pageSize, page, name, surname, patronymic, gender)
+	allUsers, totalCount, err := h.services.UserService.GetAllUsers(pageSize, page, filter, sort, fields)
 	if err != nil {
-		newErrorResponse(c, log, http.StatusInternalServerError, "Failed to get users", err)
+		newServiceErrorResponse(c, log, "Failed to get users", err)
 		return
 	}
 
@@ -88,28 +170,97 @@ func (h *Handler) getAllUsers(c *gin.Context) {
 		return
 	}
 
-	//check for invalid page num, totalPages also may be used by frontend but totalcount == 0 and status code 404 may be used here as well
-	totalPages := math.Ceil(float64(totalCount) / float64(pageSize))
-	if page > int(totalPages) {
-		newErrorResponse(c, log, http.StatusBadRequest, "Page exceeds total number of pages", errors.New("page exceeds max of pages"))
+	//check for invalid page num, totalcount == 0 and status code 404 may be used here as well
+	totalPages := int(math.Ceil(float64(totalCount) / float64(pageSize)))
+	if page > totalPages {
+		newErrorResponse(c, log, codeInvalidParameter, "Page exceeds total number of pages", errors.New("page exceeds max of pages"))
 		return
 	}
 
 	log.Info("Got users successfully", slog.Int("count", len(allUsers)))
 
-	c.JSON(http.StatusOK, allUsers)
+	withFields(allUsers, fields)
+
+	links := pageLinks(c, page, totalPages)
+	setLinkHeader(c, map[string]string{"self": links.Self, "first": links.First, "prev": links.Prev, "next": links.Next, "last": links.Last})
+
+	if !wantsEnvelope(c) {
+		c.JSON(http.StatusOK, allUsers)
+		return
+	}
+
+	c.JSON(http.StatusOK, entities.UsersPage{
+		Data:       allUsers,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+		Links:      links,
+	})
+}
+
+// keyset pagination for getAllUsers
+func (h *Handler) getUsersByCursor(c *gin.Context, log *slog.Logger, sort []entities.SortField, fields []string) {
+	filter, err := parseUserFilter(c)
+	if err != nil {
+		newParamErrorResponse(c, log, codeInvalidFilter, detailInvalidFilter, err)
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", "5")
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 {
+		limit = 5
+		log.Debug("Invalid limit value, set to 5", slog.String("user's limit", limitStr))
+	}
+	if limit > 50 {
+		limit = 50
+		log.Debug("limit is greater than 50, set to 50", slog.String("user's limit", limitStr))
+	}
+
+	cursor := c.DefaultQuery("cursor", "")
+	withTotal := c.DefaultQuery("with_total", "false") == "true"
+
+	log.Info("Getting users by cursor", slog.Int("limit", limit), slog.String("cursor", cursor), slog.Bool("with_total", withTotal), slog.Any("filter", filter), slog.String("sort", service.FormatSort(sort)), slog.Any("fields", fields))
+	page, err := h.services.UserService.GetUsersByCursor(filter, sort, cursor, limit, withTotal, fields)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			newErrorResponse(c, log, codeInvalidParameter, "Invalid cursor", err)
+			return
+		}
+		newServiceErrorResponse(c, log, "Failed to get users", err)
+		return
+	}
+
+	log.Info("Got users successfully", slog.Int("count", len(page.Data)))
+
+	withFields(page.Data, fields)
+
+	links := map[string]string{}
+	if page.NextCursor != "" {
+		links["next"] = urlWithQuery(c, "cursor", page.NextCursor)
+	}
+	if page.PrevCursor != "" {
+		links["prev"] = urlWithQuery(c, "cursor", page.PrevCursor)
+	}
+	setLinkHeader(c, links)
+
+	c.JSON(http.StatusOK, page)
 }
 
 // createUser godoc
 // @Summary      create user
 // @Description  creating new user with provided name, surname, patronymic(optional)
+// @Description  Name parts are normalized before validation: spaces are trimmed and collapsed, names typed in one case become title case (" ivan " is saved as "Ivan")
 // @Tags         users
 // @Accept       json
 // @Produce      json
 // @Param        fullname  body  entities.FullName  true  "Users fullname: name, surname, patronymic(optional)"
 // @Success      201  {object}  map[string]string "message with created user's id"
-// @Failure      400  {object}  errorResponse
-// @Failure      500  {object}  errorResponse
+// @Failure      400  {object}  errorResponse  "invalid_body, validation_failed: invalid fields are listed in errors"
+// @Failure      409  {object}  errorResponse  "conflict: user with the same full name exists"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Failure      502  {object}  errorResponse  "enrichment_failed"
 // @Router       /users [post]
 func (h *Handler) createUser(c *gin.Context) {
 	op, _ := c.Get("op")
@@ -120,44 +271,13 @@ func (h *Handler) createUser(c *gin.Context) {
 	var fullName entities.FullName
 	err := c.ShouldBindJSON(&fullName)
 	if err != nil {
-		newErrorResponse(c, log, http.StatusBadRequest, "Failed to parse json", err)
-		return
-	}
-	//validation
-	if !isValidFullnameField(fullName.Name) || !isValidFullnameField(fullName.Surname) || (fullName.Patronymic != "" && !isValidFullnameField(fullName.Patronymic)) {
-		newErrorResponse(c, log, http.StatusBadRequest, "Name, Surname or Patronymic is invalid", errors.New("name,surname or patronimyc is invalid"))
-		return
-	}
-
-	exists, err := h.services.UserService.ExistByFullName(fullName)
-	if err != nil {
-		newErrorResponse(c, log, http.StatusInternalServerError, "Failed to check if the user exists", err)
-		return
-	}
-	if exists {
-		newErrorResponse(c, log, http.StatusBadRequest, "User already exists", errors.New("user already exists"))
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
 		return
 	}
-
-	age, gender, nationality, err := h.services.InfoRequestService.RequestAdditionalInfo(fullName.Name)
+	log.Info("Creating user", slog.Any("fullname", fullName))
+	createdUser, err := h.services.UserService.CreateUser(fullName)
 	if err != nil {
-		newErrorResponse(c, log, http.StatusInternalServerError, "Requests timed out or service is unreachable", err)
-		return
-	}
-
-	params := entities.User{
-		Name:        fullName.Name,
-		Surname:     fullName.Surname,
-		Patronymic:  fullName.Patronymic,
-		Age:         age,
-		Gender:      gender,
-		Nationality: nationality,
-	}
-
-	log.Info("Creating user with parameters", slog.Any("user", params))
-	createdUser, err := h.services.UserService.CreateUser(params)
-	if err != nil {
-		newErrorResponse(c, log, http.StatusInternalServerError, "Failed to create user", err)
+		newServiceErrorResponse(c, log, "Failed to create user", err)
 		return
 	}
 
@@ -169,12 +289,15 @@ func (h *Handler) createUser(c *gin.Context) {
 // getUserById godoc
 // @Summary      get user by id
 // @Description  recieve user info by providing id in path
+// @Description  Only requested fields are returned if `fields` is provided: id, created_at, updated_at, name, surname, patronymic, age, gender, nationality
 // @Tags         users
 // @Produce      json
-// @Param        user_id  path      int  true "user_id"
+// @Param        user_id  path      int     true  "user_id"
+// @Param        fields   query     string  false "comma separated fields to return, example: id,name,surname"
 // @Success      200      {object}  entities.User
-// @Failure      400      {object}  errorResponse
-// @Failure      500      {object}  errorResponse
+// @Failure      400      {object}  errorResponse  "invalid_parameter"
+// @Failure      404      {object}  errorResponse  "not_found"
+// @Failure      500      {object}  errorResponse  "internal_error"
 // @Router       /users/{user_id} [get]
 func (h *Handler) getUserById(c *gin.Context) {
 	op, _ := c.Get("op")
@@ -185,49 +308,68 @@ func (h *Handler) getUserById(c *gin.Context) {
 	userIdStr := c.Param("user_id")
 	userId32, err := parseInt32(userIdStr)
 	if err != nil {
-		newErrorResponse(c, log, http.StatusBadRequest, "Id should be number", err)
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
 		return
 	}
 
-	//cache check
+	fields, err := service.ParseFields(c.DefaultQuery("fields", ""))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Invalid fields, available fields: %s", err, availableUserFields)
+		return
+	}
+
+	//cache check, full user is cached so it can be used for any fields
 	var user entities.User
 	cacheKey := "user:" + userIdStr
 	err = h.services.RedisService.Get(context.Background(), cacheKey, &user)
 	if err == nil {
 		log.Info("User found in cache", slog.Int("user_id", int(userId32)))
-		c.JSON(http.StatusOK, user)
+		c.JSON(http.StatusOK, user.WithFields(fields))
 		return
 	}
 
-	log.Info("Getting user by ID from postgres db", slog.Int("user_id", int(userId32)))
-	user, err = h.services.UserService.GetUserById(userId32)
+	log.Info("Getting user by ID from postgres db", slog.Int("user_id", int(userId32)), slog.Any("fields", fields))
+	user, err = h.services.UserService.GetUserById(userId32, fields)
 	if err != nil {
-		newErrorResponse(c, log, http.StatusNotFound, "User not found", err)
+		newServiceErrorResponse(c, log, "Failed to get user", err)
 		return
 	}
 
-	//cache set
-	err = h.services.RedisService.Set(context.Background(), cacheKey, user)
-	if err != nil {
-		log.Warn("Failed to set user in cache", slog.Int("user_id", int(userId32)), sl.Err(err))
+	//cache set, partial users are not cached
+	if len(fields) == 0 {
+		err = h.services.RedisService.Set(context.Background(), cacheKey, user)
+		if err != nil {
+			log.Warn("Failed to set user in cache", slog.Int("user_id", int(userId32)), sl.Err(err))
+		}
 	}
 
 	log.Info("Got user successfully", slog.Any("user", user))
 
-	c.JSON(http.StatusOK, user)
+	c.JSON(http.StatusOK, user.WithFields(fields))
 }
 
 // updateUser godoc
 // @Summary      update user info by id
-// @Description  updating user info by id provided in path. In request body you can optionally provide: name, surname, patronymic, age, gender, nationality. Update_at will change automatically
+// @Description  updating user info by id provided in path, format of body is chosen by Content-Type:
+// @Description  application/json: you can optionally provide name, surname, patronymic, age, gender, nationality, only provided fields are changed.
+// @Description  application/merge-patch+json (RFC 7386): fields are merged into the user, null removes a field, so {"patronymic":null} clears patronymic.
+// @Description  application/json-patch+json (RFC 6902): operations (add, remove, replace, move, copy, test) are applied to the user as it is returned by GET,
+// @Description  e.g. [{"op":"test","path":"/updated_at","value":"..."},{"op":"replace","path":"/age","value":30}]. If any operation fails nothing is changed.
+// @Description  Merge and json patches must leave a valid full user (like PUT), id, created_at and updated_at are read only. They return the patched user.
+// @Description  Update_at will change automatically. Name parts are normalized the same way as on create
 // @Tags         users
 // @Accept       json
+// @Accept       application/merge-patch+json
+// @Accept       application/json-patch+json
 // @Produce      json
 // @Param        user_id  path      int                     true "user_id"
-// @Param        user     body      entities.UpdateUserParams  true "parameters for update"
-// @Success      200      {object}  map[string]string       "message about user update"
-// @Failure      400      {object}  errorResponse
-// @Failure      500      {object}  errorResponse
+// @Param        user     body      entities.UpdateUserParams  true "parameters for update, merge patch or json patch"
+// @Success      200      {object}  entities.User           "patched user for merge and json patches, message about user update for application/json"
+// @Failure      400      {object}  errorResponse  "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors"
+// @Failure      404      {object}  errorResponse  "not_found"
+// @Failure      409      {object}  errorResponse  "conflict: user with the same full name exists, patch_failed: test operation failed or path doesnt exist"
+// @Failure      415      {object}  errorResponse  "unsupported_media_type"
+// @Failure      500      {object}  errorResponse  "internal_error"
 // @Router       /users/{user_id} [patch]
 func (h *Handler) updateUser(c *gin.Context) {
 	op, _ := c.Get("op")
@@ -239,69 +381,135 @@ func (h *Handler) updateUser(c *gin.Context) {
 
 	userId32, err := parseInt32(userIdStr)
 	if err != nil {
-		newErrorResponse(c, log, http.StatusBadRequest, "Id should be number", err)
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
 		return
 	}
 
-	log.Info("Checking if user exists by id", slog.Int("user_id", int(userId32)))
-	exists, err := h.services.UserService.ExistById(userId32)
-	if err != nil {
-		newErrorResponse(c, log, http.StatusBadRequest, "Failed to check if the user exists", err)
+	var format string
+	switch c.ContentType() {
+	case "", "application/json":
+	case "application/merge-patch+json":
+		format = service.PatchFormatMerge
+	case "application/json-patch+json":
+		format = service.PatchFormatJSON
+	default:
+		newErrorResponse(c, log, codeUnsupportedMedia, "Content type must be application/json, application/merge-patch+json or application/json-patch+json", fmt.Errorf("unsupported content type %q", c.ContentType()))
 		return
 	}
-	if !exists {
-		newErrorResponse(c, log, http.StatusBadRequest, "Cannot update user that does not exist", errors.New("user does not exist"))
+	if format != "" {
+		h.patchUser(c, log, userId32, format)
 		return
 	}
-	log.Info("User exists in db", slog.Int("user_id", int(userId32)))
 
 	var user entities.UpdateUserParams
 	err = c.ShouldBindJSON(&user)
 	if err != nil {
-		newErrorResponse(c, log, http.StatusBadRequest, "Failed to parse json in updateUser handler", err)
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json in updateUser handler", err)
 		return
 	}
 
-	//validation
-	if user.Name != nil && !isValidFullnameField(*user.Name) {
-		newErrorResponse(c, log, http.StatusBadRequest, "Invalid name", errors.New("invalid name"))
+	log.Info("Updating user with parameters", slog.Int("user_id", int(userId32)), slog.Any("update_params", user))
+	err = h.services.UserService.UpdateUser(userId32, user)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to update user", err)
+		return
+	}
+	h.deleteCachedUser(log, userId32)
+
+	log.Info("Updated user successfully", slog.Int("user_id", int(userId32)))
+
+	c.JSON(http.StatusOK, gin.H{"message": "User updated successfully"})
+}
+
+// patchUser applies merge or json patch from request body, patched user is returned
+func (h *Handler) patchUser(c *gin.Context, log *slog.Logger, userId int32, format string) {
+	patch, err := c.GetRawData()
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to read patch", err)
 		return
 	}
-	if user.Surname != nil && !isValidFullnameField(*user.Surname) {
-		newErrorResponse(c, log, http.StatusBadRequest, "Invalid surname", errors.New("invalid surname"))
+
+	log.Info("Patching user", slog.Int("user_id", int(userId)), slog.String("format", format), slog.String("patch", string(patch)))
+	user, err := h.services.UserService.PatchUser(userId, format, patch)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to patch user", err)
 		return
 	}
-	if user.Patronymic != nil && *user.Patronymic != "" && !isValidFullnameField(*user.Patronymic) {
-		newErrorResponse(c, log, http.StatusBadRequest, "Invalid patronymic", errors.New("invalid patronymic"))
+	h.deleteCachedUser(log, userId)
+
+	log.Info("Patched user successfully", slog.Int("user_id", int(userId)))
+
+	c.JSON(http.StatusOK, user)
+}
+
+// replaceUser godoc
+// @Summary      replace user by id
+// @Description  replacing all editable fields of user by id provided in path. name, surname, age and gender are required,
+// @Description  missing patronymic and nationality are cleared. Update_at will change automatically. Name parts are normalized the same way as on create
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        user_id  path      int                         true "user_id"
+// @Param        user     body      entities.ReplaceUserParams  true "all editable fields of user"
+// @Success      200      {object}  map[string]string           "message about user replacement"
+// @Failure      400      {object}  errorResponse  "invalid_parameter, invalid_body, validation_failed: invalid fields are listed in errors"
+// @Failure      404      {object}  errorResponse  "not_found"
+// @Failure      409      {object}  errorResponse  "conflict: user with the same full name exists"
+// @Failure      500      {object}  errorResponse  "internal_error"
+// @Router       /users/{user_id} [put]
+func (h *Handler) replaceUser(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	userId32, err := parseInt32(c.Param("user_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
 		return
 	}
-	if user.Gender != nil && *user.Gender != "female" && *user.Gender != "male" {
-		newErrorResponse(c, log, http.StatusBadRequest, "Gender must be 'male' or 'female'", errors.New("invalid gender"))
+
+	var user entities.ReplaceUserParams
+	err = c.ShouldBindJSON(&user)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
 		return
 	}
 
-	log.Info("Updating user with parameters", slog.Any("update_params", user))
-	err = h.services.UserService.UpdateUser(userId32, user)
+	log.Info("Replacing user", slog.Int("user_id", int(userId32)), slog.Any("user", user))
+	err = h.services.UserService.ReplaceUser(userId32, user)
 	if err != nil {
-		newErrorResponse(c, log, http.StatusInternalServerError, "Failed to update user", err)
+		newServiceErrorResponse(c, log, "Failed to replace user", err)
 		return
 	}
+	h.deleteCachedUser(log, userId32)
 
-	log.Info("Updated user successfully", slog.Int("user_id", int(userId32)))
+	log.Info("Replaced user successfully", slog.Int("user_id", int(userId32)))
 
-	c.JSON(http.StatusOK, gin.H{"message": "User updated successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "User replaced successfully"})
+}
+
+// deleteCachedUser removes changed user from cache, failure is only logged because cached copy is removed by the next change too
+func (h *Handler) deleteCachedUser(log *slog.Logger, userId int32) {
+	err := h.services.RedisService.Delete(context.Background(), "user:"+strconv.Itoa(int(userId)))
+	if err != nil {
+		log.Warn("Failed to delete user from cache", slog.Int("user_id", int(userId)), sl.Err(err))
+	}
 }
 
 // deleteUser godoc
 // @Summary      delete user by id
-// @Description  deleting user by id if exists
+// @Description  soft deletes user by id if exists (deleted_at is set), the same as bulk delete without hard.
+// @Description  Before soft delete was added the row was removed, now it is kept and hidden from all endpoints, a repeated delete returns 404.
+// @Description  Use bulk delete with `ids` and `hard=true` to remove the row completely
 // @Tags         users
 // @Accept       json
 // @Produce      json
 // @Param        user_id  path      int  true "user_id"
 // @Success      200      {object}  map[string]string  "successful deleting message"
-// @Failure      400      {object}  errorResponse
-// @Failure      500      {object}  errorResponse
+// @Failure      400      {object}  errorResponse  "invalid_parameter"
+// @Failure      404      {object}  errorResponse  "not_found"
+// @Failure      500      {object}  errorResponse  "internal_error"
 // @Router       /users/{user_id} [delete]
 func (h *Handler) deleteUser(c *gin.Context) {
 	op, _ := c.Get("op")
@@ -313,34 +521,185 @@ func (h *Handler) deleteUser(c *gin.Context) {
 
 	userId32, err := parseInt32(userIdStr)
 	if err != nil {
-		newErrorResponse(c, log, http.StatusBadRequest, "Id should be number", err)
-		return
-	}
-
-	log.Info("Checking if user exists by id", slog.Int("user_id", int(userId32)))
-	exist, err := h.services.UserService.ExistById(userId32)
-	if err != nil {
-		newErrorResponse(c, log, http.StatusInternalServerError, "Failed to check if the user exists", err)
-		return
-	}
-	if !exist {
-		newErrorResponse(c, log, http.StatusBadRequest, "Cannot delete user that does not exist", errors.New("user does not exist"))
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
 		return
 	}
-	log.Info("User exists in db", slog.Int("user_id", int(userId32)))
 
 	log.Info("Deleting user", slog.Int("user_id", int(userId32)))
 	err = h.services.UserService.DeleteUser(userId32)
 	if err != nil {
-		newErrorResponse(c, log, http.StatusBadRequest, "Cannot find user", err)
+		newServiceErrorResponse(c, log, "Failed to delete user", err)
 		return
 	}
+	h.deleteCachedUser(log, userId32)
 
 	log.Info("Deleted user successfully", slog.Int("user_id", int(userId32)))
 
 	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("User with id:%s deleted successfully", userIdStr)})
 }
 
+// same filters as in getAllUsers
+func parseUserFilter(c *gin.Context) (entities.UserFilter, error) {
+	filter := entities.UserFilter{
+		Name:       c.DefaultQuery("name", ""),
+		Surname:    c.DefaultQuery("surname", ""),
+		Patronymic: c.DefaultQuery("patronymic", ""),
+		Match:      c.DefaultQuery("match", ""),
+		Gender:     c.DefaultQuery("gender", ""),
+	}
+
+	if filter.Gender != "" && !service.IsValidGender(filter.Gender) {
+		return entities.UserFilter{}, service.NewParamError("gender", service.FieldErrInvalidGender)
+	}
+	switch filter.Match {
+	case "", entities.MatchPrefix, entities.MatchExact, entities.MatchContains:
+	default:
+		return entities.UserFilter{}, service.NewParamError("match", service.FieldErrInvalidMatch)
+	}
+
+	if nationalitiesStr := c.DefaultQuery("nationality", ""); nationalitiesStr != "" {
+		for _, nationality := range strings.Split(nationalitiesStr, ",") {
+			nationality = strings.ToUpper(strings.TrimSpace(nationality))
+			if !nationalityRe.MatchString(nationality) {
+				return entities.UserFilter{}, service.NewParamError("nationality", service.FieldErrInvalidNationality)
+			}
+			filter.Nationalities = append(filter.Nationalities, nationality)
+		}
+	}
+
+	var err error
+	if filter.AgeGte, err = parseAgeQuery(c, "age_gte"); err != nil {
+		return entities.UserFilter{}, err
+	}
+	if filter.AgeLte, err = parseAgeQuery(c, "age_lte"); err != nil {
+		return entities.UserFilter{}, err
+	}
+	if filter.AgeGte != nil && filter.AgeLte != nil && *filter.AgeGte > *filter.AgeLte {
+		return entities.UserFilter{}, service.NewParamError("age_gte", service.FieldErrInvalidAgeRange)
+	}
+
+	if filter.CreatedGte, err = parseTimeQuery(c, "created_gte", false); err != nil {
+		return entities.UserFilter{}, err
+	}
+	if filter.CreatedLte, err = parseTimeQuery(c, "created_lte", true); err != nil {
+		return entities.UserFilter{}, err
+	}
+	if filter.UpdatedGte, err = parseTimeQuery(c, "updated_gte", false); err != nil {
+		return entities.UserFilter{}, err
+	}
+	if filter.UpdatedLte, err = parseTimeQuery(c, "updated_lte", true); err != nil {
+		return entities.UserFilter{}, err
+	}
+
+	if hasPatronymicStr := c.DefaultQuery("has_patronymic", ""); hasPatronymicStr != "" {
+		hasPatronymic, err := strconv.ParseBool(hasPatronymicStr)
+		if err != nil {
+			return entities.UserFilter{}, service.NewParamError("has_patronymic", service.FieldErrNotBoolean)
+		}
+		filter.HasPatronymic = &hasPatronymic
+	}
+
+	if filter.Expr, err = service.ParseFilterExpr(c.DefaultQuery("filter", "")); err != nil {
+		return entities.UserFilter{}, err
+	}
+
+	tagMode := c.DefaultQuery("tag_mode", entities.TagModeAny)
+	if tagMode != entities.TagModeAny && tagMode != entities.TagModeAll {
+		return entities.UserFilter{}, service.NewParamError("tag_mode", service.FieldErrInvalidTagMode)
+	}
+	if tagsStr := c.DefaultQuery("tag", ""); tagsStr != "" {
+		if filter.Tags, err = service.ParseTags(tagsStr); err != nil {
+			return entities.UserFilter{}, err
+		}
+		filter.TagMode = tagMode
+	}
+
+	if filter.Attributes, err = parseAttributeFilters(c); err != nil {
+		return entities.UserFilter{}, err
+	}
+
+	return filter, nil
+}
+
+var nationalityRe = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// parseAttributeFilters reads ?attr.<name>=a,b params of users listing, comma means any of values.
+// Values are typed by the service with attribute definitions
+func parseAttributeFilters(c *gin.Context) ([]entities.AttributeFilter, error) {
+	query := c.Request.URL.Query()
+	names := []string{}
+	for key := range query {
+		if strings.HasPrefix(key, "attr.") {
+			names = append(names, strings.TrimPrefix(key, "attr."))
+		}
+	}
+	// the same order for the same query
+	slices.Sort(names)
+
+	var filters []entities.AttributeFilter
+	for _, name := range names {
+		if name == "" {
+			return nil, service.NewParamError("attr.", service.FieldErrNoAttributeName)
+		}
+		filter := entities.AttributeFilter{Name: name}
+		for _, valuesStr := range query["attr."+name] {
+			for _, value := range strings.Split(valuesStr, ",") {
+				filter.Values = append(filter.Values, strings.TrimSpace(value))
+			}
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+func parseAgeQuery(c *gin.Context, key string) (*int, error) {
+	ageStr := c.DefaultQuery(key, "")
+	if ageStr == "" {
+		return nil, nil
+	}
+
+	age, err := strconv.Atoi(ageStr)
+	if err != nil || age < 0 {
+		return nil, service.NewParamError(key, service.FieldErrNotNonNegativeInt)
+	}
+	return &age, nil
+}
+
+// accepts RFC 3339 time or a date, for upper bound a date means the end of that day
+func parseTimeQuery(c *gin.Context, key string, upperBound bool) (*time.Time, error) {
+	timeStr := c.DefaultQuery(key, "")
+	if timeStr == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, timeStr)
+	if err == nil {
+		return &t, nil
+	}
+
+	t, err = time.Parse(time.DateOnly, timeStr)
+	if err != nil {
+		return nil, service.NewParamError(key, service.FieldErrInvalidTime)
+	}
+	if upperBound {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return &t, nil
+}
+
+// for error messages
+const availableUserFields = "id, created_at, updated_at, name, surname, patronymic, age, gender, nationality, attributes"
+
+// withFields makes users serialize only requested fields, nothing changes if fields are empty
+func withFields(users []entities.User, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	for i := range users {
+		users[i] = users[i].WithFields(fields)
+	}
+}
+
 func parseInt32(numStr string) (int32, error) {
 	parsedNum, err := strconv.ParseInt(numStr, 10, 32)
 	if err != nil {
@@ -348,8 +707,3 @@ func parseInt32(numStr string) (int32, error) {
 	}
 	return int32(parsedNum), nil
 }
-
-func isValidFullnameField(s string) bool {
-	s = strings.TrimSpace(s)
-	return nameRe.MatchString(s)
-}
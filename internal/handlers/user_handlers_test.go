@@ -2,13 +2,18 @@ package handlers
 
 import (
 	"bytes"
+	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/Util787/user-manager-api/entities"
 	"github.com/Util787/user-manager-api/internal/logger/handlers/slogdiscard"
+	"github.com/Util787/user-manager-api/internal/repository"
 	service "github.com/Util787/user-manager-api/internal/services"
 	serviceMock "github.com/Util787/user-manager-api/internal/services/mocks"
 	"github.com/gin-gonic/gin"
@@ -19,101 +24,109 @@ import (
 func TestHandler_createUser(t *testing.T) {
 
 	tests := []struct {
-		testname                string
-		inputBody               string
-		mockExistBehavior       func(s *serviceMock.MockUserService)
-		mockInfoRequestBehavior func(s *serviceMock.MockInfoRequestService)
-		mockCreateBehavior      func(s *serviceMock.MockUserService)
-		expectedStatusCode      int
-		expectedResponseBody    string
+		testname             string
+		inputBody            string
+		acceptLanguage       string
+		mockCreateBehavior   func(s *serviceMock.MockUserService)
+		expectedStatusCode   int
+		expectedResponseBody string
 	}{
 		{
 			testname:  "Ok",
 			inputBody: `{"name":"Testname","surname":"Testsurname","patronymic":"Testpatronymic"}`,
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistByFullName", entities.FullName{Name: "Testname", Surname: "Testsurname", Patronymic: "Testpatronymic"}).Return(false, nil)
-			},
-			mockInfoRequestBehavior: func(s *serviceMock.MockInfoRequestService) {
-				s.On("RequestAdditionalInfo", "Testname").Return(41, "female", "BY", nil)
-			},
 			mockCreateBehavior: func(s *serviceMock.MockUserService) {
-				s.On("CreateUser", entities.User{Name: "Testname", Surname: "Testsurname", Patronymic: "Testpatronymic", Age: 41, Gender: "female", Nationality: "BY"}).Return(entities.User{Id: 3, Name: "Testname", Surname: "Testsurname", Patronymic: "Testpatronymic", Age: 41, Gender: "female", Nationality: "BY"}, nil)
+				s.On("CreateUser", entities.FullName{Name: "Testname", Surname: "Testsurname", Patronymic: "Testpatronymic"}).Return(entities.User{Id: 3, Name: "Testname", Surname: "Testsurname", Patronymic: "Testpatronymic", Age: 41, Gender: "female", Nationality: "BY"}, nil)
 			},
 			expectedStatusCode:   201,
 			expectedResponseBody: `{"message":"User created successfully with id: 3"}`,
 		},
 		{
-			testname:                "Empty JSON",
-			inputBody:               `{}`,
-			mockExistBehavior:       func(s *serviceMock.MockUserService) {},
-			mockInfoRequestBehavior: func(s *serviceMock.MockInfoRequestService) {},
-			mockCreateBehavior:      func(s *serviceMock.MockUserService) {},
-			expectedStatusCode:      400,
-			expectedResponseBody:    `{"message":"Failed to parse json"}`,
+			testname:             "Empty JSON",
+			inputBody:            `{}`,
+			mockCreateBehavior:   func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"code":"invalid_body","title":"Request body is malformed","status":400,"detail":"Failed to parse json"}`,
 		},
 		{
-			testname:  "Create service error",
-			inputBody: `{"name":"Testname","surname":"Testsurname","patronymic":"Testpatronymic"}`,
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistByFullName", entities.FullName{Name: "Testname", Surname: "Testsurname", Patronymic: "Testpatronymic"}).Return(false, nil)
-			},
-			mockInfoRequestBehavior: func(s *serviceMock.MockInfoRequestService) {
-				s.On("RequestAdditionalInfo", "Testname").Return(41, "female", "BY", nil)
+			testname:  "Invalid full name",
+			inputBody: `{"name":"Test1","surname":"testsurname"}`,
+			mockCreateBehavior: func(s *serviceMock.MockUserService) {
+				s.On("CreateUser", entities.FullName{Name: "Test1", Surname: "testsurname"}).Return(entities.User{}, &service.ValidationError{
+					Fields: []service.FieldError{
+						{Field: "name", Code: service.FieldErrNameNotCapitalized, Message: "must start with a capital letter"},
+						{Field: "surname", Code: service.FieldErrNameNotCapitalized, Message: "must start with a capital letter"},
+					},
+				})
 			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `"code":"validation_failed","title":"Validation failed","status":400,"detail":"Some fields are invalid, they are listed in errors","errors":[{"field":"name","code":"name_not_capitalized","message":"must start with a capital letter"},{"field":"surname"`,
+		},
+		{
+			testname:       "Invalid full name in russian",
+			inputBody:      `{"name":"Test1","surname":"Testsurname"}`,
+			acceptLanguage: "ru-RU,ru;q=0.9,en;q=0.8",
 			mockCreateBehavior: func(s *serviceMock.MockUserService) {
-				s.On("CreateUser", entities.User{Name: "Testname", Surname: "Testsurname", Patronymic: "Testpatronymic", Age: 41, Gender: "female", Nationality: "BY"}).Return(entities.User{}, errors.New("Something went wrong"))
+				s.On("CreateUser", mock.Anything).Return(entities.User{}, &service.ValidationError{
+					Fields: []service.FieldError{{Field: "name", Code: service.FieldErrNameNotCapitalized, Message: "must start with a capital letter"}},
+				})
 			},
-			expectedStatusCode:   500,
-			expectedResponseBody: `{"message":"Failed to create user"}`,
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"code":"validation_failed","title":"Ошибка валидации","status":400,"detail":"Некоторые поля заполнены неверно, они перечислены в errors","errors":[{"field":"name","code":"name_not_capitalized","message":"должно начинаться с заглавной буквы"}]}`,
 		},
 		{
-			testname:  "Exist service error",
+			testname:             "Empty JSON in russian",
+			inputBody:            `{}`,
+			acceptLanguage:       "ru",
+			mockCreateBehavior:   func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"code":"invalid_body","title":"Некорректное тело запроса","status":400,"detail":"Не удалось разобрать json"}`,
+		},
+		{
+			testname:             "Unsupported language falls back to english",
+			inputBody:            `{}`,
+			acceptLanguage:       "de-DE,fr;q=0.5",
+			mockCreateBehavior:   func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"code":"invalid_body","title":"Request body is malformed","status":400,"detail":"Failed to parse json"}`,
+		},
+		{
+			testname:  "Create service error",
 			inputBody: `{"name":"Testname","surname":"Testsurname","patronymic":"Testpatronymic"}`,
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistByFullName", entities.FullName{Name: "Testname", Surname: "Testsurname", Patronymic: "Testpatronymic"}).Return(false, errors.New("Something went wrong"))
+			mockCreateBehavior: func(s *serviceMock.MockUserService) {
+				s.On("CreateUser", mock.Anything).Return(entities.User{}, errors.New("Something went wrong"))
 			},
-			mockInfoRequestBehavior: func(s *serviceMock.MockInfoRequestService) {},
-			mockCreateBehavior:      func(s *serviceMock.MockUserService) {},
-			expectedStatusCode:      500,
-			expectedResponseBody:    `{"message":"Failed to check if the user exists"}`,
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"code":"internal_error","title":"Internal server error","status":500,"detail":"Failed to create user"}`,
 		},
 		{
 			testname:  "User already exists",
 			inputBody: `{"name":"Testname","surname":"Testsurname","patronymic":"Testpatronymic"}`,
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistByFullName", entities.FullName{Name: "Testname", Surname: "Testsurname", Patronymic: "Testpatronymic"}).Return(true, nil)
+			mockCreateBehavior: func(s *serviceMock.MockUserService) {
+				s.On("CreateUser", mock.Anything).Return(entities.User{}, fmt.Errorf("%w: %w", service.ErrConflict, repository.ErrUserExists))
 			},
-			mockInfoRequestBehavior: func(s *serviceMock.MockInfoRequestService) {},
-			mockCreateBehavior:      func(s *serviceMock.MockUserService) {},
-			expectedStatusCode:      400,
-			expectedResponseBody:    `{"message":"User already exists"}`,
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"code":"conflict","title":"Conflict with existing data","status":409,"detail":"Failed to create user"}`,
 		},
 		{
-			testname:  "Info request error",
+			testname:  "Enrichment error",
 			inputBody: `{"name":"Testname","surname":"Testsurname","patronymic":"Testpatronymic"}`,
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistByFullName", entities.FullName{Name: "Testname", Surname: "Testsurname", Patronymic: "Testpatronymic"}).Return(false, nil)
-			},
-			mockInfoRequestBehavior: func(s *serviceMock.MockInfoRequestService) {
-				s.On("RequestAdditionalInfo", "Testname").Return(0, "", "", errors.New("api call unreachable"))
+			mockCreateBehavior: func(s *serviceMock.MockUserService) {
+				s.On("CreateUser", mock.Anything).Return(entities.User{}, fmt.Errorf("%w: api call unreachable", service.ErrEnrichmentFailed))
 			},
-			mockCreateBehavior:   func(s *serviceMock.MockUserService) {},
-			expectedStatusCode:   500,
-			expectedResponseBody: `{"message":"Requests timed out or service is unreachable"}`,
+			expectedStatusCode:   502,
+			expectedResponseBody: `{"code":"enrichment_failed","title":"Enrichment apis are unreachable","status":502,"detail":"Failed to create user"}`,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.testname, func(t *testing.T) {
 			mockUserService := serviceMock.NewMockUserService(t)
-			mockInfoRequestService := serviceMock.NewMockInfoRequestService(t)
-			router := setupTestRouter(mockUserService, mockInfoRequestService, nil)
+			router := setupTestRouter(mockUserService, nil, nil)
 
 			resp := httptest.NewRecorder()
 			req := httptest.NewRequest("POST", "/users", bytes.NewBufferString(test.inputBody))
 			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept-Language", test.acceptLanguage)
 
-			test.mockExistBehavior(mockUserService)
-			test.mockInfoRequestBehavior(mockInfoRequestService)
 			test.mockCreateBehavior(mockUserService)
 
 			router.ServeHTTP(resp, req)
@@ -136,19 +149,31 @@ func setupTestRouter(mockUserService *serviceMock.MockUserService, mockInfoReque
 
 	router.GET("/users", h.getAllUsers)
 	router.POST("/users", h.createUser)
+	router.PATCH("/users", h.bulkUpdateUsers)
+	router.DELETE("/users", h.bulkDeleteUsers)
+	router.GET("/users/search", h.searchUsers)
+	router.POST("/users/lookup", h.lookupUsers)
+	router.GET("/users/duplicates", h.findDuplicates)
+	router.POST("/users/merge", h.mergeUsers)
+	router.POST("/users/tags", h.bulkTagUsers)
 	router.GET("/users/:user_id", h.getUserById)
+	router.PUT("/users/:user_id", h.replaceUser)
 	router.PATCH("/users/:user_id", h.updateUser)
 	router.DELETE("/users/:user_id", h.deleteUser)
+	router.GET("/users/:user_id/tags", h.getUserTags)
+	router.POST("/users/:user_id/tags", h.addUserTags)
+	router.DELETE("/users/:user_id/tags/:tag", h.removeUserTag)
 
 	return router
 }
 
-// Signature: GetAllUsers(pageSize, page int, name, surname, patronymic, gender string) (users []entities.User, totalCount int,err error)
+// Signature: GetAllUsers(pageSize, page int, filter entities.UserFilter, sort []entities.SortField, fields []string) (users []entities.User, totalCount int,err error)
 func TestHandler_getAllUsers(t *testing.T) {
 
 	tests := []struct {
 		testname                string
 		queryStr                string
+		acceptLanguage          string
 		mockGetAllUsersBehavior func(s *serviceMock.MockUserService)
 		expectedStatusCode      int
 		expectedResponseBody    string
@@ -157,7 +182,7 @@ func TestHandler_getAllUsers(t *testing.T) {
 			testname: "Ok",
 			queryStr: "",
 			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
-				s.On("GetAllUsers", 5, 1, "", "", "", "").Return([]entities.User{{Name: "Aleksey", Surname: "Ivanov"}}, 1, nil)
+				s.On("GetAllUsers", 5, 1, entities.UserFilter{}, []entities.SortField(nil), []string(nil)).Return([]entities.User{{Name: "Aleksey", Surname: "Ivanov"}}, 1, nil)
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `"name":"Aleksey","surname":"Ivanov"`,
@@ -166,7 +191,7 @@ func TestHandler_getAllUsers(t *testing.T) {
 			testname: "Invalid page_size below minimum",
 			queryStr: "?page_size=2",
 			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
-				s.On("GetAllUsers", 5, 1, "", "", "", "").Return([]entities.User{{Name: "Aleksey", Surname: "Ivanov"}}, 1, nil)
+				s.On("GetAllUsers", 5, 1, entities.UserFilter{}, []entities.SortField(nil), []string(nil)).Return([]entities.User{{Name: "Aleksey", Surname: "Ivanov"}}, 1, nil)
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `"name":"Aleksey","surname":"Ivanov"`,
@@ -175,7 +200,7 @@ func TestHandler_getAllUsers(t *testing.T) {
 			testname: "Invalid page_size above maximum",
 			queryStr: "?page_size=100",
 			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
-				s.On("GetAllUsers", 50, 1, "", "", "", "").Return([]entities.User{{Name: "Aleksey", Surname: "Ivanov"}}, 1, nil)
+				s.On("GetAllUsers", 50, 1, entities.UserFilter{}, []entities.SortField(nil), []string(nil)).Return([]entities.User{{Name: "Aleksey", Surname: "Ivanov"}}, 1, nil)
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `"name":"Aleksey","surname":"Ivanov"`,
@@ -184,7 +209,7 @@ func TestHandler_getAllUsers(t *testing.T) {
 			testname: "Invalid page number",
 			queryStr: "?page=-1",
 			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
-				s.On("GetAllUsers", 5, 1, "", "", "", "").Return([]entities.User{{Name: "Aleksey", Surname: "Ivanov"}}, 1, nil)
+				s.On("GetAllUsers", 5, 1, entities.UserFilter{}, []entities.SortField(nil), []string(nil)).Return([]entities.User{{Name: "Aleksey", Surname: "Ivanov"}}, 1, nil)
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `"name":"Aleksey","surname":"Ivanov"`,
@@ -193,7 +218,7 @@ func TestHandler_getAllUsers(t *testing.T) {
 			testname: "No users found",
 			queryStr: "",
 			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
-				s.On("GetAllUsers", 5, 1, "", "", "", "").Return([]entities.User{}, 0, nil)
+				s.On("GetAllUsers", 5, 1, entities.UserFilter{}, []entities.SortField(nil), []string(nil)).Return([]entities.User{}, 0, nil)
 			},
 			expectedStatusCode:   404,
 			expectedResponseBody: "",
@@ -202,7 +227,7 @@ func TestHandler_getAllUsers(t *testing.T) {
 			testname: "No users found with page >1",
 			queryStr: "?page=4",
 			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
-				s.On("GetAllUsers", 5, 4, "", "", "", "").Return([]entities.User{}, 0, nil)
+				s.On("GetAllUsers", 5, 4, entities.UserFilter{}, []entities.SortField(nil), []string(nil)).Return([]entities.User{}, 0, nil)
 			},
 			expectedStatusCode:   400,
 			expectedResponseBody: `"Page exceeds total number of pages"`,
@@ -211,7 +236,7 @@ func TestHandler_getAllUsers(t *testing.T) {
 			testname: "Page exceeds total number of pages",
 			queryStr: "?page=3",
 			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
-				s.On("GetAllUsers", 5, 3, "", "", "", "").Return([]entities.User{}, 5, nil)
+				s.On("GetAllUsers", 5, 3, entities.UserFilter{}, []entities.SortField(nil), []string(nil)).Return([]entities.User{}, 5, nil)
 			},
 			expectedStatusCode:   400,
 			expectedResponseBody: "Page exceeds total number of pages",
@@ -220,11 +245,206 @@ func TestHandler_getAllUsers(t *testing.T) {
 			testname: "Internal server error",
 			queryStr: "",
 			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
-				s.On("GetAllUsers", 5, 1, "", "", "", "").Return(nil, 0, errors.New("DB error"))
+				s.On("GetAllUsers", 5, 1, entities.UserFilter{}, []entities.SortField(nil), []string(nil)).Return(nil, 0, errors.New("DB error"))
 			},
 			expectedStatusCode:   500,
 			expectedResponseBody: "Failed to get users",
 		},
+		{
+			testname: "Sort by several fields",
+			queryStr: "?sort=surname,-created_at",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetAllUsers", 5, 1, entities.UserFilter{}, []entities.SortField{{Field: "surname"}, {Field: "created_at", Desc: true}}, []string(nil)).Return([]entities.User{{Name: "Aleksey", Surname: "Ivanov"}}, 1, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `"name":"Aleksey","surname":"Ivanov"`,
+		},
+		{
+			testname: "Rich filter",
+			queryStr: "?surname=ov&match=contains&nationality=by,RU&age_gte=18&age_lte=30&created_gte=2025-01-01&updated_lte=2025-02-01&has_patronymic=false",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
+				ageGte, ageLte, hasPatronymic := 18, 30, false
+				createdGte := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				updatedLte := time.Date(2025, 2, 1, 23, 59, 59, 999999999, time.UTC)
+				s.On("GetAllUsers", 5, 1, entities.UserFilter{
+					Surname:       "ov",
+					Match:         entities.MatchContains,
+					Nationalities: []string{"BY", "RU"},
+					AgeGte:        &ageGte,
+					AgeLte:        &ageLte,
+					CreatedGte:    &createdGte,
+					UpdatedLte:    &updatedLte,
+					HasPatronymic: &hasPatronymic,
+				}, []entities.SortField(nil), []string(nil)).Return([]entities.User{{Name: "Aleksey", Surname: "Ivanov"}}, 1, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `"name":"Aleksey","surname":"Ivanov"`,
+		},
+		{
+			testname: "Filter expression",
+			queryStr: "?filter=" + url.QueryEscape(`age>30 and (gender=="female" or nationality in ["BY","UA"])`),
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetAllUsers", 5, 1, entities.UserFilter{Expr: entities.FilterLogical{
+					Op:   entities.FilterAnd,
+					Left: entities.FilterComparison{Field: "age", Op: entities.FilterGt, Value: 30},
+					Right: entities.FilterLogical{
+						Op:    entities.FilterOr,
+						Left:  entities.FilterComparison{Field: "gender", Op: entities.FilterEq, Value: "female"},
+						Right: entities.FilterComparison{Field: "nationality", Op: entities.FilterIn, Value: []any{"BY", "UA"}},
+					},
+				}}, []entities.SortField(nil), []string(nil)).Return([]entities.User{{Name: "Olga", Surname: "Ivanova"}}, 1, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `"name":"Olga","surname":"Ivanova"`,
+		},
+		{
+			testname: "Tag filter",
+			queryStr: "?tag=VIP,newsletter,vip&tag_mode=all",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetAllUsers", 5, 1, entities.UserFilter{Tags: []string{"vip", "newsletter"}, TagMode: entities.TagModeAll},
+					[]entities.SortField(nil), []string(nil)).Return([]entities.User{{Name: "Olga", Surname: "Ivanova"}}, 1, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `"name":"Olga","surname":"Ivanova"`,
+		},
+		{
+			testname:                "Invalid tag mode",
+			queryStr:                "?tag=vip&tag_mode=none",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    `"errors":[{"field":"tag_mode","code":"invalid_tag_mode"`,
+		},
+		{
+			testname:                "Invalid tag",
+			queryStr:                "?tag=" + url.QueryEscape("vip list"),
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    `"errors":[{"field":"tag","code":"invalid_tag"`,
+		},
+		{
+			testname: "Attribute filters",
+			queryStr: "?attr.remote=true&attr.department=sales,%20support",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetAllUsers", 5, 1, entities.UserFilter{Attributes: []entities.AttributeFilter{
+					{Name: "department", Values: []any{"sales", "support"}},
+					{Name: "remote", Values: []any{"true"}},
+				}}, []entities.SortField(nil), []string(nil)).Return([]entities.User{{Name: "Olga", Surname: "Ivanova", Attributes: entities.Attributes{"department": "sales"}}}, 1, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `"attributes":{"department":"sales"}`,
+		},
+		{
+			testname: "Attribute filter of unknown attribute",
+			queryStr: "?attr.salary=100",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetAllUsers", 5, 1, mock.Anything, []entities.SortField(nil), []string(nil)).
+					Return(nil, 0, fmt.Errorf("%w: unknown attribute \"salary\"", service.ErrInvalidAttributeFilter))
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `"code":"invalid_filter"`,
+		},
+		{
+			testname:                "Attribute filter without name",
+			queryStr:                "?attr.=1",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    "attribute filter should be attr.",
+		},
+		{
+			testname:                "Filter expression with unknown field",
+			queryStr:                "?filter=" + url.QueryEscape(`salary > 100`),
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    `"errors":[{"field":"filter","code":"expr_unknown_field","message":"has unknown field \"salary\" at 1"}]`,
+		},
+		{
+			testname:                "Filter expression error in russian",
+			queryStr:                "?filter=" + url.QueryEscape(`salary > 100`),
+			acceptLanguage:          "ru",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody: `"detail":"Некоторые фильтры некорректны, они перечислены в errors",` +
+				`"errors":[{"field":"filter","code":"expr_unknown_field","message":"содержит неизвестное поле \"salary\" в позиции 1"}]`,
+		},
+		{
+			testname:                "Filter expression with wrong value type",
+			queryStr:                "?filter=" + url.QueryEscape(`age > "old"`),
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    "age must be compared with integer at 7",
+		},
+		{
+			testname:                "Filter expression syntax error",
+			queryStr:                "?filter=" + url.QueryEscape(`(age > 30`),
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    "unexpected end of expression",
+		},
+		{
+			testname: "Sparse fieldset",
+			queryStr: "?fields=id,name",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetAllUsers", 5, 1, entities.UserFilter{}, []entities.SortField(nil), []string{"id", "name"}).Return([]entities.User{{Id: 1, Name: "Aleksey"}}, 1, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":1,"name":"Aleksey"}]`,
+		},
+		{
+			testname:                "Sparse fieldset with unknown field",
+			queryStr:                "?fields=id,name_key",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    "Invalid fields, available fields: id, created_at",
+		},
+		{
+			testname:                "Invalid match mode",
+			queryStr:                "?name=al&match=suffix",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    `"errors":[{"field":"match","code":"invalid_match"`,
+		},
+		{
+			testname:                "Invalid nationality",
+			queryStr:                "?nationality=BY,RUS",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    `"errors":[{"field":"nationality","code":"invalid_nationality"`,
+		},
+		{
+			testname:                "Age range is reversed",
+			queryStr:                "?age_gte=40&age_lte=30",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    `"errors":[{"field":"age_gte","code":"invalid_age_range"`,
+		},
+		{
+			testname:                "Invalid date",
+			queryStr:                "?created_gte=yesterday",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    `"errors":[{"field":"created_gte","code":"invalid_time"`,
+		},
+		{
+			testname:                "Invalid gender",
+			queryStr:                "?gender=unknown",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    `"errors":[{"field":"gender","code":"invalid_gender"`,
+		},
+		{
+			testname:                "Sort by not whitelisted field",
+			queryStr:                "?sort=patronymic",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    "Invalid sort",
+		},
+		{
+			testname:                "Sort field used twice",
+			queryStr:                "?sort=age,-age",
+			mockGetAllUsersBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:      400,
+			expectedResponseBody:    "Invalid sort",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.testname, func(t *testing.T) {
@@ -234,6 +454,7 @@ func TestHandler_getAllUsers(t *testing.T) {
 			resp := httptest.NewRecorder()
 
 			req := httptest.NewRequest("GET", "/users"+test.queryStr, nil)
+			req.Header.Set("Accept-Language", test.acceptLanguage)
 
 			test.mockGetAllUsersBehavior(mockUserService)
 
@@ -245,6 +466,163 @@ func TestHandler_getAllUsers(t *testing.T) {
 	}
 }
 
+func TestHandler_getAllUsersEnvelope(t *testing.T) {
+	tests := []struct {
+		testname             string
+		queryStr             string
+		acceptHeader         string
+		expectedResponseBody string
+		expectedLinkHeader   string
+	}{
+		{
+			testname:             "Bare array by default",
+			queryStr:             "?page=2&name=Al",
+			expectedResponseBody: `[{"id":6,`,
+			expectedLinkHeader:   `</users?name=Al&page=2>; rel="self", </users?name=Al&page=1>; rel="first", </users?name=Al&page=1>; rel="prev", </users?name=Al&page=3>; rel="next", </users?name=Al&page=3>; rel="last"`,
+		},
+		{
+			testname:             "Envelope by query",
+			queryStr:             "?page=2&name=Al&envelope=true",
+			expectedResponseBody: `{"data":[{"id":6,`,
+			expectedLinkHeader:   `rel="first"`,
+		},
+		{
+			testname:             "Envelope metadata and links by Accept header",
+			queryStr:             "?page=2&name=Al",
+			acceptHeader:         "application/vnd.user-manager.envelope+json",
+			expectedResponseBody: `"page":2,"page_size":5,"total_count":12,"total_pages":3,"links":{"self":"/users?name=Al\u0026page=2","first":"/users?name=Al\u0026page=1","last":"/users?name=Al\u0026page=3","prev":"/users?name=Al\u0026page=1","next":"/users?name=Al\u0026page=3"}}`,
+			expectedLinkHeader:   `rel="next"`,
+		},
+		{
+			testname:             "Bare array with envelope=false",
+			queryStr:             "?page=2&name=Al&envelope=false",
+			expectedResponseBody: `[{"id":6,`,
+			expectedLinkHeader:   `rel="last"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockUserService := serviceMock.NewMockUserService(t)
+			router := setupTestRouter(mockUserService, nil, nil)
+
+			resp := httptest.NewRecorder()
+
+			req := httptest.NewRequest("GET", "/users"+test.queryStr, nil)
+			if test.acceptHeader != "" {
+				req.Header.Set("Accept", test.acceptHeader)
+			}
+
+			mockUserService.On("GetAllUsers", 5, 2, entities.UserFilter{Name: "Al"}, []entities.SortField(nil), []string(nil)).Return([]entities.User{{Id: 6, Name: "Alina"}}, 12, nil)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponseBody)
+			assert.Contains(t, resp.Header().Get("Link"), test.expectedLinkHeader)
+		})
+	}
+}
+
+func TestHandler_getUsersByCursor(t *testing.T) {
+	totalCount := 12
+
+	tests := []struct {
+		testname                     string
+		queryStr                     string
+		mockGetUsersByCursorBehavior func(s *serviceMock.MockUserService)
+		expectedStatusCode           int
+		expectedResponseBody         string
+	}{
+		{
+			testname: "First page",
+			queryStr: "?limit=1",
+			mockGetUsersByCursorBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetUsersByCursor", entities.UserFilter{}, []entities.SortField(nil), "", 1, false, []string(nil)).Return(entities.UsersCursorPage{Data: []entities.User{{Id: 1, Name: "Aleksey"}}, NextCursor: "next"}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `"next_cursor":"next"`,
+		},
+		{
+			testname: "Next page with total and filters",
+			queryStr: "?cursor=abc&with_total=true&surname=Iv",
+			mockGetUsersByCursorBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetUsersByCursor", entities.UserFilter{Surname: "Iv"}, []entities.SortField(nil), "abc", 5, true, []string(nil)).Return(entities.UsersCursorPage{Data: []entities.User{{Id: 6, Surname: "Ivanov"}}, PrevCursor: "prev", TotalCount: &totalCount}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `"prev_cursor":"prev","total_count":12`,
+		},
+		{
+			testname: "Sorted cursor page",
+			queryStr: "?limit=2&sort=-age",
+			mockGetUsersByCursorBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetUsersByCursor", entities.UserFilter{}, []entities.SortField{{Field: "age", Desc: true}}, "", 2, false, []string(nil)).Return(entities.UsersCursorPage{Data: []entities.User{{Id: 3, Age: 70}, {Id: 1, Age: 65}}, NextCursor: "next"}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `"next_cursor":"next"`,
+		},
+		{
+			testname: "Cursor page with fields",
+			queryStr: "?limit=1&fields=surname",
+			mockGetUsersByCursorBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetUsersByCursor", entities.UserFilter{}, []entities.SortField(nil), "", 1, false, []string{"surname"}).Return(entities.UsersCursorPage{Data: []entities.User{{Id: 1, Surname: "Ivanov"}}, NextCursor: "next"}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `"data":[{"surname":"Ivanov"}]`,
+		},
+		{
+			testname: "Limit above maximum",
+			queryStr: "?limit=100",
+			mockGetUsersByCursorBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetUsersByCursor", entities.UserFilter{}, []entities.SortField(nil), "", 50, false, []string(nil)).Return(entities.UsersCursorPage{Data: []entities.User{}}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[]}`,
+		},
+		{
+			testname: "Invalid cursor",
+			queryStr: "?cursor=broken",
+			mockGetUsersByCursorBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetUsersByCursor", entities.UserFilter{}, []entities.SortField(nil), "broken", 5, false, []string(nil)).Return(entities.UsersCursorPage{}, service.ErrInvalidCursor)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: "Invalid cursor",
+		},
+		{
+			testname:                     "Invalid gender",
+			queryStr:                     "?limit=5&gender=unknown",
+			mockGetUsersByCursorBehavior: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode:           400,
+			expectedResponseBody:         `"errors":[{"field":"gender","code":"invalid_gender"`,
+		},
+		{
+			testname: "Internal server error",
+			queryStr: "?limit=5",
+			mockGetUsersByCursorBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetUsersByCursor", entities.UserFilter{}, []entities.SortField(nil), "", 5, false, []string(nil)).Return(entities.UsersCursorPage{}, errors.New("DB error"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: "Failed to get users",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockUserService := serviceMock.NewMockUserService(t)
+			router := setupTestRouter(mockUserService, nil, nil)
+
+			resp := httptest.NewRecorder()
+
+			req := httptest.NewRequest("GET", "/users"+test.queryStr, nil)
+
+			test.mockGetUsersByCursorBehavior(mockUserService)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponseBody)
+		})
+	}
+}
+
 func TestHandler_getUserById(t *testing.T) {
 	tests := []struct {
 		testname           string
@@ -275,11 +653,44 @@ func TestHandler_getUserById(t *testing.T) {
 				s.On("Set", mock.Anything, "user:2", entities.User{Id: 2, Name: "DBUser"}).Return(nil)
 			},
 			mockUserServiceGet: func(s *serviceMock.MockUserService) {
-				s.On("GetUserById", int32(2)).Return(entities.User{Id: 2, Name: "DBUser"}, nil)
+				s.On("GetUserById", int32(2), []string(nil)).Return(entities.User{Id: 2, Name: "DBUser"}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedResponse:   `"id":2`,
 		},
+		{
+			testname: "Fields from cache",
+			userId:   "1?fields=name,id",
+			mockRedisGet: func(s *serviceMock.MockRedisService) {
+				s.On("Get", mock.Anything, "user:1", mock.AnythingOfType("*entities.User")).Run(func(args mock.Arguments) {
+					u := args.Get(2).(*entities.User)
+					*u = entities.User{Id: 1, Name: "CachedUser", Age: 30}
+				}).Return(nil)
+			},
+			mockUserServiceGet: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"name":"CachedUser","id":1}`,
+		},
+		{
+			testname: "Fields from DB are not cached",
+			userId:   "5?fields=id,surname",
+			mockRedisGet: func(s *serviceMock.MockRedisService) {
+				s.On("Get", mock.Anything, "user:5", mock.AnythingOfType("*entities.User")).Return(errors.New("redis: nil"))
+			},
+			mockUserServiceGet: func(s *serviceMock.MockUserService) {
+				s.On("GetUserById", int32(5), []string{"id", "surname"}).Return(entities.User{Id: 5, Surname: "Ivanov"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"id":5,"surname":"Ivanov"}`,
+		},
+		{
+			testname:           "Unknown field",
+			userId:             "1?fields=id,password",
+			mockRedisGet:       func(s *serviceMock.MockRedisService) {},
+			mockUserServiceGet: func(s *serviceMock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Invalid fields",
+		},
 		{
 			testname:           "Invalid user ID param",
 			userId:             "abc",
@@ -295,10 +706,10 @@ func TestHandler_getUserById(t *testing.T) {
 				s.On("Get", mock.Anything, "user:3", mock.AnythingOfType("*entities.User")).Return(errors.New("redis: nil"))
 			},
 			mockUserServiceGet: func(s *serviceMock.MockUserService) {
-				s.On("GetUserById", int32(3)).Return(entities.User{}, errors.New("not found"))
+				s.On("GetUserById", int32(3), []string(nil)).Return(entities.User{}, fmt.Errorf("%w: %w", service.ErrNotFound, sql.ErrNoRows))
 			},
 			expectedStatusCode: http.StatusNotFound,
-			expectedResponse:   `"User not found"`,
+			expectedResponse:   `{"code":"not_found","title":"Resource not found","status":404,"detail":"Failed to get user"}`,
 		},
 		{
 			testname: "Cache set warning ignored",
@@ -308,7 +719,7 @@ func TestHandler_getUserById(t *testing.T) {
 				s.On("Set", mock.Anything, "user:4", entities.User{Id: 4, Name: "DBUser4"}).Return(errors.New("redis set error"))
 			},
 			mockUserServiceGet: func(s *serviceMock.MockUserService) {
-				s.On("GetUserById", int32(4)).Return(entities.User{Id: 4, Name: "DBUser4"}, nil)
+				s.On("GetUserById", int32(4), []string(nil)).Return(entities.User{Id: 4, Name: "DBUser4"}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedResponse:   `"id":4`,
@@ -344,8 +755,8 @@ func TestHandler_updateUser(t *testing.T) {
 		testname           string
 		userId             string
 		inputBody          string
-		mockExistBehavior  func(s *serviceMock.MockUserService)
-		mockUpdateBehavior func(s *serviceMock.MockUserService)
+		contentType        string
+		mockUpdateBehavior func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService)
 		expectedCode       int
 		expectedResponse   string
 	}{
@@ -353,130 +764,225 @@ func TestHandler_updateUser(t *testing.T) {
 			testname:           "Invalid user_id param",
 			userId:             "abc",
 			inputBody:          `{"name":"John"}`,
-			mockExistBehavior:  func(s *serviceMock.MockUserService) {},
-			mockUpdateBehavior: func(s *serviceMock.MockUserService) {},
+			mockUpdateBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
 			expectedCode:       http.StatusBadRequest,
 			expectedResponse:   "Id should be number",
 		},
-		{
-			testname:  "ExistById service error",
-			userId:    "1",
-			inputBody: `{"name":"John"}`,
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistById", int32(1)).Return(false, errors.New("db error"))
-			},
-			mockUpdateBehavior: func(s *serviceMock.MockUserService) {},
-			expectedCode:       http.StatusBadRequest,
-			expectedResponse:   "Failed to check if the user exists",
-		},
 		{
 			testname:  "User does not exist",
 			userId:    "2",
 			inputBody: `{"name":"John"}`,
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistById", int32(2)).Return(false, nil)
+			mockUpdateBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("UpdateUser", int32(2), mock.Anything).Return(fmt.Errorf("%w: %w", service.ErrNotFound, repository.ErrUserNotFound))
 			},
-			mockUpdateBehavior: func(s *serviceMock.MockUserService) {},
-			expectedCode:       http.StatusBadRequest,
-			expectedResponse:   "Cannot update user that does not exist",
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `"code":"not_found"`,
 		},
 		{
-			testname:  "Invalid JSON body",
-			userId:    "3",
-			inputBody: `{"name":123}`, // expecting string, not number
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistById", int32(3)).Return(true, nil)
-			},
-			mockUpdateBehavior: func(s *serviceMock.MockUserService) {},
+			testname:           "Invalid JSON body",
+			userId:             "3",
+			inputBody:          `{"name":123}`, // expecting string, not number
+			mockUpdateBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
 			expectedCode:       http.StatusBadRequest,
 			expectedResponse:   "Failed to parse json in updateUser handler",
 		},
 		{
-			testname:  "Name contains digits",
+			testname:  "Invalid fields",
 			userId:    "4",
-			inputBody: `{"name":"John123"}`,
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistById", int32(4)).Return(true, nil)
-			},
-			mockUpdateBehavior: func(s *serviceMock.MockUserService) {},
-			expectedCode:       http.StatusBadRequest,
-			expectedResponse:   "Invalid name",
-		},
-		{
-			testname:  "Surname contains digits",
-			userId:    "5",
-			inputBody: `{"surname":"Smith1"}`,
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistById", int32(5)).Return(true, nil)
-			},
-			mockUpdateBehavior: func(s *serviceMock.MockUserService) {},
-			expectedCode:       http.StatusBadRequest,
-			expectedResponse:   "Invalid surname",
-		},
-		{
-			testname:  "Patronymic contains digits",
-			userId:    "6",
-			inputBody: `{"patronymic":"Ivanov3"}`,
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistById", int32(6)).Return(true, nil)
-			},
-			mockUpdateBehavior: func(s *serviceMock.MockUserService) {},
-			expectedCode:       http.StatusBadRequest,
-			expectedResponse:   "Invalid patronymic",
-		},
-		{
-			testname:  "Invalid gender",
-			userId:    "7",
-			inputBody: `{"gender":"unknown"}`,
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistById", int32(7)).Return(true, nil)
+			inputBody: `{"name":"John123","gender":"unknown"}`,
+			mockUpdateBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("UpdateUser", int32(4), mock.Anything).Return(&service.ValidationError{
+					Fields: []service.FieldError{
+						{Field: "name", Code: service.FieldErrNameNotCapitalized, Message: "must start with a capital letter"},
+						{Field: "gender", Code: service.FieldErrInvalidGender, Message: "must be male or female"},
+					},
+				})
 			},
-			mockUpdateBehavior: func(s *serviceMock.MockUserService) {},
-			expectedCode:       http.StatusBadRequest,
-			expectedResponse:   "Gender must be 'male' or 'female'",
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"field":"gender","code":"invalid_gender","message":"must be male or female"}`,
 		},
 		{
 			testname:  "UpdateUser service error",
 			userId:    "8",
 			inputBody: `{"name":"John"}`,
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistById", int32(8)).Return(true, nil)
-			},
-			mockUpdateBehavior: func(s *serviceMock.MockUserService) {
+			mockUpdateBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
 				s.On("UpdateUser", int32(8), mock.Anything).Return(errors.New("update failed"))
 			},
 			expectedCode:     http.StatusInternalServerError,
 			expectedResponse: "Failed to update user",
 		},
+		{
+			testname:  "Full name conflict",
+			userId:    "8",
+			inputBody: `{"surname":"Ivanov"}`,
+			mockUpdateBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("UpdateUser", int32(8), mock.Anything).Return(fmt.Errorf("%w: %w", service.ErrConflict, repository.ErrUserExists))
+			},
+			expectedCode:     http.StatusConflict,
+			expectedResponse: `"code":"conflict"`,
+		},
 		{
 			testname:  "Success update",
 			userId:    "9",
 			inputBody: `{"name":"John","gender":"male"}`,
-			mockExistBehavior: func(s *serviceMock.MockUserService) {
-				s.On("ExistById", int32(9)).Return(true, nil)
-			},
-			mockUpdateBehavior: func(s *serviceMock.MockUserService) {
+			mockUpdateBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
 				s.On("UpdateUser", int32(9), mock.Anything).Return(nil)
+				r.On("Delete", mock.Anything, "user:9").Return(nil)
 			},
 			expectedCode:     http.StatusOK,
 			expectedResponse: "User updated successfully",
 		},
+		{
+			testname:    "Merge patch",
+			userId:      "9",
+			inputBody:   `{"patronymic":null,"age":31}`,
+			contentType: "application/merge-patch+json",
+			mockUpdateBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("PatchUser", int32(9), service.PatchFormatMerge, []byte(`{"patronymic":null,"age":31}`)).
+					Return(entities.User{Id: 9, Name: "Ivan", Surname: "Ivanov", Age: 31}, nil)
+				r.On("Delete", mock.Anything, "user:9").Return(errors.New("redis error"))
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `"name":"Ivan","surname":"Ivanov","patronymic":"","age":31`,
+		},
+		{
+			testname:    "Json patch with failed test",
+			userId:      "9",
+			inputBody:   `[{"op":"test","path":"/age","value":30},{"op":"replace","path":"/age","value":31}]`,
+			contentType: "application/json-patch+json",
+			mockUpdateBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("PatchUser", int32(9), service.PatchFormatJSON, mock.Anything).
+					Return(entities.User{}, fmt.Errorf("%w: test of /age failed", service.ErrPatchFailed))
+			},
+			expectedCode:     http.StatusConflict,
+			expectedResponse: `"code":"patch_failed"`,
+		},
+		{
+			testname:    "Malformed json patch",
+			userId:      "9",
+			inputBody:   `{"op":"add"}`,
+			contentType: "application/json-patch+json",
+			mockUpdateBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("PatchUser", int32(9), service.PatchFormatJSON, mock.Anything).
+					Return(entities.User{}, fmt.Errorf("%w: cannot unmarshal object", service.ErrInvalidPatch))
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `"code":"invalid_body"`,
+		},
+		{
+			testname:           "Unsupported content type",
+			userId:             "9",
+			inputBody:          `name=Ivan`,
+			contentType:        "application/x-www-form-urlencoded",
+			mockUpdateBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
+			expectedCode:       http.StatusUnsupportedMediaType,
+			expectedResponse:   `"code":"unsupported_media_type"`,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.testname, func(t *testing.T) {
 			mockUserService := serviceMock.NewMockUserService(t)
-			router := setupTestRouter(mockUserService, nil, nil)
+			mockRedisService := serviceMock.NewMockRedisService(t)
+			router := setupTestRouter(mockUserService, nil, mockRedisService)
 
 			router.Use(func(c *gin.Context) {
 				c.Next()
 			})
 
-			test.mockExistBehavior(mockUserService)
-			test.mockUpdateBehavior(mockUserService)
+			test.mockUpdateBehavior(mockUserService, mockRedisService)
 
 			resp := httptest.NewRecorder()
 			req := httptest.NewRequest("PATCH", "/users/"+test.userId, bytes.NewBufferString(test.inputBody))
+			contentType := test.contentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			req.Header.Set("Content-Type", contentType)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
+
+func TestHandler_replaceUser(t *testing.T) {
+	age := 30
+
+	tests := []struct {
+		testname            string
+		userId              string
+		inputBody           string
+		mockReplaceBehavior func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService)
+		expectedCode        int
+		expectedResponse    string
+	}{
+		{
+			testname:  "Success replace",
+			userId:    "9",
+			inputBody: `{"name":"Ivan","surname":"Ivanov","age":30,"gender":"male"}`,
+			mockReplaceBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("ReplaceUser", int32(9), entities.ReplaceUserParams{Name: "Ivan", Surname: "Ivanov", Age: &age, Gender: "male"}).Return(nil)
+				r.On("Delete", mock.Anything, "user:9").Return(nil)
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: "User replaced successfully",
+		},
+		{
+			testname:            "Invalid user_id param",
+			userId:              "abc",
+			inputBody:           `{"name":"Ivan"}`,
+			mockReplaceBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
+			expectedCode:        http.StatusBadRequest,
+			expectedResponse:    `"code":"invalid_parameter"`,
+		},
+		{
+			testname:            "Invalid JSON body",
+			userId:              "9",
+			inputBody:           `{"age":"thirty"}`,
+			mockReplaceBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
+			expectedCode:        http.StatusBadRequest,
+			expectedResponse:    `"code":"invalid_body"`,
+		},
+		{
+			testname:  "Missing required fields",
+			userId:    "9",
+			inputBody: `{"name":"Ivan"}`,
+			mockReplaceBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("ReplaceUser", int32(9), mock.Anything).Return(&service.ValidationError{
+					Fields: []service.FieldError{
+						{Field: "surname", Code: service.FieldErrRequired, Message: "is required"},
+						{Field: "age", Code: service.FieldErrRequired, Message: "is required"},
+					},
+				})
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"field":"age","code":"required","message":"is required"}`,
+		},
+		{
+			testname:  "User does not exist",
+			userId:    "2",
+			inputBody: `{"name":"Ivan","surname":"Ivanov","age":30,"gender":"male"}`,
+			mockReplaceBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("ReplaceUser", int32(2), mock.Anything).Return(fmt.Errorf("%w: %w", service.ErrNotFound, repository.ErrUserNotFound))
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `"code":"not_found"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockUserService := serviceMock.NewMockUserService(t)
+			mockRedisService := serviceMock.NewMockRedisService(t)
+			router := setupTestRouter(mockUserService, nil, mockRedisService)
+
+			test.mockReplaceBehavior(mockUserService, mockRedisService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/users/"+test.userId, bytes.NewBufferString(test.inputBody))
 			req.Header.Set("Content-Type", "application/json")
 
 			router.ServeHTTP(resp, req)
@@ -486,3 +992,58 @@ func TestHandler_updateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_deleteUser(t *testing.T) {
+	tests := []struct {
+		testname           string
+		userId             string
+		mockDeleteBehavior func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService)
+		expectedCode       int
+		expectedResponse   string
+	}{
+		{
+			testname: "Success delete",
+			userId:   "9",
+			mockDeleteBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("DeleteUser", int32(9)).Return(nil)
+				r.On("Delete", mock.Anything, "user:9").Return(nil)
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: "User with id:9 deleted successfully",
+		},
+		{
+			testname:           "Invalid user_id param",
+			userId:             "abc",
+			mockDeleteBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
+			expectedCode:       http.StatusBadRequest,
+			expectedResponse:   `"code":"invalid_parameter"`,
+		},
+		{
+			testname: "User does not exist",
+			userId:   "2",
+			mockDeleteBehavior: func(s *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				s.On("DeleteUser", int32(2)).Return(fmt.Errorf("%w: %w", service.ErrNotFound, repository.ErrUserNotFound))
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `"code":"not_found"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockUserService := serviceMock.NewMockUserService(t)
+			mockRedisService := serviceMock.NewMockRedisService(t)
+			router := setupTestRouter(mockUserService, nil, mockRedisService)
+
+			test.mockDeleteBehavior(mockUserService, mockRedisService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/users/"+test.userId, nil)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
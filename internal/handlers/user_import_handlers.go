@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/Util787/user-manager-api/entities"
+	service "github.com/Util787/user-manager-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+const maxImportBodySize = 32 << 20 // 32 MB
+
+// importUsers godoc
+// @Summary      import users from csv or ndjson
+// @Description  Creates users from the request body row by row with the same validation as create user. Invalid rows and duplicates dont stop the import, they are returned in the report with reasons.
+// @Description
+// @Description  csv: first row is a header, name and surname columns are required, patronymic, age, gender, nationality and attributes (json object) are optional.
+// @Description  ndjson: one json object per line with the same fields, attributes are an object like in create user.
+// @Description  Attributes are checked against attribute definitions, so rows without required attributes are rejected.
+// @Description
+// @Description  If the file cant be read to the end (too large, broken csv quoting, ndjson line over 64 KB) rows imported before the error are kept and returned in `report` of the error.
+// @Description
+// @Description  Without enrichment gender is required for every row. Format is taken from `format` query or Content-Type (text/csv, application/x-ndjson).
+// @Tags         users
+// @Accept       plain
+// @Produce      json
+// @Param        format  query     string  false "csv or ndjson"
+// @Param        enrich  query     bool    false "fill missing age, gender and nationality from external apis"
+// @Param        file    body      string  true  "csv or ndjson content"
+// @Success      200  {object}  entities.ImportReport
+// @Failure      400  {object}  errorResponse  "invalid_parameter, invalid_body: rows imported before the error are listed in report"
+// @Failure      413  {object}  errorResponse  "payload_too_large: rows imported before the error are listed in report"
+// @Router       /users/import [post]
+func (h *Handler) importUsers(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	format := c.DefaultQuery("format", importFormatFromContentType(c.ContentType()))
+	enrich := c.DefaultQuery("enrich", "false") == "true"
+
+	log.Info("Importing users", slog.String("format", format), slog.Bool("enrich", enrich))
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBodySize)
+	report, err := h.services.ImportService.ImportUsers(body, format, enrich)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			newImportErrorResponse(c, log, codePayloadTooLarge, "Import file is too large", err, report)
+			return
+		}
+		if errors.Is(err, service.ErrUnsupportedImportFormat) {
+			newErrorResponse(c, log, codeInvalidParameter, "Format must be csv or ndjson", err)
+			return
+		}
+		newImportErrorResponse(c, log, codeInvalidBody, "Failed to read import file", err, report)
+		return
+	}
+
+	log.Info("Imported users", slog.Int("total", report.Total), slog.Int("accepted", len(report.Accepted)), slog.Int("rejected", len(report.Rejected)))
+
+	c.JSON(http.StatusOK, report)
+}
+
+// newImportErrorResponse sends problem with report of rows handled before the input failed, imported users arent rolled back
+func newImportErrorResponse(c *gin.Context, log *slog.Logger, code string, detail string, err error, report entities.ImportReport) {
+	problem := buildProblem(c, log, code, nil, err, detail)
+	if report.Total > 0 {
+		problem.Report = &report
+	}
+	sendProblem(c, problem)
+}
+
+func importFormatFromContentType(contentType string) string {
+	switch {
+	case contentType == "text/csv":
+		return service.ImportFormatCSV
+	case strings.Contains(contentType, "ndjson"):
+		return service.ImportFormatNDJSON
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/logger/handlers/slogdiscard"
+	service "github.com/Util787/user-manager-api/internal/services"
+	serviceMock "github.com/Util787/user-manager-api/internal/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_importUsers(t *testing.T) {
+	tests := []struct {
+		testname           string
+		queryStr           string
+		contentType        string
+		mockBehavior       func(s *serviceMock.MockImportService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname:    "Ok csv from content type",
+			queryStr:    "",
+			contentType: "text/csv",
+			mockBehavior: func(s *serviceMock.MockImportService) {
+				s.On("ImportUsers", mock.Anything, "csv", false).Return(entities.ImportReport{
+					Total:    2,
+					Accepted: []entities.ImportedRow{{Row: 2, Id: 10}},
+					Rejected: []entities.RejectedRow{{Row: 3, Code: service.RejectCodeConflict, Reason: "conflict: user already exists"}},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"total":2,"accepted":[{"row":2,"id":10}],"rejected":[{"row":3,"code":"conflict","reason":"conflict: user already exists"}]}`,
+		},
+		{
+			testname:    "Ok ndjson from query with enrichment",
+			queryStr:    "?format=ndjson&enrich=true",
+			contentType: "text/plain",
+			mockBehavior: func(s *serviceMock.MockImportService) {
+				s.On("ImportUsers", mock.Anything, "ndjson", true).Return(entities.ImportReport{Total: 1, Accepted: []entities.ImportedRow{{Row: 1, Id: 11}}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"accepted":[{"row":1,"id":11}]`,
+		},
+		{
+			testname:    "Unsupported format",
+			queryStr:    "?format=xlsx",
+			contentType: "text/plain",
+			mockBehavior: func(s *serviceMock.MockImportService) {
+				s.On("ImportUsers", mock.Anything, "xlsx", false).Return(entities.ImportReport{}, service.ErrUnsupportedImportFormat)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Format must be csv or ndjson",
+		},
+		{
+			testname:    "Too large file after imported rows",
+			queryStr:    "",
+			contentType: "application/x-ndjson",
+			mockBehavior: func(s *serviceMock.MockImportService) {
+				s.On("ImportUsers", mock.Anything, "ndjson", false).Return(entities.ImportReport{Total: 1, Accepted: []entities.ImportedRow{{Row: 1, Id: 12}}, Rejected: []entities.RejectedRow{}}, &http.MaxBytesError{Limit: maxImportBodySize})
+			},
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedResponse:   `"report":{"total":1,"accepted":[{"row":1,"id":12}],"rejected":[]}`,
+		},
+		{
+			testname:    "Invalid file",
+			queryStr:    "?format=csv",
+			contentType: "text/csv",
+			mockBehavior: func(s *serviceMock.MockImportService) {
+				s.On("ImportUsers", mock.Anything, "csv", false).Return(entities.ImportReport{}, errors.New("csv header must contain name column"))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Failed to read import file",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockImportService := serviceMock.NewMockImportService(t)
+			router := setupImportTestRouter(mockImportService)
+
+			test.mockBehavior(mockImportService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/users/import"+test.queryStr, bytes.NewBufferString("name,surname\nIvan,Ivanov\n"))
+			req.Header.Set("Content-Type", test.contentType)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
+
+// codes of rejected rows are checked by clients the same way as problem codes
+func TestRejectCodesAreProblemCodes(t *testing.T) {
+	for _, code := range []string{service.RejectCodeInvalidRow, service.RejectCodeValidationFailed, service.RejectCodeConflict,
+		service.RejectCodeEnrichmentFailed, service.RejectCodeInternal} {
+		assert.Contains(t, problemTypes, code)
+	}
+}
+
+func setupImportTestRouter(mockImportService *serviceMock.MockImportService) *gin.Engine {
+	logger := slogdiscard.NewDiscardLogger()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	h := NewHandlers(&service.Service{ImportService: mockImportService}, logger)
+
+	router.POST("/users/import", h.importUsers)
+
+	return router
+}
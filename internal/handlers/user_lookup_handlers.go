@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/logger/sl"
+	service "github.com/Util787/user-manager-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// max number of ids in one lookup
+const maxLookupIds = 100
+
+// lookupUsers godoc
+// @Summary      get many users by ids
+// @Description  Returns users in the same order as requested ids, ids of users that dont exist are listed in `missing`. Repeated ids are returned once.
+// @Description  The same lookup is available as GET /users?ids=1,2,3
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        ids     body      entities.LookupUsersParams  true  "max 100 ids"
+// @Param        fields  query     string                      false "comma separated fields to return, example: id,name,surname"
+// @Success      200  {object}  entities.UsersLookup
+// @Failure      400  {object}  errorResponse  "invalid_body, invalid_parameter"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /users/lookup [post]
+func (h *Handler) lookupUsers(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	var params entities.LookupUsersParams
+	err := c.ShouldBindJSON(&params)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
+		return
+	}
+
+	fields, err := service.ParseFields(c.DefaultQuery("fields", ""))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Invalid fields, available fields: %s", err, availableUserFields)
+		return
+	}
+
+	h.getUsersByIds(c, log, params.Ids, fields)
+}
+
+// cached users are read with one MGET, the rest with one db query and then cached with one pipeline
+func (h *Handler) getUsersByIds(c *gin.Context, log *slog.Logger, ids []int32, fields []string) {
+	ids = uniqueIds(ids)
+	if len(ids) == 0 {
+		newErrorResponse(c, log, codeInvalidParameter, "At least one id must be provided", errors.New("empty ids"))
+		return
+	}
+	if len(ids) > maxLookupIds {
+		newErrorResponse(c, log, codeInvalidParameter, "Too many ids, max is %d", errors.New("too many ids"), maxLookupIds)
+		return
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = "user:" + strconv.Itoa(int(id))
+	}
+
+	//cache check
+	cached, err := h.services.RedisService.MGet(context.Background(), keys)
+	if err != nil {
+		log.Warn("Failed to get users from cache", sl.Err(err))
+		cached = make([][]byte, len(ids))
+	}
+
+	found := make(map[int32]entities.User, len(ids))
+	var misses []int32
+	for i, id := range ids {
+		var user entities.User
+		if cached[i] != nil && json.Unmarshal(cached[i], &user) == nil {
+			found[id] = user
+			continue
+		}
+		misses = append(misses, id)
+	}
+	log.Info("Got users from cache", slog.Int("hits", len(found)), slog.Int("misses", len(misses)))
+
+	if len(misses) > 0 {
+		log.Info("Getting users by ids from postgres db", slog.Any("ids", misses))
+		users, err := h.services.UserService.GetUsersByIds(misses)
+		if err != nil {
+			newServiceErrorResponse(c, log, "Failed to get users", err)
+			return
+		}
+
+		//cache set
+		toCache := make(map[string]any, len(users))
+		for _, user := range users {
+			found[user.Id] = user
+			toCache["user:"+strconv.Itoa(int(user.Id))] = user
+		}
+		if len(toCache) > 0 {
+			err = h.services.RedisService.SetMany(context.Background(), toCache)
+			if err != nil {
+				log.Warn("Failed to set users in cache", sl.Err(err))
+			}
+		}
+	}
+
+	result := entities.UsersLookup{Data: []entities.User{}, Missing: []int32{}}
+	for _, id := range ids {
+		user, ok := found[id]
+		if !ok {
+			result.Missing = append(result.Missing, id)
+			continue
+		}
+		result.Data = append(result.Data, user.WithFields(fields))
+	}
+
+	log.Info("Got users by ids successfully", slog.Int("count", len(result.Data)), slog.Any("missing", result.Missing))
+
+	c.JSON(http.StatusOK, result)
+}
+
+// keeps the first occurrence of every id
+func uniqueIds(ids []int32) []int32 {
+	seen := make(map[int32]bool, len(ids))
+	unique := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
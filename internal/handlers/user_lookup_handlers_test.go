@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	serviceMock "github.com/Util787/user-manager-api/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_getUsersByIds(t *testing.T) {
+	manyIds := make([]string, maxLookupIds+1)
+	for i := range manyIds {
+		manyIds[i] = strconv.Itoa(i + 1)
+	}
+
+	tests := []struct {
+		testname           string
+		method             string
+		target             string
+		inputBody          string
+		mockBehavior       func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname: "Cache hits and db misses keep request order",
+			method:   "GET",
+			target:   "/users?ids=3,1,2,3",
+			mockBehavior: func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				r.On("MGet", mock.Anything, []string{"user:3", "user:1", "user:2"}).Return([][]byte{nil, []byte(`{"id":1,"name":"Cached"}`), nil}, nil)
+				u.On("GetUsersByIds", []int32{3, 2}).Return([]entities.User{{Id: 3, Name: "Db"}}, nil)
+				r.On("SetMany", mock.Anything, map[string]any{"user:3": entities.User{Id: 3, Name: "Db"}}).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"data":[{"id":3,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","name":"Db"`,
+		},
+		{
+			testname: "Missing ids are reported",
+			method:   "GET",
+			target:   "/users?ids=3,1,2&fields=id,name",
+			mockBehavior: func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				r.On("MGet", mock.Anything, []string{"user:3", "user:1", "user:2"}).Return([][]byte{nil, []byte(`{"id":1,"name":"Cached"}`), nil}, nil)
+				u.On("GetUsersByIds", []int32{3, 2}).Return([]entities.User{{Id: 3, Name: "Db"}}, nil)
+				r.On("SetMany", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"data":[{"id":3,"name":"Db"},{"id":1,"name":"Cached"}],"missing":[2]}`,
+		},
+		{
+			testname:  "All from cache",
+			method:    "POST",
+			target:    "/users/lookup?fields=name",
+			inputBody: `{"ids":[2,1]}`,
+			mockBehavior: func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				r.On("MGet", mock.Anything, []string{"user:2", "user:1"}).Return([][]byte{[]byte(`{"id":2,"name":"Second"}`), []byte(`{"id":1,"name":"First"}`)}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"data":[{"name":"Second"},{"name":"First"}],"missing":[]}`,
+		},
+		{
+			testname:  "Redis error falls back to db",
+			method:    "POST",
+			target:    "/users/lookup?fields=id",
+			inputBody: `{"ids":[5]}`,
+			mockBehavior: func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				r.On("MGet", mock.Anything, []string{"user:5"}).Return(nil, errors.New("redis down"))
+				u.On("GetUsersByIds", []int32{5}).Return([]entities.User{{Id: 5}}, nil)
+				r.On("SetMany", mock.Anything, mock.Anything).Return(errors.New("redis down"))
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"data":[{"id":5}],"missing":[]}`,
+		},
+		{
+			testname:           "Invalid ids",
+			method:             "GET",
+			target:             "/users?ids=1,x",
+			mockBehavior:       func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"errors":[{"field":"ids","code":"invalid_ids"`,
+		},
+		{
+			testname:           "Empty ids",
+			method:             "POST",
+			target:             "/users/lookup",
+			inputBody:          `{"ids":[]}`,
+			mockBehavior:       func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "At least one id must be provided",
+		},
+		{
+			testname:           "Too many ids",
+			method:             "GET",
+			target:             "/users?ids=" + strings.Join(manyIds, ","),
+			mockBehavior:       func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Too many ids, max is 100",
+		},
+		{
+			testname:  "Service error",
+			method:    "POST",
+			target:    "/users/lookup",
+			inputBody: `{"ids":[1]}`,
+			mockBehavior: func(u *serviceMock.MockUserService, r *serviceMock.MockRedisService) {
+				r.On("MGet", mock.Anything, mock.Anything).Return([][]byte{nil}, nil)
+				u.On("GetUsersByIds", []int32{1}).Return(nil, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   "Failed to get users",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockUserService := serviceMock.NewMockUserService(t)
+			mockRedisService := serviceMock.NewMockRedisService(t)
+			router := setupTestRouter(mockUserService, nil, mockRedisService)
+
+			test.mockBehavior(mockUserService, mockRedisService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.target, bytes.NewBufferString(test.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// pg_trgm default similarity threshold
+	defaultSearchThreshold = 0.3
+	defaultSearchLimit     = 20
+	maxSearchLimit         = 50
+)
+
+// searchUsers godoc
+// @Summary      fuzzy search users by name
+// @Description  Typo tolerant search across name, surname and patronymic using trigram similarity (pg_trgm), works for cyrillic names as well.
+// @Description  Names are compared by transliterated latin keys, so latin query finds cyrillic names and vice versa.
+// @Description  Users are ordered by `score` - the best similarity of name, surname or patronymic to the query from 0 to 1.
+// @Description
+// @Description  Example: ?q=Ивонов
+// @Description  Response: Иванов, Ивонин, Ivanov, etc.
+// @Description
+// @Description  Lower `threshold` returns more users with worse matches.
+// @Tags         users
+// @Produce      json
+// @Param        q          query     string  true  "search query"
+// @Param        threshold  query     number  false "min similarity, from 0 to 1, default 0.3"
+// @Param        limit      query     int     false "default 20, max 50"
+// @Success      200  {object}  entities.UserSearchResponse
+// @Failure      400  {object}  errorResponse  "invalid_parameter"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /users/search [get]
+func (h *Handler) searchUsers(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	query := strings.TrimSpace(c.DefaultQuery("q", ""))
+	if query == "" {
+		newErrorResponse(c, log, codeInvalidParameter, "Search query q is required", errors.New("empty search query"))
+		return
+	}
+
+	//validation
+	threshold := defaultSearchThreshold
+	if thresholdStr := c.DefaultQuery("threshold", ""); thresholdStr != "" {
+		var err error
+		threshold, err = strconv.ParseFloat(thresholdStr, 64)
+		if err != nil || threshold < 0 || threshold > 1 {
+			newErrorResponse(c, log, codeInvalidParameter, "Threshold should be a number from 0 to 1", errors.New("invalid threshold"))
+			return
+		}
+	}
+
+	limitStr := c.DefaultQuery("limit", strconv.Itoa(defaultSearchLimit))
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 {
+		limit = defaultSearchLimit
+		log.Debug("Invalid limit value, set to default", slog.String("user's limit", limitStr))
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+		log.Debug("limit is greater than max, set to max", slog.String("user's limit", limitStr))
+	}
+
+	log.Info("Searching users", slog.String("query", query), slog.Float64("threshold", threshold), slog.Int("limit", limit))
+	results, err := h.services.UserService.SearchUsers(query, threshold, limit)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to search users", err)
+		return
+	}
+
+	log.Info("Searched users successfully", slog.Int("count", len(results)))
+
+	c.JSON(http.StatusOK, entities.UserSearchResponse{
+		Query:     query,
+		Threshold: threshold,
+		Data:      results,
+	})
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	serviceMock "github.com/Util787/user-manager-api/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_searchUsers(t *testing.T) {
+	tests := []struct {
+		testname           string
+		queryStr           string
+		mockBehavior       func(s *serviceMock.MockUserService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname: "Ok with defaults",
+			queryStr: "?q=" + url.QueryEscape("Ивонов"),
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("SearchUsers", "Ивонов", defaultSearchThreshold, defaultSearchLimit).Return([]entities.UserSearchResult{
+					{User: entities.User{Id: 1, Name: "Иван", Surname: "Иванов"}, Score: 0.54},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"surname":"Иванов","patronymic":"","age":0,"gender":"","nationality":"","score":0.54}]`,
+		},
+		{
+			testname: "Custom threshold and limit above max",
+			queryStr: "?q=aleks&threshold=0.1&limit=100",
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("SearchUsers", "aleks", 0.1, maxSearchLimit).Return([]entities.UserSearchResult{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"query":"aleks","threshold":0.1,"data":[]}`,
+		},
+		{
+			testname:           "Empty query",
+			queryStr:           "?q=%20",
+			mockBehavior:       func(s *serviceMock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Search query q is required",
+		},
+		{
+			testname:           "Threshold out of range",
+			queryStr:           "?q=aleks&threshold=1.5",
+			mockBehavior:       func(s *serviceMock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Threshold should be a number from 0 to 1",
+		},
+		{
+			testname: "Service error",
+			queryStr: "?q=aleks",
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("SearchUsers", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   "Failed to search users",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockUserService := serviceMock.NewMockUserService(t)
+			router := setupTestRouter(mockUserService, nil, nil)
+
+			test.mockBehavior(mockUserService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/users/search"+test.queryStr, nil)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Util787/user-manager-api/entities"
+	service "github.com/Util787/user-manager-api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+const maxAgeBuckets = 20
+
+// getUserStats godoc
+// @Summary      aggregated users statistics
+// @Description  Counts by gender, nationality and tag, average age per nationality, age histogram and time series of created users.
+// @Description  Accepts the same filters as GET /users, deleted users are not counted.
+// @Description
+// @Description  `age_buckets` are ascending lower bounds of age buckets, bucket from 0 is added if the first bound isnt 0.
+// @Description  Example: ?age_buckets=18,30,60 gives buckets 0-18, 18-30, 30-60 and 60+, `to` of the last bucket is null.
+// @Description
+// @Description  `interval` of created series is day or week (weeks start on monday), periods are in UTC and periods without created users are skipped.
+// @Description
+// @Description  Results are cached until any user is created, updated, deleted or tagged.
+// @Tags         users
+// @Produce      json
+// @Param        age_buckets     query     string  false  "comma separated lower bounds of age buckets, default 18,25,35,45,55,65, max 20 bounds"
+// @Param        interval        query     string  false  "day or week, default day"
+// @Param        name            query     string  false  "name filter"
+// @Param        surname         query     string  false  "surname filter"
+// @Param        patronymic      query     string  false  "patronymic filter"
+// @Param        gender          query     string  false  "gender filter can be only male or female"
+// @Param        match           query     string  false  "how name filters are matched: prefix (default), exact or contains"
+// @Param        nationality     query     string  false  "comma separated country codes, example: BY,RU"
+// @Param        tag             query     string  false  "comma separated tags, example: vip,newsletter"
+// @Param        tag_mode        query     string  false  "any (default) or all of tags"
+// @Param        age_gte         query     int     false  "min age, inclusive"
+// @Param        age_lte         query     int     false  "max age, inclusive"
+// @Param        created_gte     query     string  false  "created at or after, date (2006-01-02) or RFC 3339 time"
+// @Param        created_lte     query     string  false  "created at or before, date means the end of that day"
+// @Param        updated_gte     query     string  false  "updated at or after, date (2006-01-02) or RFC 3339 time"
+// @Param        updated_lte     query     string  false  "updated at or before, date means the end of that day"
+// @Param        has_patronymic  query     bool    false  "only users with (true) or without (false) patronymic"
+// @Param        filter          query     string  false  "filter expression, same as for GET /users"
+// @Param        attr.name       query     string  false  "custom attribute filter, name is the attribute name, example: attr.department=sales,support"
+// @Success      200  {object}  entities.UserStats
+// @Failure      400  {object}  errorResponse  "invalid_parameter, invalid_filter"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /users/stats [get]
+func (h *Handler) getUserStats(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	filter, err := parseUserFilter(c)
+	if err != nil {
+		newParamErrorResponse(c, log, codeInvalidFilter, detailInvalidFilter, err)
+		return
+	}
+
+	//validation
+	opts := entities.StatsOptions{
+		AgeBuckets: service.DefaultAgeBuckets,
+		Interval:   c.DefaultQuery("interval", entities.StatsIntervalDay),
+	}
+	if opts.Interval != entities.StatsIntervalDay && opts.Interval != entities.StatsIntervalWeek {
+		newErrorResponse(c, log, codeInvalidParameter, "Interval can be only day or week", errors.New("invalid interval"))
+		return
+	}
+	if bucketsStr := c.DefaultQuery("age_buckets", ""); bucketsStr != "" {
+		opts.AgeBuckets, err = parseAgeBuckets(bucketsStr)
+		if err != nil {
+			newParamErrorResponse(c, log, codeInvalidParameter, detailInvalidParameter, err)
+			return
+		}
+	}
+
+	log.Info("Getting users stats", slog.Any("filter", filter), slog.Any("options", opts))
+	stats, err := h.services.StatsService.GetUserStats(c.Request.Context(), filter, opts)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidStatsOptions) {
+			newErrorResponse(c, log, codeInvalidParameter, "Invalid stats options", err)
+			return
+		}
+		newServiceErrorResponse(c, log, "Failed to get users stats", err)
+		return
+	}
+
+	log.Info("Got users stats successfully", slog.Int("total", stats.Total))
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func parseAgeBuckets(bucketsStr string) ([]int, error) {
+	parts := strings.Split(bucketsStr, ",")
+	if len(parts) > maxAgeBuckets {
+		return nil, service.NewParamError("age_buckets", service.FieldErrTooManyBounds, maxAgeBuckets)
+	}
+
+	buckets := make([]int, 0, len(parts))
+	for _, part := range parts {
+		bound, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || bound < 0 {
+			return nil, service.NewParamError("age_buckets", service.FieldErrInvalidBound, part)
+		}
+		if len(buckets) > 0 && bound <= buckets[len(buckets)-1] {
+			return nil, service.NewParamError("age_buckets", service.FieldErrBoundsNotAscending)
+		}
+		buckets = append(buckets, bound)
+	}
+	return buckets, nil
+}
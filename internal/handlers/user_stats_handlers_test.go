@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/logger/handlers/slogdiscard"
+	service "github.com/Util787/user-manager-api/internal/services"
+	serviceMock "github.com/Util787/user-manager-api/internal/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_getUserStats(t *testing.T) {
+	to := 30
+	stats := entities.UserStats{
+		Total:         3,
+		ByGender:      []entities.GenderStats{{Gender: "female", Count: 1}, {Gender: "male", Count: 2}},
+		ByNationality: []entities.NationalityStats{{Nationality: "BY", Count: 3, AvgAge: 27.5}},
+		AgeHistogram:  []entities.AgeBucket{{From: 0, To: &to, Count: 2}, {From: 30, Count: 1}},
+		Interval:      entities.StatsIntervalWeek,
+		CreatedSeries: []entities.TimeSeriesPoint{{Period: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), Count: 3}},
+	}
+
+	tests := []struct {
+		testname           string
+		queryStr           string
+		mockBehavior       func(s *serviceMock.MockStatsService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname: "Ok with defaults",
+			queryStr: "",
+			mockBehavior: func(s *serviceMock.MockStatsService) {
+				s.On("GetUserStats", mock.Anything, entities.UserFilter{},
+					entities.StatsOptions{AgeBuckets: service.DefaultAgeBuckets, Interval: entities.StatsIntervalDay}).Return(entities.UserStats{Total: 0}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"total":0`,
+		},
+		{
+			testname: "Ok with filters, buckets and interval",
+			queryStr: "?gender=male&age_buckets=0,30&interval=week",
+			mockBehavior: func(s *serviceMock.MockStatsService) {
+				s.On("GetUserStats", mock.Anything, entities.UserFilter{Gender: "male"},
+					entities.StatsOptions{AgeBuckets: []int{0, 30}, Interval: entities.StatsIntervalWeek}).Return(stats, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `"age_histogram":[{"from":0,"to":30,"count":2},{"from":30,"to":null,"count":1}],` +
+				`"interval":"week","created_series":[{"period":"2025-03-03T00:00:00Z","count":3}]}`,
+		},
+		{
+			testname: "Attribute filter",
+			queryStr: "?attr.remote=true",
+			mockBehavior: func(s *serviceMock.MockStatsService) {
+				s.On("GetUserStats", mock.Anything, entities.UserFilter{Attributes: []entities.AttributeFilter{{Name: "remote", Values: []any{"true"}}}},
+					entities.StatsOptions{AgeBuckets: service.DefaultAgeBuckets, Interval: entities.StatsIntervalDay}).Return(entities.UserStats{Total: 1}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"total":1`,
+		},
+		{
+			testname: "Tag counts of tagged users",
+			queryStr: "?tag=vip",
+			mockBehavior: func(s *serviceMock.MockStatsService) {
+				s.On("GetUserStats", mock.Anything, entities.UserFilter{Tags: []string{"vip"}, TagMode: entities.TagModeAny},
+					entities.StatsOptions{AgeBuckets: service.DefaultAgeBuckets, Interval: entities.StatsIntervalDay}).
+					Return(entities.UserStats{Total: 2, ByTag: []entities.TagStats{{Tag: "vip", Count: 2}, {Tag: "newsletter", Count: 1}}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `"by_tag":[{"tag":"vip","count":2},{"tag":"newsletter","count":1}]`,
+		},
+		{
+			testname:           "Invalid interval",
+			queryStr:           "?interval=month",
+			mockBehavior:       func(s *serviceMock.MockStatsService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Interval can be only day or week",
+		},
+		{
+			testname:           "Not ascending age buckets",
+			queryStr:           "?age_buckets=30,18",
+			mockBehavior:       func(s *serviceMock.MockStatsService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"errors":[{"field":"age_buckets","code":"bounds_not_ascending"`,
+		},
+		{
+			testname:           "Negative age bucket",
+			queryStr:           "?age_buckets=-1,18",
+			mockBehavior:       func(s *serviceMock.MockStatsService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"errors":[{"field":"age_buckets","code":"invalid_bound","message":"bound \"-1\" is not a non-negative integer"}]`,
+		},
+		{
+			testname:           "Too many age buckets",
+			queryStr:           "?age_buckets=1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21",
+			mockBehavior:       func(s *serviceMock.MockStatsService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "no more than 20 bounds allowed",
+		},
+		{
+			testname:           "Invalid filter",
+			queryStr:           "?gender=other",
+			mockBehavior:       func(s *serviceMock.MockStatsService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Invalid filter",
+		},
+		{
+			testname: "Service error",
+			queryStr: "",
+			mockBehavior: func(s *serviceMock.MockStatsService) {
+				s.On("GetUserStats", mock.Anything, mock.Anything, mock.Anything).Return(entities.UserStats{}, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   "Failed to get users stats",
+		},
+		{
+			testname: "Invalid options from service",
+			queryStr: "",
+			mockBehavior: func(s *serviceMock.MockStatsService) {
+				s.On("GetUserStats", mock.Anything, mock.Anything, mock.Anything).
+					Return(entities.UserStats{}, fmt.Errorf("%w: no age buckets", service.ErrInvalidStatsOptions))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Invalid stats options",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockStatsService := serviceMock.NewMockStatsService(t)
+			router := setupStatsTestRouter(mockStatsService)
+
+			test.mockBehavior(mockStatsService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/users/stats"+test.queryStr, nil)
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
+
+func setupStatsTestRouter(mockStatsService *serviceMock.MockStatsService) *gin.Engine {
+	logger := slogdiscard.NewDiscardLogger()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	h := NewHandlers(&service.Service{StatsService: mockStatsService}, logger)
+
+	router.GET("/users/stats", h.getUserStats)
+
+	return router
+}
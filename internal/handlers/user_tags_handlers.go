@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/gin-gonic/gin"
+)
+
+// getUserTags godoc
+// @Summary      get tags of user
+// @Tags         tags
+// @Produce      json
+// @Param        user_id  path      int  true "user_id"
+// @Success      200      {object}  entities.UserTags
+// @Failure      400      {object}  errorResponse  "invalid_parameter"
+// @Failure      404      {object}  errorResponse  "not_found"
+// @Failure      500      {object}  errorResponse  "internal_error"
+// @Router       /users/{user_id}/tags [get]
+func (h *Handler) getUserTags(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	userId32, err := parseInt32(c.Param("user_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
+		return
+	}
+
+	tags, err := h.services.UserService.GetUserTags(userId32)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to get user tags", err)
+		return
+	}
+
+	log.Info("Got user tags successfully", slog.Int("user_id", int(userId32)), slog.Int("count", len(tags.Tags)))
+	c.JSON(http.StatusOK, tags)
+}
+
+// addUserTags godoc
+// @Summary      add tags to user
+// @Description  Tags are trimmed and lowercased, missing tags are created. Tags that the user already has are skipped
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        user_id  path      int                  true "user_id"
+// @Param        tags     body      entities.TagsParams  true "tags to add"
+// @Success      200      {object}  entities.UserTags    "all tags of the user after the change"
+// @Failure      400      {object}  errorResponse  "invalid_parameter, invalid_body, validation_failed: invalid tags are listed in errors"
+// @Failure      404      {object}  errorResponse  "not_found"
+// @Failure      500      {object}  errorResponse  "internal_error"
+// @Router       /users/{user_id}/tags [post]
+func (h *Handler) addUserTags(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	userId32, err := parseInt32(c.Param("user_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
+		return
+	}
+
+	var params entities.TagsParams
+	err = c.ShouldBindJSON(&params)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
+		return
+	}
+
+	log.Info("Adding user tags", slog.Int("user_id", int(userId32)), slog.Any("tags", params.Tags))
+	tags, err := h.services.UserService.AddUserTags(userId32, params.Tags)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to add user tags", err)
+		return
+	}
+
+	log.Info("Added user tags successfully", slog.Int("user_id", int(userId32)))
+	c.JSON(http.StatusOK, tags)
+}
+
+// removeUserTag godoc
+// @Summary      remove tag from user
+// @Description  Removing a tag that the user doesnt have isnt an error
+// @Tags         tags
+// @Produce      json
+// @Param        user_id  path      int     true "user_id"
+// @Param        tag      path      string  true "tag"
+// @Success      200      {object}  entities.UserTags  "all tags of the user after the change"
+// @Failure      400      {object}  errorResponse  "invalid_parameter, validation_failed"
+// @Failure      404      {object}  errorResponse  "not_found"
+// @Failure      500      {object}  errorResponse  "internal_error"
+// @Router       /users/{user_id}/tags/{tag} [delete]
+func (h *Handler) removeUserTag(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	userId32, err := parseInt32(c.Param("user_id"))
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidParameter, "Id should be number", err)
+		return
+	}
+	tag := c.Param("tag")
+
+	log.Info("Removing user tag", slog.Int("user_id", int(userId32)), slog.String("tag", tag))
+	tags, err := h.services.UserService.RemoveUserTags(userId32, []string{tag})
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to remove user tag", err)
+		return
+	}
+
+	log.Info("Removed user tag successfully", slog.Int("user_id", int(userId32)))
+	c.JSON(http.StatusOK, tags)
+}
+
+// bulkTagUsers godoc
+// @Summary      bulk tag users by filter
+// @Description  Adds and removes tags of every user matched by the filter. Filters are the same as in get all users plus `ids`, at least one of them is required.
+// @Description  Tags are changed in one transaction that is rolled back if more than `max_affected` users are matched.
+// @Description
+// @Description  Example: ?nationality=BY&age_gte=18 with body {"add":["campaign_2025"],"remove":["trial"]}
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        ids           query     string                   false "comma separated ids, example: 1,2,3"
+// @Param        name          query     string                   false "name filter"
+// @Param        surname       query     string                   false "surname filter"
+// @Param        gender        query     string                   false "gender filter can be only male or female"
+// @Param        attr.name     query     string                   false "custom attribute filter, name is the attribute name, example: attr.department=sales,support"
+// @Param        tag           query     string                   false "comma separated tags, example: vip,newsletter"
+// @Param        tag_mode      query     string                   false "any (default) or all of tags"
+// @Param        dry_run       query     bool                     false "only count matched users and return a sample"
+// @Param        max_affected  query     int                      false "max:1000"
+// @Param        tags          body      entities.BulkTagsParams  true  "tags to add and remove"
+// @Success      200  {object}  entities.BulkResult
+// @Failure      400  {object}  errorResponse  "invalid_filter, invalid_body, validation_failed, bulk_limit_exceeded"
+// @Failure      500  {object}  errorResponse  "internal_error"
+// @Router       /users/tags [post]
+func (h *Handler) bulkTagUsers(c *gin.Context) {
+	op, _ := c.Get("op")
+	log := h.log.With(
+		slog.Any("op", op),
+	)
+
+	filter, err := parseBulkFilter(c)
+	if err != nil {
+		newParamErrorResponse(c, log, codeInvalidFilter, detailInvalidFilter, err)
+		return
+	}
+	opts := parseBulkOptions(c, log)
+
+	var params entities.BulkTagsParams
+	err = c.ShouldBindJSON(&params)
+	if err != nil {
+		newErrorResponse(c, log, codeInvalidBody, "Failed to parse json", err)
+		return
+	}
+
+	log.Info("Bulk tagging users", slog.Any("filter", filter), slog.Any("options", opts), slog.Any("tags", params))
+	result, err := h.services.UserService.BulkTagUsers(filter, params, opts)
+	if err != nil {
+		newServiceErrorResponse(c, log, "Failed to tag users", err)
+		return
+	}
+
+	log.Info("Bulk tagged users successfully", slog.Int("affected", result.Affected), slog.Bool("dry_run", result.DryRun))
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/repository"
+	service "github.com/Util787/user-manager-api/internal/services"
+	serviceMock "github.com/Util787/user-manager-api/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_userTags(t *testing.T) {
+	tests := []struct {
+		testname           string
+		method             string
+		path               string
+		inputBody          string
+		mockBehavior       func(s *serviceMock.MockUserService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname: "Get tags",
+			method:   "GET",
+			path:     "/users/1/tags",
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetUserTags", int32(1)).Return(entities.UserTags{UserId: 1, Tags: []string{"newsletter", "vip"}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"user_id":1,"tags":["newsletter","vip"]}`,
+		},
+		{
+			testname:           "Get tags with invalid id",
+			method:             "GET",
+			path:               "/users/abc/tags",
+			mockBehavior:       func(s *serviceMock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "Id should be number",
+		},
+		{
+			testname: "Get tags of missing user",
+			method:   "GET",
+			path:     "/users/5/tags",
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("GetUserTags", int32(5)).Return(entities.UserTags{}, fmt.Errorf("%w: %w", service.ErrNotFound, repository.ErrUserNotFound))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `"code":"not_found"`,
+		},
+		{
+			testname:  "Add tags",
+			method:    "POST",
+			path:      "/users/1/tags",
+			inputBody: `{"tags":["VIP","newsletter"]}`,
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("AddUserTags", int32(1), []string{"VIP", "newsletter"}).Return(entities.UserTags{UserId: 1, Tags: []string{"newsletter", "vip"}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"user_id":1,"tags":["newsletter","vip"]}`,
+		},
+		{
+			testname:           "Add tags without tags",
+			method:             "POST",
+			path:               "/users/1/tags",
+			inputBody:          `{}`,
+			mockBehavior:       func(s *serviceMock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"code":"invalid_body"`,
+		},
+		{
+			testname:  "Add invalid tag",
+			method:    "POST",
+			path:      "/users/1/tags",
+			inputBody: `{"tags":["vip list"]}`,
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("AddUserTags", int32(1), []string{"vip list"}).Return(entities.UserTags{}, &service.ValidationError{
+					Fields: []service.FieldError{{Field: "tags.0", Code: service.FieldErrInvalidTag, Message: "invalid"}},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"errors":[{"field":"tags.0","code":"invalid_tag"`,
+		},
+		{
+			testname: "Remove tag",
+			method:   "DELETE",
+			path:     "/users/1/tags/vip",
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("RemoveUserTags", int32(1), []string{"vip"}).Return(entities.UserTags{UserId: 1, Tags: []string{"newsletter"}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"user_id":1,"tags":["newsletter"]}`,
+		},
+		{
+			testname: "Remove tag with db error",
+			method:   "DELETE",
+			path:     "/users/1/tags/vip",
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("RemoveUserTags", int32(1), []string{"vip"}).Return(entities.UserTags{}, errors.New("db error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   "Failed to remove user tag",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockUserService := serviceMock.NewMockUserService(t)
+			router := setupTestRouter(mockUserService, nil, nil)
+
+			test.mockBehavior(mockUserService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.path, bytes.NewBufferString(test.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
+
+func TestHandler_bulkTagUsers(t *testing.T) {
+	tests := []struct {
+		testname           string
+		queryStr           string
+		inputBody          string
+		mockBehavior       func(s *serviceMock.MockUserService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			testname:  "Ok",
+			queryStr:  "?nationality=BY&tag=trial",
+			inputBody: `{"add":["campaign_2025"],"remove":["trial"]}`,
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("BulkTagUsers",
+					entities.BulkFilter{UserFilter: entities.UserFilter{Nationalities: []string{"BY"}, Tags: []string{"trial"}, TagMode: entities.TagModeAny}},
+					entities.BulkTagsParams{Add: []string{"campaign_2025"}, Remove: []string{"trial"}},
+					entities.BulkOptions{MaxAffected: maxBulkAffected},
+				).Return(entities.BulkResult{Affected: 3}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"affected":3,"dry_run":false}`,
+		},
+		{
+			testname:           "Empty filter",
+			queryStr:           "",
+			inputBody:          `{"add":["vip"]}`,
+			mockBehavior:       func(s *serviceMock.MockUserService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   "at least one filter or ids must be provided",
+		},
+		{
+			testname:  "Tag added and removed",
+			queryStr:  "?ids=1",
+			inputBody: `{"add":["vip"],"remove":["vip"]}`,
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("BulkTagUsers", mock.Anything, mock.Anything, mock.Anything).Return(entities.BulkResult{}, &service.ValidationError{
+					Fields: []service.FieldError{{Field: "remove.0", Code: service.FieldErrTagAddedAndRemoved, Message: "cant be added and removed at once"}},
+				})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"code":"tag_added_and_removed"`,
+		},
+		{
+			testname:  "Limit exceeded",
+			queryStr:  "?gender=male",
+			inputBody: `{"add":["vip"]}`,
+			mockBehavior: func(s *serviceMock.MockUserService) {
+				s.On("BulkTagUsers", mock.Anything, mock.Anything, mock.Anything).Return(entities.BulkResult{}, repository.ErrBulkLimitExceeded)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `"code":"bulk_limit_exceeded"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			mockUserService := serviceMock.NewMockUserService(t)
+			router := setupTestRouter(mockUserService, nil, nil)
+
+			test.mockBehavior(mockUserService)
+
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/users/tags"+test.queryStr, bytes.NewBufferString(test.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.expectedStatusCode, resp.Code)
+			assert.Contains(t, resp.Body.String(), test.expectedResponse)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Package namenorm normalizes names before they are saved. Display form is what users see,
+// key is used to compare names, so "ivan", "IVAN" and " Ivan " are the same name
+package namenorm
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ё is often written as е, typographic apostrophe as ascii one
+var keyFolding = strings.NewReplacer("ё", "е", "’", "'")
+
+// Display trims s, collapses inner whitespace to one space and composes it to NFC.
+// Names written in one case ("ivan", "IVAN") are turned to title case: "Ivan", "Anna-Maria", "O'Neil".
+// Names in mixed case ("McDonald", "van der Berg") are kept as typed, letters of scripts without case are never changed
+func Display(s string) string {
+	s = collapse(s)
+	if isMixedCase(s) {
+		return s
+	}
+	return titleCase(s)
+}
+
+// Key returns lower case key of a name for equality checks, keys of "Пётр", "ПЕТР" and " петр " are the same.
+// Unlike translit.SearchKey, key is not transliterated, so "Ivan" and "Иван" are different names
+func Key(s string) string {
+	return keyFolding.Replace(strings.ToLower(collapse(s)))
+}
+
+func collapse(s string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(s)), " ")
+}
+
+func isMixedCase(s string) bool {
+	hasUpper, hasLower := false, false
+	for _, r := range s {
+		hasUpper = hasUpper || unicode.IsUpper(r) || unicode.IsTitle(r)
+		hasLower = hasLower || unicode.IsLower(r)
+	}
+	return hasUpper && hasLower
+}
+
+// titleCase capitalizes the first letter of every part, parts are separated by anything but letters and combining marks
+func titleCase(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	afterLetter := false
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r):
+			if afterLetter {
+				b.WriteRune(unicode.ToLower(r))
+			} else {
+				b.WriteRune(unicode.ToTitle(r))
+			}
+			afterLetter = true
+		case unicode.Is(unicode.Mn, r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+			afterLetter = false
+		}
+	}
+	return b.String()
+}
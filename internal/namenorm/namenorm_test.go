@@ -0,0 +1,52 @@
+package namenorm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplay(t *testing.T) {
+	tests := []struct {
+		testname string
+		name     string
+		expected string
+	}{
+		{testname: "Lower case", name: "ivan", expected: "Ivan"},
+		{testname: "Upper case", name: "IVAN", expected: "Ivan"},
+		{testname: "Spaces around", name: " Ivan ", expected: "Ivan"},
+		{testname: "Spaces inside", name: "anna \t maria", expected: "Anna Maria"},
+		{testname: "Separators", name: "ANNA-MARIA O'NEIL", expected: "Anna-Maria O'Neil"},
+		{testname: "Cyrillic", name: "римский-корсаков", expected: "Римский-Корсаков"},
+		{testname: "Decomposed diacritics", name: "zoe\u0308", expected: "Zoë"},
+		{testname: "Mixed case is kept", name: "McDonald", expected: "McDonald"},
+		{testname: "Lower case part is kept", name: "van der Berg", expected: "van der Berg"},
+		{testname: "Script without case", name: " 王小明 ", expected: "王小明"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			assert.Equal(t, test.expected, Display(test.name))
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		testname string
+		a, b     string
+		equal    bool
+	}{
+		{testname: "Case and spaces", a: "ivan", b: " IVAN ", equal: true},
+		{testname: "ё and е", a: "Пётр", b: "ПЕТР", equal: true},
+		{testname: "Apostrophes", a: "D’Angelo", b: "d'angelo", equal: true},
+		{testname: "Composed and decomposed", a: "Zoë", b: "zoe\u0308", equal: true},
+		{testname: "Different scripts", a: "Ivan", b: "Иван", equal: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			assert.Equal(t, test.equal, Key(test.a) == Key(test.b))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Util787/user-manager-api/internal/config"
+)
+
+// sinks of NOTIFIER config
+const (
+	SinkLog  = "log"
+	SinkFile = "file"
+)
+
+// Message is a notification to one email or phone
+type Message struct {
+	// entities.ContactKindEmail or entities.ContactKindPhone
+	Channel string `json:"channel"`
+	To      string `json:"to"`
+	Text    string `json:"text"`
+}
+
+// Notifier delivers messages to users, email and sms providers should implement it.
+// Log and file notifiers are meant for local use, they dont deliver anything
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New creates notifier of the configured sink
+func New(cfg config.NotifierConfig, log *slog.Logger) (Notifier, error) {
+	switch cfg.Sink {
+	case SinkLog:
+		return NewLogNotifier(log), nil
+	case SinkFile:
+		return NewFileNotifier(cfg.File), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier sink %q, must be log or file", cfg.Sink)
+	}
+}
+
+type logNotifier struct {
+	log *slog.Logger
+}
+
+// NewLogNotifier writes messages to the log with info level
+func NewLogNotifier(log *slog.Logger) Notifier {
+	return &logNotifier{log: log}
+}
+
+func (n *logNotifier) Send(ctx context.Context, msg Message) error {
+	n.log.Info("Notification", slog.String("channel", msg.Channel), slog.String("to", msg.To), slog.String("text", msg.Text))
+	return nil
+}
+
+type fileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileNotifier appends messages to the file as json lines, the file is created on the first message
+func NewFileNotifier(path string) Notifier {
+	return &fileNotifier{path: path}
+}
+
+func (n *fileNotifier) Send(ctx context.Context, msg Message) error {
+	line, err := json.Marshal(struct {
+		Time time.Time `json:"time"`
+		Message
+	}{Time: time.Now(), Message: msg})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.log")
+	n := NewFileNotifier(path)
+
+	require.NoError(t, n.Send(context.Background(), Message{Channel: "email", To: "ivan@example.com", Text: "code 123456"}))
+	require.NoError(t, n.Send(context.Background(), Message{Channel: "phone", To: "+79991234567", Text: "code 654321"}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var msg Message
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &msg))
+	assert.Equal(t, Message{Channel: "phone", To: "+79991234567", Text: "code 654321"}, msg)
+}
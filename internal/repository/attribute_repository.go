@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrAttributeExists   = errors.New("attribute already exists")
+	ErrAttributeNotFound = errors.New("attribute not found")
+)
+
+type attributeRepository struct {
+	db *sqlx.DB
+}
+
+func NewAttributeRepository(db *sqlx.DB) AttributeRepository {
+	return &attributeRepository{db: db}
+}
+
+// CreateDefinition returns ErrAttributeExists if definition with the same name exists
+func (a *attributeRepository) CreateDefinition(def entities.AttributeDefinition) (entities.AttributeDefinition, error) {
+	def.Created_at = time.Now()
+	def.Updated_at = def.Created_at
+
+	_, err := a.db.NamedExec(`INSERT INTO attribute_definitions (name, type, required, enum, pattern, description, created_at, updated_at)
+		VALUES (:name, :type, :required, :enum, :pattern, :description, :created_at, :updated_at)`, def)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolationCode {
+		return entities.AttributeDefinition{}, ErrAttributeExists
+	}
+	if err != nil {
+		return entities.AttributeDefinition{}, err
+	}
+	return def, nil
+}
+
+// GetDefinitions returns all definitions ordered by name
+func (a *attributeRepository) GetDefinitions() ([]entities.AttributeDefinition, error) {
+	defs := []entities.AttributeDefinition{}
+	err := a.db.Select(&defs, `SELECT * FROM attribute_definitions ORDER BY name`)
+	return defs, err
+}
+
+func (a *attributeRepository) GetDefinition(name string) (entities.AttributeDefinition, error) {
+	var def entities.AttributeDefinition
+	err := a.db.Get(&def, `SELECT * FROM attribute_definitions WHERE name = $1`, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entities.AttributeDefinition{}, ErrAttributeNotFound
+	}
+	return def, err
+}
+
+// UpdateDefinition replaces definition with the same name, values of users are not changed
+func (a *attributeRepository) UpdateDefinition(def entities.AttributeDefinition) (entities.AttributeDefinition, error) {
+	def.Updated_at = time.Now()
+
+	query, args, err := a.db.BindNamed(`UPDATE attribute_definitions SET type = :type, required = :required, enum = :enum,
+		pattern = :pattern, description = :description, updated_at = :updated_at WHERE name = :name RETURNING *`, def)
+	if err != nil {
+		return entities.AttributeDefinition{}, err
+	}
+
+	var result entities.AttributeDefinition
+	err = a.db.Get(&result, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entities.AttributeDefinition{}, ErrAttributeNotFound
+	}
+	return result, err
+}
+
+// DeleteDefinition removes definition and the attribute from all users (soft deleted too) in one transaction
+func (a *attributeRepository) DeleteDefinition(name string) error {
+	tx, err := a.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`DELETE FROM attribute_definitions WHERE name = $1`, name)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAttributeNotFound
+	}
+
+	// updated_at is kept, attribute of a removed definition isnt a change of user data
+	_, err = tx.Exec(`UPDATE users SET attributes = attributes - $1::text WHERE attributes ? $1::text`, name)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrContactExists      = errors.New("contact is already used")
+	ErrContactNotFound    = errors.New("contact not found")
+	ErrInvalidContactKind = errors.New("invalid contact kind")
+)
+
+type contactTable struct {
+	name   string
+	column string
+}
+
+// every kind of contacts has its own table, the value column is selected as value
+var contactTables = map[string]contactTable{
+	entities.ContactKindEmail: {name: "user_emails", column: "email"},
+	entities.ContactKindPhone: {name: "user_phones", column: "phone"},
+}
+
+func (t contactTable) columns() string {
+	return "id, user_id, " + t.column + " AS value, type, is_primary, verified_at, created_at, updated_at"
+}
+
+type contactRepository struct {
+	db *sqlx.DB
+}
+
+func NewContactRepository(db *sqlx.DB) ContactRepository {
+	return &contactRepository{db: db}
+}
+
+func tableOfKind(kind string) (contactTable, error) {
+	table, ok := contactTables[kind]
+	if !ok {
+		return contactTable{}, fmt.Errorf("%w: %s", ErrInvalidContactKind, kind)
+	}
+	return table, nil
+}
+
+// GetContacts returns contacts of live user, primary first and then by id
+func (r *contactRepository) GetContacts(kind string, userId int32) ([]entities.Contact, error) {
+	table, err := tableOfKind(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = lockLiveUser(tx, userId); err != nil {
+		return nil, err
+	}
+
+	contacts := []entities.Contact{}
+	err = tx.Select(&contacts, `SELECT `+table.columns()+` FROM `+table.name+` WHERE user_id = $1 ORDER BY is_primary DESC, id`, userId)
+	if err != nil {
+		return nil, err
+	}
+	return contacts, tx.Commit()
+}
+
+// GetContact returns ErrContactNotFound if the contact doesnt belong to the live user
+func (r *contactRepository) GetContact(kind string, userId, contactId int32) (entities.Contact, error) {
+	table, err := tableOfKind(kind)
+	if err != nil {
+		return entities.Contact{}, err
+	}
+
+	var contact entities.Contact
+	err = r.db.Get(&contact, `SELECT `+table.columns()+` FROM `+table.name+` c
+		WHERE c.id = $1 AND c.user_id = $2 AND EXISTS (SELECT 1 FROM users u WHERE u.id = c.user_id AND u.deleted_at IS NULL)`, contactId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entities.Contact{}, ErrContactNotFound
+	}
+	return contact, err
+}
+
+// CreateContact makes the contact primary if it is asked or the user has no contacts of the kind.
+// ErrContactExists is returned if any user has the same value
+func (r *contactRepository) CreateContact(kind string, contact entities.Contact) (entities.Contact, error) {
+	table, err := tableOfKind(kind)
+	if err != nil {
+		return entities.Contact{}, err
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return entities.Contact{}, err
+	}
+	defer tx.Rollback()
+
+	if err = lockUserContacts(tx, contact.UserId); err != nil {
+		return entities.Contact{}, err
+	}
+
+	now := time.Now()
+	var hasPrimary bool
+	err = tx.Get(&hasPrimary, `SELECT EXISTS (SELECT 1 FROM `+table.name+` WHERE user_id = $1 AND is_primary)`, contact.UserId)
+	if err != nil {
+		return entities.Contact{}, err
+	}
+	if !hasPrimary {
+		contact.Primary = true
+	}
+	if contact.Primary && hasPrimary {
+		if err = unsetPrimaryContact(tx, table, contact.UserId, now); err != nil {
+			return entities.Contact{}, err
+		}
+	}
+
+	var created entities.Contact
+	err = tx.Get(&created, `INSERT INTO `+table.name+` (user_id, `+table.column+`, type, is_primary, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5) RETURNING `+table.columns(), contact.UserId, contact.Value, contact.Type, contact.Primary, now)
+	if err != nil {
+		return entities.Contact{}, mapContactUniqueViolation(err)
+	}
+	return created, tx.Commit()
+}
+
+// UpdateContact changes type and moves primary flag to the contact if params.Primary is true
+func (r *contactRepository) UpdateContact(kind string, userId, contactId int32, params entities.UpdateContactParams) (entities.Contact, error) {
+	table, err := tableOfKind(kind)
+	if err != nil {
+		return entities.Contact{}, err
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return entities.Contact{}, err
+	}
+	defer tx.Rollback()
+
+	if err = lockUserContacts(tx, userId); err != nil {
+		return entities.Contact{}, err
+	}
+
+	now := time.Now()
+	if params.Primary {
+		if err = unsetPrimaryContact(tx, table, userId, now); err != nil {
+			return entities.Contact{}, err
+		}
+	}
+
+	builder := sq.Update(table.name).Set("updated_at", now).Where(sq.Eq{"id": contactId, "user_id": userId}).
+		Suffix("RETURNING " + table.columns()).PlaceholderFormat(sq.Dollar)
+	if params.Type != nil {
+		builder = builder.Set("type", *params.Type)
+	}
+	if params.Primary {
+		builder = builder.Set("is_primary", true)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return entities.Contact{}, err
+	}
+
+	var contact entities.Contact
+	err = tx.Get(&contact, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entities.Contact{}, ErrContactNotFound
+	}
+	if err != nil {
+		return entities.Contact{}, err
+	}
+	return contact, tx.Commit()
+}
+
+// DeleteContact makes the oldest remaining contact of the kind primary if the primary one is deleted
+func (r *contactRepository) DeleteContact(kind string, userId, contactId int32) error {
+	table, err := tableOfKind(kind)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = lockUserContacts(tx, userId); err != nil {
+		return err
+	}
+
+	var wasPrimary bool
+	err = tx.Get(&wasPrimary, `DELETE FROM `+table.name+` WHERE id = $1 AND user_id = $2 RETURNING is_primary`, contactId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrContactNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if wasPrimary {
+		_, err = tx.Exec(`UPDATE `+table.name+` SET is_primary = true, updated_at = $2
+			WHERE id = (SELECT id FROM `+table.name+` WHERE user_id = $1 ORDER BY id LIMIT 1)`, userId, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// MarkContactVerified returns ErrContactNotFound if the contact was deleted or its value isnt the verified one anymore
+func (r *contactRepository) MarkContactVerified(kind string, userId, contactId int32, value string) (entities.Contact, error) {
+	table, err := tableOfKind(kind)
+	if err != nil {
+		return entities.Contact{}, err
+	}
+
+	now := time.Now()
+	var contact entities.Contact
+	err = r.db.Get(&contact, `UPDATE `+table.name+` c SET verified_at = $4, updated_at = $4
+		WHERE c.id = $1 AND c.user_id = $2 AND c.`+table.column+` = $3
+		AND EXISTS (SELECT 1 FROM users u WHERE u.id = c.user_id AND u.deleted_at IS NULL)
+		RETURNING `+table.columns(), contactId, userId, value, now)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entities.Contact{}, ErrContactNotFound
+	}
+	return contact, err
+}
+
+// lockUserContacts serializes changes of contacts of one live user, so only one contact of a kind becomes primary.
+// Unlike FOR UPDATE it doesnt block inserts that reference the user
+func lockUserContacts(tx *sqlx.Tx, userId int32) error {
+	var id int32
+	err := tx.Get(&id, `SELECT id FROM users WHERE id = $1 AND deleted_at IS NULL FOR NO KEY UPDATE`, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrUserNotFound
+	}
+	return err
+}
+
+func unsetPrimaryContact(tx *sqlx.Tx, table contactTable, userId int32, now time.Time) error {
+	_, err := tx.Exec(`UPDATE `+table.name+` SET is_primary = false, updated_at = $2 WHERE user_id = $1 AND is_primary`, userId, now)
+	return err
+}
+
+// moveUserContacts gives target all contacts of source, source contacts stay primary only if target has no contacts of the kind
+func moveUserContacts(tx *sqlx.Tx, targetId, sourceId int32, now time.Time) error {
+	for _, table := range contactTables {
+		_, err := tx.Exec(`UPDATE `+table.name+` SET user_id = $1, updated_at = $3,
+			is_primary = is_primary AND NOT EXISTS (SELECT 1 FROM `+table.name+` WHERE user_id = $1)
+			WHERE user_id = $2`, targetId, sourceId, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// values are unique across users, primary flags cant collide because changes are serialized by lockUserContacts
+func mapContactUniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolationCode {
+		return ErrContactExists
+	}
+	return err
+}
@@ -0,0 +1,346 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrGroupExists         = errors.New("group with the same name already exists in parent group")
+	ErrGroupNotFound       = errors.New("group not found")
+	ErrGroupParentNotFound = errors.New("parent group not found")
+	ErrGroupCycle          = errors.New("group cant be moved into itself or its subgroup")
+	ErrGroupHasSubgroups   = errors.New("group has subgroups")
+	ErrGroupMemberNotFound = errors.New("user is not a member of the group")
+)
+
+const pgForeignKeyViolationCode = "23503"
+
+// roles ordered from the strongest, array_position of a role is its rank
+const groupRolesArray = `ARRAY['owner', 'admin', 'member']`
+
+type groupRepository struct {
+	db *sqlx.DB
+}
+
+func NewGroupRepository(db *sqlx.DB) GroupRepository {
+	return &groupRepository{db: db}
+}
+
+// CreateGroup returns ErrGroupParentNotFound for missing parent and ErrGroupExists if sibling with the same name exists
+func (g *groupRepository) CreateGroup(params entities.CreateGroupParams) (entities.Group, error) {
+	now := time.Now()
+
+	var group entities.Group
+	err := g.db.Get(&group, `INSERT INTO groups (name, description, parent_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4) RETURNING *`, params.Name, params.Description, params.ParentId, now)
+	if err != nil {
+		return entities.Group{}, mapGroupWriteError(err)
+	}
+	return group, nil
+}
+
+// GetGroups returns page of groups ordered by id
+func (g *groupRepository) GetGroups(pageSize, page int, filter entities.GroupFilter) ([]entities.Group, int, error) {
+	var cond sq.Sqlizer = sq.And{}
+	switch {
+	case filter.TopLevel:
+		cond = sq.Expr("parent_id IS NULL")
+	case filter.ParentId != nil:
+		cond = sq.Eq{"parent_id": *filter.ParentId}
+	}
+
+	offset := (page - 1) * pageSize
+	groupsQuery, groupsArgs, err := sq.Select("*").From("groups").Where(cond).OrderBy("id").
+		Limit(uint64(pageSize)).Offset(uint64(offset)).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+	totalCountQuery, totalCountArgs, err := sq.Select("COUNT(*)").From("groups").Where(cond).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tx, err := g.db.Beginx()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback()
+
+	groups := []entities.Group{}
+	err = tx.Select(&groups, groupsQuery, groupsArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var totalCount int
+	err = tx.Get(&totalCount, totalCountQuery, totalCountArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return groups, totalCount, tx.Commit()
+}
+
+func (g *groupRepository) GetGroupById(id int32) (entities.Group, error) {
+	var group entities.Group
+	err := g.db.Get(&group, `SELECT * FROM groups WHERE id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entities.Group{}, ErrGroupNotFound
+	}
+	return group, err
+}
+
+// UpdateGroup changes only provided fields, parent id 0 moves the group to the top level.
+// ErrGroupCycle is returned if the new parent is the group itself or its subgroup
+func (g *groupRepository) UpdateGroup(id int32, params entities.UpdateGroupParams) (entities.Group, error) {
+	builder := sq.Update("groups").Set("updated_at", time.Now()).Where(sq.Eq{"id": id}).Suffix("RETURNING *").PlaceholderFormat(sq.Dollar)
+	if params.Name != nil {
+		builder = builder.Set("name", *params.Name)
+	}
+	if params.Description != nil {
+		builder = builder.Set("description", *params.Description)
+	}
+
+	tx, err := g.db.Beginx()
+	if err != nil {
+		return entities.Group{}, err
+	}
+	defer tx.Rollback()
+
+	if params.ParentId != nil {
+		var parentId *int32
+		if *params.ParentId != 0 {
+			parentId = params.ParentId
+			err = checkGroupCycle(tx, id, *parentId)
+			if err != nil {
+				return entities.Group{}, err
+			}
+		}
+		builder = builder.Set("parent_id", parentId)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return entities.Group{}, err
+	}
+
+	var group entities.Group
+	err = tx.Get(&group, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entities.Group{}, ErrGroupNotFound
+	}
+	if err != nil {
+		return entities.Group{}, mapGroupWriteError(err)
+	}
+
+	return group, tx.Commit()
+}
+
+// checkGroupCycle walks up from the new parent, the group must not be met on the way.
+// Moves are serialized by table lock, otherwise two concurrent moves could make a cycle
+func checkGroupCycle(tx *sqlx.Tx, id, parentId int32) error {
+	if id == parentId {
+		return ErrGroupCycle
+	}
+
+	_, err := tx.Exec(`LOCK TABLE groups IN SHARE ROW EXCLUSIVE MODE`)
+	if err != nil {
+		return err
+	}
+
+	var cycle bool
+	err = tx.Get(&cycle, `WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id FROM groups WHERE id = $1
+			UNION
+			SELECT g.id, g.parent_id FROM groups g JOIN ancestors a ON g.id = a.parent_id
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)`, parentId, id)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return ErrGroupCycle
+	}
+	return nil
+}
+
+// DeleteGroup deletes group with its memberships, ErrGroupHasSubgroups is returned if the group has subgroups
+func (g *groupRepository) DeleteGroup(id int32) error {
+	res, err := g.db.Exec(`DELETE FROM groups WHERE id = $1`, id)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolationCode {
+		return ErrGroupHasSubgroups
+	}
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrGroupNotFound
+	}
+	return nil
+}
+
+// GetGroupMembers returns memberships of live users ordered by group and user.
+// With recursive members of all subgroups are returned too, a user can be listed once per group
+func (g *groupRepository) GetGroupMembers(groupId int32, recursive bool) ([]entities.GroupMember, error) {
+	tx, err := g.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = lockGroup(tx, groupId); err != nil {
+		return nil, err
+	}
+
+	groupsQuery := `SELECT $1::int AS id`
+	if recursive {
+		groupsQuery = `WITH RECURSIVE subgroups AS (
+				SELECT $1::int AS id
+				UNION
+				SELECT g.id FROM groups g JOIN subgroups s ON g.parent_id = s.id
+			)
+			SELECT id FROM subgroups`
+	}
+
+	members := []entities.GroupMember{}
+	err = tx.Select(&members, `SELECT gm.* FROM group_members gm JOIN users u ON u.id = gm.user_id
+		WHERE u.deleted_at IS NULL AND gm.group_id IN (`+groupsQuery+`)
+		ORDER BY gm.group_id, gm.user_id`, groupId)
+	if err != nil {
+		return nil, err
+	}
+	return members, tx.Commit()
+}
+
+// SetGroupMember adds user to the group or changes role of existing member.
+// ErrUserNotFound is returned for missing and deleted users
+func (g *groupRepository) SetGroupMember(groupId, userId int32, role string) (entities.GroupMember, error) {
+	tx, err := g.db.Beginx()
+	if err != nil {
+		return entities.GroupMember{}, err
+	}
+	defer tx.Rollback()
+
+	if err = lockGroup(tx, groupId); err != nil {
+		return entities.GroupMember{}, err
+	}
+	if err = lockLiveUser(tx, userId); err != nil {
+		return entities.GroupMember{}, err
+	}
+
+	var member entities.GroupMember
+	err = tx.Get(&member, `INSERT INTO group_members (group_id, user_id, role, created_at, updated_at) VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (group_id, user_id) DO UPDATE SET role = EXCLUDED.role, updated_at = EXCLUDED.updated_at
+		RETURNING *`, groupId, userId, role, time.Now())
+	if err != nil {
+		return entities.GroupMember{}, err
+	}
+	return member, tx.Commit()
+}
+
+// RemoveGroupMember returns ErrGroupMemberNotFound if the user isnt a direct member of the group
+func (g *groupRepository) RemoveGroupMember(groupId, userId int32) error {
+	res, err := g.db.Exec(`DELETE FROM group_members WHERE group_id = $1 AND user_id = $2`, groupId, userId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrGroupMemberNotFound
+	}
+	return nil
+}
+
+// GetUserGroups returns groups of live user ordered by id, ErrUserNotFound is returned for missing and deleted users.
+// With recursive ancestors of direct groups are returned too, every group is listed once with the role of the nearest membership
+func (g *groupRepository) GetUserGroups(userId int32, recursive bool) ([]entities.UserGroup, error) {
+	tx, err := g.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = lockLiveUser(tx, userId); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT g.*, gm.role, false AS inherited FROM group_members gm JOIN groups g ON g.id = gm.group_id
+		WHERE gm.user_id = $1 ORDER BY g.id`
+	if recursive {
+		query = `WITH RECURSIVE user_groups AS (
+				SELECT g.id, g.parent_id, gm.role, 0 AS depth FROM group_members gm JOIN groups g ON g.id = gm.group_id
+				WHERE gm.user_id = $1
+				UNION ALL
+				SELECT p.id, p.parent_id, ug.role, ug.depth + 1 FROM groups p JOIN user_groups ug ON p.id = ug.parent_id
+			)
+			SELECT g.*, ug.role, ug.depth > 0 AS inherited FROM (
+				SELECT DISTINCT ON (id) id, role, depth FROM user_groups
+				ORDER BY id, depth, array_position(` + groupRolesArray + `, role)
+			) ug JOIN groups g ON g.id = ug.id
+			ORDER BY g.id`
+	}
+
+	groups := []entities.UserGroup{}
+	err = tx.Select(&groups, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	return groups, tx.Commit()
+}
+
+// lockGroup keeps group from being deleted until the end of transaction
+func lockGroup(tx *sqlx.Tx, groupId int32) error {
+	var id int32
+	err := tx.Get(&id, `SELECT id FROM groups WHERE id = $1 FOR SHARE`, groupId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrGroupNotFound
+	}
+	return err
+}
+
+// moveGroupMembers gives target all memberships of source, in groups they share target keeps the stronger role
+func moveGroupMembers(tx *sqlx.Tx, targetId, sourceId int32, now time.Time) error {
+	_, err := tx.Exec(`INSERT INTO group_members (group_id, user_id, role, created_at, updated_at)
+		SELECT group_id, $1, role, created_at, $3 FROM group_members WHERE user_id = $2
+		ON CONFLICT (group_id, user_id) DO UPDATE SET role = EXCLUDED.role, updated_at = EXCLUDED.updated_at
+		WHERE array_position(`+groupRolesArray+`, EXCLUDED.role) < array_position(`+groupRolesArray+`, group_members.role)`,
+		targetId, sourceId, now)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`DELETE FROM group_members WHERE user_id = $1`, sourceId)
+	return err
+}
+
+// mapGroupWriteError turns violations of sibling names index and parent reference into repository errors
+func mapGroupWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch pgErr.Code {
+	case pgUniqueViolationCode:
+		return ErrGroupExists
+	case pgForeignKeyViolationCode:
+		return ErrGroupParentNotFound
+	default:
+		return err
+	}
+}
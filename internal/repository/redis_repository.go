@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -10,6 +11,9 @@ import (
 
 const redisTTL = 15 * time.Second
 
+// ErrCacheMiss is returned by Get if there is no value for the key
+var ErrCacheMiss = errors.New("cache miss")
+
 type redisRepository struct {
 	redis *redis.Client
 }
@@ -28,6 +32,9 @@ func (r *redisRepository) Set(ctx context.Context, key string, value any) error
 
 func (r *redisRepository) Get(ctx context.Context, key string, dest any) error {
 	str, err := r.redis.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return ErrCacheMiss
+	}
 	if err != nil {
 		return err
 	}
@@ -38,3 +45,54 @@ func (r *redisRepository) Get(ctx context.Context, key string, dest any) error {
 func (r *redisRepository) Delete(ctx context.Context, key string) error {
 	return r.redis.Del(ctx, key).Err()
 }
+
+// MGet returns raw json values in the same order as keys, nil for missing keys
+func (r *redisRepository) MGet(ctx context.Context, keys []string) ([][]byte, error) {
+	values, err := r.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]byte, len(keys))
+	for i, value := range values {
+		if str, ok := value.(string); ok {
+			result[i] = []byte(str)
+		}
+	}
+	return result, nil
+}
+
+// SetMany sets all values in one round trip, MSET cant set ttl so pipeline is used
+func (r *redisRepository) SetMany(ctx context.Context, values map[string]any) error {
+	pipe := r.redis.Pipeline()
+	for key, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, key, data, redisTTL)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *redisRepository) Incr(ctx context.Context, key string) (int64, error) {
+	return r.redis.Incr(ctx, key).Result()
+}
+
+func (r *redisRepository) SetWithTTL(ctx context.Context, key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return r.redis.Set(ctx, key, data, ttl).Err()
+}
+
+func (r *redisRepository) SetNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	return r.redis.SetNX(ctx, key, data, ttl).Result()
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/Util787/user-manager-api/entities"
 	"github.com/jmoiron/sqlx"
@@ -9,33 +10,116 @@ import (
 )
 
 type UserRepository interface {
-	GetAllUsers(pageSize, page int, name, surname, patronymic, gender string) (users []entities.User, totalCount int,err error)
+	GetAllUsers(pageSize, page int, filter entities.UserFilter, sort []entities.SortField, fields []string) (users []entities.User, totalCount int,err error)
+	GetUsersByCursor(filter entities.UserFilter, sort []entities.SortField, cursor *entities.Cursor, limit int, fields []string) ([]entities.User, error)
+	CountUsers(filter entities.UserFilter) (int, error)
 	CreateUser(params entities.User) (entities.User, error)
-	ExistByFullName(params entities.FullName) (bool, error)
-	ExistById(id int32) (bool, error)
-	GetUserById(id int32) (entities.User, error)
+	GetUserById(id int32, fields []string) (entities.User, error)
+	GetUsersByIds(ids []int32) ([]entities.User, error)
 	UpdateUser(id int32, params entities.UpdateUserParams) error
+	// patch gets live user locked in transaction and returns fields to save
+	PatchUser(id int32, patch func(user entities.User) (entities.UpdateUserParams, error)) (entities.User, error)
 	DeleteUser(id int32) error
+	BulkUpdateUsers(filter entities.BulkFilter, params entities.UpdateUserParams, opts entities.BulkOptions) (entities.BulkResult, error)
+	BulkDeleteUsers(filter entities.BulkFilter, hard bool, opts entities.BulkOptions) (entities.BulkResult, error)
+	StreamUsers(ctx context.Context, filter entities.UserFilter, fn func(user entities.User) error) error
+	SearchUsers(query string, threshold float64, limit int) ([]entities.UserSearchResult, error)
+	// returns number of updated users, it is not 0 on error if some batches were already committed
+	ReindexSearchKeys(ctx context.Context, batchSize int) (int, error)
+	GetUserStats(ctx context.Context, filter entities.UserFilter, opts entities.StatsOptions) (entities.UserStats, error)
+	// userId is 0 to find all duplicates
+	FindDuplicates(threshold float64, limit int, userId int32) ([]entities.DuplicateCandidate, error)
+	// merge gets both users locked in transaction and returns values to save to target
+	MergeUsers(targetId, sourceId int32, merge func(target, source entities.User) entities.User) (entities.User, error)
+	// tags of live users ordered by name, ErrUserNotFound is returned for missing and deleted users
+	GetUserTags(userId int32) ([]string, error)
+	// tags must be normalized, missing tags are created. Returns all tags of the user after the change
+	AddUserTags(userId int32, tags []string) ([]string, error)
+	RemoveUserTags(userId int32, tags []string) ([]string, error)
+	// affected is the number of matched users, ErrBulkLimitExceeded is returned if there are more than opts.MaxAffected
+	BulkTagUsers(filter entities.BulkFilter, add, remove []string, opts entities.BulkOptions) (entities.BulkResult, error)
+	// scope is FullNameUniqueWithPatronymic, FullNameUniqueAll or FullNameUniqueOff
+	SetFullNameUniqueness(ctx context.Context, scope string) error
+}
+
+// AttributeRepository stores definitions of custom user attributes
+type AttributeRepository interface {
+	// ErrAttributeExists is returned if definition with the same name exists
+	CreateDefinition(def entities.AttributeDefinition) (entities.AttributeDefinition, error)
+	GetDefinitions() ([]entities.AttributeDefinition, error)
+	GetDefinition(name string) (entities.AttributeDefinition, error)
+	UpdateDefinition(def entities.AttributeDefinition) (entities.AttributeDefinition, error)
+	// attribute is removed from all users too
+	DeleteDefinition(name string) error
+}
+
+// GroupRepository stores the tree of groups and memberships of users in them
+type GroupRepository interface {
+	// ErrGroupParentNotFound is returned for missing parent, ErrGroupExists if sibling with the same name exists
+	CreateGroup(params entities.CreateGroupParams) (entities.Group, error)
+	GetGroups(pageSize, page int, filter entities.GroupFilter) (groups []entities.Group, totalCount int, err error)
+	GetGroupById(id int32) (entities.Group, error)
+	// parent id 0 moves the group to the top level, ErrGroupCycle is returned if the new parent is the group itself or its subgroup
+	UpdateGroup(id int32, params entities.UpdateGroupParams) (entities.Group, error)
+	// memberships are deleted with the group, ErrGroupHasSubgroups is returned if the group has subgroups
+	DeleteGroup(id int32) error
+	// memberships of live users, recursive includes members of all subgroups
+	GetGroupMembers(groupId int32, recursive bool) ([]entities.GroupMember, error)
+	// adds member or changes its role, ErrUserNotFound is returned for missing and deleted users
+	SetGroupMember(groupId, userId int32, role string) (entities.GroupMember, error)
+	RemoveGroupMember(groupId, userId int32) error
+	// recursive includes ancestors of groups the user is a direct member of
+	GetUserGroups(userId int32, recursive bool) ([]entities.UserGroup, error)
+}
+
+// ContactRepository stores emails and phones of users, kind is entities.ContactKindEmail or entities.ContactKindPhone
+type ContactRepository interface {
+	// contacts of live user, primary first. ErrUserNotFound is returned for missing and deleted users
+	GetContacts(kind string, userId int32) ([]entities.Contact, error)
+	// ErrContactNotFound is returned if the contact doesnt belong to the live user
+	GetContact(kind string, userId, contactId int32) (entities.Contact, error)
+	// the first contact of the kind becomes primary, ErrContactExists is returned if any user has the same value
+	CreateContact(kind string, contact entities.Contact) (entities.Contact, error)
+	UpdateContact(kind string, userId, contactId int32, params entities.UpdateContactParams) (entities.Contact, error)
+	// the oldest remaining contact becomes primary if the primary one is deleted
+	DeleteContact(kind string, userId, contactId int32) error
+	// value must be the value the code was sent to, ErrContactNotFound is returned if it was changed
+	MarkContactVerified(kind string, userId, contactId int32, value string) (entities.Contact, error)
 }
 
 type RedisRepository interface {
 	Set(ctx context.Context, key string, value any) error
 
-	// Use reference for dest, otherwise you'll get an empty struct
+	// Use reference for dest, otherwise you'll get an empty struct. ErrCacheMiss is returned for missing keys
 	//
 	// Example: Get(context.Background(),"key",&user)
 	Get(ctx context.Context, key string, dest any) error
 	Delete(ctx context.Context, key string) error
+	// json values in the same order as keys, nil for missing keys
+	MGet(ctx context.Context, keys []string) ([][]byte, error)
+	SetMany(ctx context.Context, values map[string]any) error
+	// increments integer value of key without ttl, missing key is counted from 0
+	Incr(ctx context.Context, key string) (int64, error)
+	// like Set but with own ttl
+	SetWithTTL(ctx context.Context, key string, value any, ttl time.Duration) error
+	// sets value only if key doesnt exist, returns false if it exists
+	SetNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error)
 }
 
 type Repository struct {
-	UserRepository  UserRepository
-	RedisRepository RedisRepository
+	UserRepository      UserRepository
+	RedisRepository     RedisRepository
+	AttributeRepository AttributeRepository
+	GroupRepository     GroupRepository
+	ContactRepository   ContactRepository
 }
 
 func NewRepository(db *sqlx.DB, redis *redis.Client) *Repository {
 	return &Repository{
-		UserRepository:  NewUserRepository(db),
-		RedisRepository: NewRedisRepository(redis),
+		UserRepository:      NewUserRepository(db),
+		RedisRepository:     NewRedisRepository(redis),
+		AttributeRepository: NewAttributeRepository(db),
+		GroupRepository:     NewGroupRepository(db),
+		ContactRepository:   NewContactRepository(db),
 	}
 }
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+// how many affected rows are returned in dry run mode
+const bulkSampleSize = 5
+
+func (u *userRepository) BulkUpdateUsers(filter entities.BulkFilter, params entities.UpdateUserParams, opts entities.BulkOptions) (entities.BulkResult, error) {
+	cond, err := bulkFilterCond(filter, false)
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+
+	tx, err := u.db.Beginx()
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+	defer tx.Rollback()
+
+	if opts.DryRun {
+		return dryRunBulk(tx, cond)
+	}
+
+	builder := sq.Update("users").Where(cond).Set("updated_at", time.Now()).Suffix("RETURNING id").PlaceholderFormat(sq.Dollar)
+	builder = setUpdateParams(builder, params)
+
+	return execBulk(tx, builder, opts.MaxAffected)
+}
+
+// BulkDeleteUsers sets deleted_at for matched users, hard delete removes rows completely (including already soft deleted ones)
+func (u *userRepository) BulkDeleteUsers(filter entities.BulkFilter, hard bool, opts entities.BulkOptions) (entities.BulkResult, error) {
+	cond, err := bulkFilterCond(filter, hard)
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+
+	tx, err := u.db.Beginx()
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+	defer tx.Rollback()
+
+	if opts.DryRun {
+		return dryRunBulk(tx, cond)
+	}
+
+	var builder sq.Sqlizer
+	if hard {
+		builder = sq.Delete("users").Where(cond).Suffix("RETURNING id").PlaceholderFormat(sq.Dollar)
+	} else {
+		now := time.Now()
+		builder = sq.Update("users").Where(cond).Set("deleted_at", now).Set("updated_at", now).Suffix("RETURNING id").PlaceholderFormat(sq.Dollar)
+	}
+
+	return execBulk(tx, builder, opts.MaxAffected)
+}
+
+func bulkFilterCond(filter entities.BulkFilter, includeDeleted bool) (sq.And, error) {
+	cond := sq.And{}
+
+	if !includeDeleted {
+		cond = append(cond, sq.Expr("deleted_at IS NULL"))
+	}
+	if len(filter.Ids) > 0 {
+		cond = append(cond, sq.Eq{"id": filter.Ids})
+	}
+
+	userCond, err := userFilterCond(filter.UserFilter)
+	if err != nil {
+		return nil, err
+	}
+	return append(cond, userCond...), nil
+}
+
+// count and sample are read in the same transaction, nothing is changed
+func dryRunBulk(tx *sqlx.Tx, cond sq.And) (entities.BulkResult, error) {
+	countQuery, countArgs, err := sq.Select("COUNT(*)").From("users").Where(cond).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+
+	sampleQuery, sampleArgs, err := sq.Select("*").From("users").Where(cond).OrderBy("id").Limit(bulkSampleSize).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+
+	result := entities.BulkResult{DryRun: true}
+
+	err = tx.Get(&result.Affected, countQuery, countArgs...)
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+
+	err = tx.Select(&result.Sample, sampleQuery, sampleArgs...)
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+
+	return result, nil
+}
+
+// changes are committed only if the number of affected rows doesnt exceed maxAffected.
+// builder must return ids of affected rows
+func execBulk(tx *sqlx.Tx, builder sq.Sqlizer, maxAffected int) (entities.BulkResult, error) {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+
+	var ids []int32
+	err = tx.Select(&ids, query, args...)
+	if err != nil {
+		return entities.BulkResult{}, mapUniqueViolation(err)
+	}
+
+	if maxAffected > 0 && len(ids) > maxAffected {
+		return entities.BulkResult{}, ErrBulkLimitExceeded
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+
+	return entities.BulkResult{Affected: len(ids), Ids: ids}, nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/namenorm"
+	"github.com/Util787/user-manager-api/internal/translit"
+)
+
+// FindDuplicates returns pairs of live users with similar surnames ordered by score from 0 to 1.
+// Score is weighted similarity of search keys of name parts plus equality of enrichment data:
+// surname 0.4, name 0.3, patronymic 0.1, nationality 0.1, gender 0.05 and age ±1 year 0.05.
+// Pairs are only taken from users with surname keys similar by pg_trgm % operator, so trigram index is used for the join.
+// If userId isnt 0 only pairs with this user are returned
+func (u *userRepository) FindDuplicates(threshold float64, limit int, userId int32) ([]entities.DuplicateCandidate, error) {
+	query := `WITH pairs AS (
+		SELECT a.id AS first_id, b.id AS second_id, (
+			0.4 * similarity(a.surname_key, b.surname_key)
+			+ 0.3 * similarity(a.name_key, b.name_key)
+			+ 0.1 * CASE WHEN a.patronymic_key = b.patronymic_key THEN 1 ELSE similarity(a.patronymic_key, b.patronymic_key) END
+			+ 0.1 * (a.nationality = b.nationality)::int
+			+ 0.05 * (a.gender = b.gender)::int
+			+ 0.05 * (abs(a.age - b.age) <= 1)::int
+		)::float8 AS score
+		FROM users a JOIN users b ON a.id < b.id AND a.surname_key % b.surname_key
+		WHERE a.deleted_at IS NULL AND b.deleted_at IS NULL AND ($3 = 0 OR a.id = $3 OR b.id = $3)
+	)
+	SELECT first_id, second_id, score FROM pairs WHERE score >= $1 ORDER BY score DESC, first_id, second_id LIMIT $2`
+
+	var pairs []struct {
+		FirstId  int32   `db:"first_id"`
+		SecondId int32   `db:"second_id"`
+		Score    float64 `db:"score"`
+	}
+	err := u.db.Select(&pairs, query, threshold, limit, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []entities.DuplicateCandidate{}
+	if len(pairs) == 0 {
+		return candidates, nil
+	}
+
+	ids := make([]int32, 0, len(pairs)*2)
+	for _, pair := range pairs {
+		ids = append(ids, pair.FirstId, pair.SecondId)
+	}
+	users, err := u.GetUsersByIds(ids)
+	if err != nil {
+		return nil, err
+	}
+	usersById := make(map[int32]entities.User, len(users))
+	for _, user := range users {
+		usersById[user.Id] = user
+	}
+
+	for _, pair := range pairs {
+		first, ok1 := usersById[pair.FirstId]
+		second, ok2 := usersById[pair.SecondId]
+		// deleted between the queries
+		if !ok1 || !ok2 {
+			continue
+		}
+		candidates = append(candidates, entities.DuplicateCandidate{First: first, Second: second, Score: pair.Score})
+	}
+	return candidates, nil
+}
+
+// MergeUsers locks both users, saves fields returned by merge to target, soft deletes source and
+// writes both users as they were before to user_merges, all in one transaction.
+// ErrUserNotFound is returned if any of the users doesnt exist or is deleted
+func (u *userRepository) MergeUsers(targetId, sourceId int32, merge func(target, source entities.User) entities.User) (entities.User, error) {
+	tx, err := u.db.Beginx()
+	if err != nil {
+		return entities.User{}, err
+	}
+	defer tx.Rollback()
+
+	// ordered by id so concurrent merges of the same users dont deadlock
+	var users []entities.User
+	err = tx.Select(&users, `SELECT * FROM users WHERE id = ANY($1) AND deleted_at IS NULL ORDER BY id FOR UPDATE`, []int32{targetId, sourceId})
+	if err != nil {
+		return entities.User{}, err
+	}
+	if len(users) != 2 {
+		return entities.User{}, ErrUserNotFound
+	}
+	target, source := users[0], users[1]
+	if target.Id != targetId {
+		target, source = source, target
+	}
+
+	merged := merge(target, source)
+	now := time.Now()
+
+	// source is deleted first, so target can take its full name without violating unique index
+	_, err = tx.Exec(`UPDATE users SET deleted_at = $1, updated_at = $1 WHERE id = $2`, now, sourceId)
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	var result entities.User
+	err = tx.Get(&result, `UPDATE users SET name = $1, surname = $2, patronymic = $3, age = $4, gender = $5, nationality = $6,
+		name_key = $7, surname_key = $8, patronymic_key = $9, name_norm = $10, surname_norm = $11, patronymic_norm = $12,
+		attributes = $13, updated_at = $14 WHERE id = $15 RETURNING *`,
+		merged.Name, merged.Surname, merged.Patronymic, merged.Age, merged.Gender, merged.Nationality,
+		translit.SearchKey(merged.Name), translit.SearchKey(merged.Surname), translit.SearchKey(merged.Patronymic),
+		namenorm.Key(merged.Name), namenorm.Key(merged.Surname), namenorm.Key(merged.Patronymic), merged.Attributes, now, targetId)
+	if err != nil {
+		return entities.User{}, mapUniqueViolation(err)
+	}
+
+	targetBefore, err := json.Marshal(target)
+	if err != nil {
+		return entities.User{}, err
+	}
+	sourceBefore, err := json.Marshal(source)
+	if err != nil {
+		return entities.User{}, err
+	}
+	_, err = tx.Exec(`INSERT INTO user_merges (target_id, source_id, target_before, source_before, merged_at) VALUES ($1, $2, $3, $4, $5)`,
+		targetId, sourceId, string(targetBefore), string(sourceBefore), now)
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	// rows of other tables that reference users must be moved from source to target here
+	err = moveUserTags(tx, targetId, sourceId)
+	if err != nil {
+		return entities.User{}, err
+	}
+	err = moveGroupMembers(tx, targetId, sourceId, now)
+	if err != nil {
+		return entities.User{}, err
+	}
+	err = moveUserContacts(tx, targetId, sourceId, now)
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	return result, nil
+}
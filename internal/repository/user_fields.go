@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrInvalidField = errors.New("invalid field")
+
+// columns that can be requested in sparse fieldsets, json names of user fields are the same
+var userSelectableColumns = []string{"id", "created_at", "updated_at", "name", "surname", "patronymic", "age", "gender", "nationality", "attributes"}
+
+func IsSelectableUserColumn(column string) bool {
+	return slices.Contains(userSelectableColumns, column)
+}
+
+// userSelectColumns returns columns for SELECT, all columns if fields are empty.
+// required columns (like sort keys needed for cursors) are added if they are not in fields
+func userSelectColumns(fields []string, required ...string) ([]string, error) {
+	if len(fields) == 0 {
+		return []string{"*"}, nil
+	}
+
+	columns := make([]string, 0, len(fields)+len(required))
+	for _, field := range fields {
+		if !IsSelectableUserColumn(field) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidField, field)
+		}
+		if !slices.Contains(columns, field) {
+			columns = append(columns, field)
+		}
+	}
+	for _, column := range required {
+		if !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns, nil
+}
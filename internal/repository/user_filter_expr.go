@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/translit"
+)
+
+var ErrInvalidFilterExpr = errors.New("invalid filter expression")
+
+// kinds of values of filter expression fields
+const (
+	FilterKindInt    = "int"
+	FilterKindString = "string"
+	FilterKindTime   = "time"
+)
+
+type filterField struct {
+	column string
+	kind   string
+	// name parts are compared by search keys, see translit.SearchKey
+	keyColumn string
+}
+
+// whitelist of fields that can be used in filter expression
+var userFilterFields = map[string]filterField{
+	"id":          {column: "id", kind: FilterKindInt},
+	"age":         {column: "age", kind: FilterKindInt},
+	"name":        {column: "name", kind: FilterKindString, keyColumn: "name_key"},
+	"surname":     {column: "surname", kind: FilterKindString, keyColumn: "surname_key"},
+	"patronymic":  {column: "patronymic", kind: FilterKindString, keyColumn: "patronymic_key"},
+	"gender":      {column: "gender", kind: FilterKindString},
+	"nationality": {column: "nationality", kind: FilterKindString},
+	"created_at":  {column: "created_at", kind: FilterKindTime},
+	"updated_at":  {column: "updated_at", kind: FilterKindTime},
+}
+
+// UserFilterFieldKind returns kind of field value, false if field cant be used in filter expression
+func UserFilterFieldKind(field string) (string, bool) {
+	f, ok := userFilterFields[field]
+	return f.kind, ok
+}
+
+// filterExprCond compiles parsed filter expression into a predicate, values are always passed as arguments
+func filterExprCond(expr entities.FilterExpr) (sq.Sqlizer, error) {
+	switch e := expr.(type) {
+	case entities.FilterLogical:
+		left, err := filterExprCond(e.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := filterExprCond(e.Right)
+		if err != nil {
+			return nil, err
+		}
+		if e.Op == entities.FilterOr {
+			return sq.Or{left, right}, nil
+		}
+		return sq.And{left, right}, nil
+	case entities.FilterNot:
+		inner, err := filterExprCond(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return sq.Expr("NOT (?)", inner), nil
+	case entities.FilterComparison:
+		return comparisonCond(e)
+	default:
+		return nil, fmt.Errorf("%w: unknown node %T", ErrInvalidFilterExpr, expr)
+	}
+}
+
+func comparisonCond(cmp entities.FilterComparison) (sq.Sqlizer, error) {
+	field, ok := userFilterFields[cmp.Field]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown field %s", ErrInvalidFilterExpr, cmp.Field)
+	}
+
+	column, value := field.column, cmp.Value
+	if field.keyColumn != "" {
+		column, value = field.keyColumn, searchKeyValue(value)
+	}
+
+	switch cmp.Op {
+	case entities.FilterEq, entities.FilterIn:
+		return sq.Eq{column: value}, nil
+	case entities.FilterNotEq, entities.FilterNotIn:
+		return sq.NotEq{column: value}, nil
+	case entities.FilterGt:
+		return sq.Gt{column: value}, nil
+	case entities.FilterGtOrEq:
+		return sq.GtOrEq{column: value}, nil
+	case entities.FilterLt:
+		return sq.Lt{column: value}, nil
+	case entities.FilterLtOrEq:
+		return sq.LtOrEq{column: value}, nil
+	case entities.FilterContains:
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s works only with strings", ErrInvalidFilterExpr, cmp.Op)
+		}
+		return sq.ILike{column: "%" + likeEscaper.Replace(str) + "%"}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown operator %s", ErrInvalidFilterExpr, cmp.Op)
+	}
+}
+
+func searchKeyValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		return translit.SearchKey(v)
+	case []any:
+		keys := make([]any, len(v))
+		for i, item := range v {
+			keys[i] = searchKeyValue(item)
+		}
+		return keys
+	default:
+		return value
+	}
+}
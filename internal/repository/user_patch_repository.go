@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Util787/user-manager-api/entities"
+)
+
+// PatchUser locks live user, saves fields returned by patch and returns updated user, all in one transaction,
+// so concurrent changes can't slip in between reading the user and writing the patched one.
+// ErrUserNotFound is returned if user doesnt exist or is deleted, errors of patch are returned as is
+func (u *userRepository) PatchUser(id int32, patch func(user entities.User) (entities.UpdateUserParams, error)) (entities.User, error) {
+	tx, err := u.db.Beginx()
+	if err != nil {
+		return entities.User{}, err
+	}
+	defer tx.Rollback()
+
+	var user entities.User
+	err = tx.Get(&user, `SELECT * FROM users WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entities.User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	params, err := patch(user)
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	builder := sq.Update("users").Where(sq.Eq{"id": id}).Set("updated_at", time.Now()).PlaceholderFormat(sq.Dollar)
+	query, args, err := setUpdateParams(builder, params).Suffix("RETURNING *").ToSql()
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	var result entities.User
+	err = tx.Get(&result, query, args...)
+	if err != nil {
+		return entities.User{}, mapUniqueViolation(err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	return result, nil
+}
@@ -1,17 +1,26 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/namenorm"
+	"github.com/Util787/user-manager-api/internal/translit"
 	"github.com/jmoiron/sqlx"
 )
 
 var (
 	ErrUserExists   = errors.New("user already exists")
 	ErrUserNotFound = errors.New("user not found")
+
+	ErrBulkLimitExceeded = errors.New("bulk operation exceeds max affected rows")
 )
 
 type userRepository struct {
@@ -22,33 +31,27 @@ func NewUserRepository(db *sqlx.DB) UserRepository {
 	return &userRepository{db: db}
 }
 
-func (u *userRepository) GetAllUsers(pageSize, page int, name, surname, patronymic, gender string) ([]entities.User, int, error) {
-	totalCountBuilder := sq.Select("COUNT(*)").From("users").Where("1=1").PlaceholderFormat(sq.Dollar)
-	usersBuilder := sq.Select("*").From("users").Where("1=1").PlaceholderFormat(sq.Dollar)
-
-	if name != "" {
-		nameLike := sq.ILike{"name": name + "%"}
-		usersBuilder = usersBuilder.Where(nameLike)
-		totalCountBuilder = totalCountBuilder.Where(nameLike)
-	}
-	if surname != "" {
-		surnameLike := sq.ILike{"surname": surname + "%"}
-		usersBuilder = usersBuilder.Where(surnameLike)
-		totalCountBuilder = totalCountBuilder.Where(surnameLike)
+// GetAllUsers selects only columns from fields, all columns if fields are empty
+func (u *userRepository) GetAllUsers(pageSize, page int, filter entities.UserFilter, sort []entities.SortField, fields []string) ([]entities.User, int, error) {
+	sortKeys, err := userSortKeys(sort)
+	if err != nil {
+		return nil, 0, err
 	}
-	if patronymic != "" {
-		patronymicLike := sq.ILike{"patronymic": patronymic + "%"}
-		usersBuilder = usersBuilder.Where(patronymicLike)
-		totalCountBuilder = totalCountBuilder.Where(patronymicLike)
+
+	columns, err := userSelectColumns(fields)
+	if err != nil {
+		return nil, 0, err
 	}
-	if gender != "" {
-		genderEq := sq.Eq{"gender": gender}
-		usersBuilder = usersBuilder.Where(genderEq)
-		totalCountBuilder = totalCountBuilder.Where(genderEq)
+
+	cond, err := userFilterCond(filter)
+	if err != nil {
+		return nil, 0, err
 	}
+	totalCountBuilder := sq.Select("COUNT(*)").From("users").Where("deleted_at IS NULL").Where(cond).PlaceholderFormat(sq.Dollar)
+	usersBuilder := sq.Select(columns...).From("users").Where("deleted_at IS NULL").Where(cond).PlaceholderFormat(sq.Dollar)
 
 	offset := (page - 1) * pageSize
-	usersBuilder = usersBuilder.Limit(uint64(pageSize)).Offset(uint64(offset))
+	usersBuilder = usersBuilder.OrderBy(orderByClauses(sortKeys, false)...).Limit(uint64(pageSize)).Offset(uint64(offset))
 
 	usersQuery, usersArgs, err := usersBuilder.ToSql()
 	if err != nil {
@@ -86,13 +89,95 @@ func (u *userRepository) GetAllUsers(pageSize, page int, name, surname, patronym
 	return users, totalCount, nil
 }
 
+// GetUsersByCursor returns up to limit users after the cursor in sort order, for backward cursor users before it are returned in reversed order.
+// nil cursor means the first page. Sort keys are always selected because cursors are built from them
+func (u *userRepository) GetUsersByCursor(filter entities.UserFilter, sort []entities.SortField, cursor *entities.Cursor, limit int, fields []string) ([]entities.User, error) {
+	sortKeys, err := userSortKeys(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	sortColumns := make([]string, 0, len(sortKeys))
+	for _, key := range sortKeys {
+		sortColumns = append(sortColumns, key.Field)
+	}
+	columns, err := userSelectColumns(fields, sortColumns...)
+	if err != nil {
+		return nil, err
+	}
+
+	filterCond, err := userFilterCond(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	backward := cursor != nil && cursor.Backward
+
+	builder := sq.Select(columns...).From("users").
+		Where("deleted_at IS NULL").Where(filterCond).
+		OrderBy(orderByClauses(sortKeys, backward)...).
+		Limit(uint64(limit)).
+		PlaceholderFormat(sq.Dollar)
+
+	if cursor != nil {
+		cond, err := keysetCond(sortKeys, cursor.Values, backward)
+		if err != nil {
+			return nil, err
+		}
+		builder = builder.Where(cond)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	users := []entities.User{}
+	err = u.db.Select(&users, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (u *userRepository) CountUsers(filter entities.UserFilter) (int, error) {
+	cond, err := userFilterCond(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	query, args, err := sq.Select("COUNT(*)").From("users").
+		Where("deleted_at IS NULL").Where(cond).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var totalCount int
+	err = u.db.Get(&totalCount, query, args...)
+	return totalCount, err
+}
+
 func (u *userRepository) CreateUser(params entities.User) (entities.User, error) {
 	params.Created_at = time.Now()
 	params.Updated_at = time.Now()
+	params.NameKey = translit.SearchKey(params.Name)
+	params.SurnameKey = translit.SearchKey(params.Surname)
+	params.PatronymicKey = translit.SearchKey(params.Patronymic)
+	params.NameNorm = namenorm.Key(params.Name)
+	params.SurnameNorm = namenorm.Key(params.Surname)
+	params.PatronymicNorm = namenorm.Key(params.Patronymic)
+	if params.Attributes == nil {
+		params.Attributes = entities.Attributes{}
+	}
 
 	builder := sq.Insert("users").
-		Columns("name", "surname", "patronymic", "age", "gender", "nationality", "created_at", "updated_at").
-		Values(params.Name, params.Surname, params.Patronymic, params.Age, params.Gender, params.Nationality, params.Created_at, params.Updated_at).
+		Columns("name", "surname", "patronymic", "age", "gender", "nationality", "created_at", "updated_at", "name_key", "surname_key", "patronymic_key",
+			"name_norm", "surname_norm", "patronymic_norm", "attributes").
+		Values(params.Name, params.Surname, params.Patronymic, params.Age, params.Gender, params.Nationality, params.Created_at, params.Updated_at,
+			params.NameKey, params.SurnameKey, params.PatronymicKey, params.NameNorm, params.SurnameNorm, params.PatronymicNorm, params.Attributes).
 		Suffix("RETURNING id").
 		PlaceholderFormat(sq.Dollar)
 
@@ -103,49 +188,72 @@ func (u *userRepository) CreateUser(params entities.User) (entities.User, error)
 
 	err = u.db.Get(&params.Id, query, args...)
 	if err != nil {
-		return entities.User{}, err
+		return entities.User{}, mapUniqueViolation(err)
 	}
 
 	return params, nil
 }
 
-func (u *userRepository) ExistByFullName(params entities.FullName) (bool, error) {
-	var exists bool
-	query := `SELECT EXISTS (
-		SELECT 1 FROM users 
-		WHERE name = $1 AND surname = $2 AND patronymic = $3)`
-
-	err := u.db.Get(&exists, query, params.Name, params.Surname, params.Patronymic)
-	return exists, err
-}
+// GetUserById selects only columns from fields, all columns if fields are empty
+func (u *userRepository) GetUserById(id int32, fields []string) (entities.User, error) {
+	columns, err := userSelectColumns(fields)
+	if err != nil {
+		return entities.User{}, err
+	}
 
-func (u *userRepository) ExistById(id int32) (bool, error) {
-	var exists bool
-	query := `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`
+	query, args, err := sq.Select(columns...).From("users").
+		Where(sq.Eq{"id": id}).Where("deleted_at IS NULL").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return entities.User{}, err
+	}
 
-	err := u.db.Get(&exists, query, id)
-	return exists, err
+	var user entities.User
+	err = u.db.Get(&user, query, args...)
+	return user, err
 }
 
-func (u *userRepository) GetUserById(id int32) (entities.User, error) {
-	var user entities.User
-	query := `SELECT * FROM users WHERE id = $1`
+// GetUsersByIds reads all users in one query, order of result is not defined
+func (u *userRepository) GetUsersByIds(ids []int32) ([]entities.User, error) {
+	query := `SELECT * FROM users WHERE id = ANY($1) AND deleted_at IS NULL`
 
-	err := u.db.Get(&user, query, id)
-	return user, err
+	users := []entities.User{}
+	err := u.db.Select(&users, query, ids)
+	return users, err
 }
 
+// UpdateUser changes only provided fields of live user, ErrUserNotFound is returned if there is no such user
 func (u *userRepository) UpdateUser(id int32, params entities.UpdateUserParams) error {
-	builder := sq.Update("users").Where(sq.Eq{"id": id}).Set("updated_at", time.Now()).PlaceholderFormat(sq.Dollar)
+	builder := sq.Update("users").Where(sq.Eq{"id": id}).Where("deleted_at IS NULL").Set("updated_at", time.Now()).PlaceholderFormat(sq.Dollar)
+	builder = setUpdateParams(builder, params)
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
 
+	res, err := u.db.Exec(query, args...)
+	if err != nil {
+		return mapUniqueViolation(err)
+	}
+
+	return checkAffected(res)
+}
+
+// setUpdateParams sets provided fields and search keys of changed name parts
+func setUpdateParams(builder sq.UpdateBuilder, params entities.UpdateUserParams) sq.UpdateBuilder {
 	if params.Name != nil {
-		builder = builder.Set("name", *params.Name)
+		builder = builder.Set("name", *params.Name).Set("name_key", translit.SearchKey(*params.Name)).
+			Set("name_norm", namenorm.Key(*params.Name))
 	}
 	if params.Surname != nil {
-		builder = builder.Set("surname", *params.Surname)
+		builder = builder.Set("surname", *params.Surname).Set("surname_key", translit.SearchKey(*params.Surname)).
+			Set("surname_norm", namenorm.Key(*params.Surname))
 	}
 	if params.Patronymic != nil {
-		builder = builder.Set("patronymic", *params.Patronymic)
+		builder = builder.Set("patronymic", *params.Patronymic).Set("patronymic_key", translit.SearchKey(*params.Patronymic)).
+			Set("patronymic_norm", namenorm.Key(*params.Patronymic))
 	}
 	if params.Age != nil {
 		builder = builder.Set("age", *params.Age)
@@ -156,22 +264,202 @@ func (u *userRepository) UpdateUser(id int32, params entities.UpdateUserParams)
 	if params.Nationality != nil {
 		builder = builder.Set("nationality", *params.Nationality)
 	}
+	if params.Attributes != nil {
+		builder = builder.Set("attributes", *params.Attributes)
+	}
+	return builder
+}
 
-	query, args, err := builder.ToSql()
+// DeleteUser sets deleted_at like BulkDeleteUsers without hard, soft deleted users are not returned by other methods
+func (u *userRepository) DeleteUser(id int32) error {
+	now := time.Now()
+	query := `UPDATE users SET deleted_at = $2, updated_at = $2 WHERE id = $1 AND deleted_at IS NULL`
+	res, err := u.db.Exec(query, id, now)
 	if err != nil {
 		return err
 	}
 
-	_, err = u.db.Exec(query, args...)
+	return checkAffected(res)
+}
+
+// checkAffected returns ErrUserNotFound if query changed nothing
+func checkAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
-
+	if affected == 0 {
+		return ErrUserNotFound
+	}
 	return nil
 }
 
-func (u *userRepository) DeleteUser(id int32) error {
-	query := `DELETE FROM users WHERE id = $1`
-	_, err := u.db.Exec(query, id)
-	return err
+// rows are fetched from the cursor by batches of this size
+const streamFetchSize = 1000
+
+// StreamUsers reads users matched by filter through a server side cursor and calls fn for every user,
+// so memory usage doesnt depend on the number of rows. Stops on the first error returned by fn
+func (u *userRepository) StreamUsers(ctx context.Context, filter entities.UserFilter, fn func(user entities.User) error) error {
+	cond, err := userFilterCond(filter)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := sq.Select("*").From("users").
+		Where("deleted_at IS NULL").Where(cond).
+		OrderBy("id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	// cursors live only inside a transaction
+	tx, err := u.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "DECLARE users_stream_cursor NO SCROLL CURSOR FOR "+query, args...)
+	if err != nil {
+		return err
+	}
+
+	fetchQuery := fmt.Sprintf("FETCH FORWARD %d FROM users_stream_cursor", streamFetchSize)
+	for {
+		fetched, err := fetchBatch(ctx, tx, fetchQuery, fn)
+		if err != nil {
+			return err
+		}
+		if fetched < streamFetchSize {
+			break
+		}
+	}
+
+	return tx.Commit()
+}
+
+func fetchBatch(ctx context.Context, tx *sqlx.Tx, fetchQuery string, fn func(user entities.User) error) (int, error) {
+	rows, err := tx.QueryxContext(ctx, fetchQuery)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		var user entities.User
+		err = rows.StructScan(&user)
+		if err != nil {
+			return 0, err
+		}
+		err = fn(user)
+		if err != nil {
+			return 0, err
+		}
+		fetched++
+	}
+
+	return fetched, rows.Err()
+}
+
+func userFilterCond(filter entities.UserFilter) (sq.And, error) {
+	cond := sq.And{}
+
+	if filter.Name != "" {
+		cond = append(cond, nameMatchCond("name_key", filter.Name, filter.Match))
+	}
+	if filter.Surname != "" {
+		cond = append(cond, nameMatchCond("surname_key", filter.Surname, filter.Match))
+	}
+	if filter.Patronymic != "" {
+		cond = append(cond, nameMatchCond("patronymic_key", filter.Patronymic, filter.Match))
+	}
+	if filter.Gender != "" {
+		cond = append(cond, sq.Eq{"gender": filter.Gender})
+	}
+	if len(filter.Nationalities) > 0 {
+		cond = append(cond, sq.Eq{"nationality": filter.Nationalities})
+	}
+	if filter.AgeGte != nil {
+		cond = append(cond, sq.GtOrEq{"age": *filter.AgeGte})
+	}
+	if filter.AgeLte != nil {
+		cond = append(cond, sq.LtOrEq{"age": *filter.AgeLte})
+	}
+	if filter.CreatedGte != nil {
+		cond = append(cond, sq.GtOrEq{"created_at": *filter.CreatedGte})
+	}
+	if filter.CreatedLte != nil {
+		cond = append(cond, sq.LtOrEq{"created_at": *filter.CreatedLte})
+	}
+	if filter.UpdatedGte != nil {
+		cond = append(cond, sq.GtOrEq{"updated_at": *filter.UpdatedGte})
+	}
+	if filter.UpdatedLte != nil {
+		cond = append(cond, sq.LtOrEq{"updated_at": *filter.UpdatedLte})
+	}
+	if filter.HasPatronymic != nil {
+		if *filter.HasPatronymic {
+			cond = append(cond, sq.Expr("COALESCE(patronymic, '') <> ''"))
+		} else {
+			cond = append(cond, sq.Expr("COALESCE(patronymic, '') = ''"))
+		}
+	}
+	if filter.Expr != nil {
+		exprCond, err := filterExprCond(filter.Expr)
+		if err != nil {
+			return nil, err
+		}
+		cond = append(cond, exprCond)
+	}
+	if len(filter.Tags) > 0 {
+		cond = append(cond, tagFilterCond(filter.Tags, filter.TagMode))
+	}
+	for _, attr := range filter.Attributes {
+		attrCond, err := attributeFilterCond(attr)
+		if err != nil {
+			return nil, err
+		}
+		cond = append(cond, attrCond)
+	}
+
+	return cond, nil
+}
+
+// attributeFilterCond checks equality with jsonpath predicate, so GIN index on attributes (jsonb_path_ops) is used.
+// Name and values are json encoded, jsonpath strings have the same escapes
+func attributeFilterCond(filter entities.AttributeFilter) (sq.Sqlizer, error) {
+	name, err := json.Marshal(filter.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	predicates := make([]string, len(filter.Values))
+	for i, value := range filter.Values {
+		literal, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		predicates[i] = "$." + string(name) + " == " + string(literal)
+	}
+	return sq.Expr("attributes @@ ?::jsonpath", strings.Join(predicates, " || ")), nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// matches search key column by the key of value, so latin and cyrillic spellings find each other.
+// % and _ in the value are matched literally
+func nameMatchCond(keyColumn, value, match string) sq.ILike {
+	value = likeEscaper.Replace(translit.SearchKey(value))
+
+	switch match {
+	case entities.MatchExact:
+		return sq.ILike{keyColumn: value}
+	case entities.MatchContains:
+		return sq.ILike{keyColumn: "%" + value + "%"}
+	default:
+		return sq.ILike{keyColumn: value + "%"}
+	}
 }
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/namenorm"
+	"github.com/Util787/user-manager-api/internal/translit"
+)
+
+// SearchUsers finds users whose name, surname or patronymic is similar to query by pg_trgm, the most similar go first.
+// Search keys are compared so typos in latin or cyrillic spelling are tolerated the same way.
+// % operator is used in where clause so trigram indexes from 000004_add_search_keys can be used
+func (u *userRepository) SearchUsers(query string, threshold float64, limit int) ([]entities.UserSearchResult, error) {
+	key := translit.SearchKey(query)
+
+	sqlQuery, args, err := sq.Select("*").
+		Column(sq.Expr("GREATEST(similarity(name_key, ?), similarity(surname_key, ?), similarity(patronymic_key, ?)) AS score", key, key, key)).
+		From("users").
+		Where("deleted_at IS NULL").
+		Where(sq.Expr("(name_key % ? OR surname_key % ? OR patronymic_key % ?)", key, key, key)).
+		OrderBy("score DESC", "id").
+		Limit(uint64(limit)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := u.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// threshold of % operator, is_local=true resets it when transaction ends
+	_, err = tx.Exec("SELECT set_config('pg_trgm.similarity_threshold', $1, true)", strconv.FormatFloat(threshold, 'f', -1, 64))
+	if err != nil {
+		return nil, err
+	}
+
+	results := []entities.UserSearchResult{}
+	err = tx.Select(&results, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ReindexSearchKeys recalculates search and normalized keys of all users (soft deleted too) by batches of batchSize rows ordered by id,
+// every batch is updated in its own transaction. Needed after 000004_add_search_keys migration or after key rules changed
+func (u *userRepository) ReindexSearchKeys(ctx context.Context, batchSize int) (int, error) {
+	updated := 0
+	lastId := int32(0)
+
+	for {
+		var users []entities.User
+		err := u.db.SelectContext(ctx, &users, `SELECT id, name, surname, COALESCE(patronymic, '') AS patronymic FROM users WHERE id > $1 ORDER BY id LIMIT $2`, lastId, batchSize)
+		if err != nil {
+			return updated, err
+		}
+		if len(users) == 0 {
+			return updated, nil
+		}
+
+		tx, err := u.db.BeginTxx(ctx, nil)
+		if err != nil {
+			return updated, err
+		}
+		for _, user := range users {
+			_, err = tx.ExecContext(ctx, `UPDATE users SET name_key = $1, surname_key = $2, patronymic_key = $3,
+				name_norm = $4, surname_norm = $5, patronymic_norm = $6 WHERE id = $7`,
+				translit.SearchKey(user.Name), translit.SearchKey(user.Surname), translit.SearchKey(user.Patronymic),
+				namenorm.Key(user.Name), namenorm.Key(user.Surname), namenorm.Key(user.Patronymic), user.Id)
+			if err != nil {
+				tx.Rollback()
+				return updated, mapUniqueViolation(err)
+			}
+		}
+		err = tx.Commit()
+		if err != nil {
+			return updated, err
+		}
+
+		updated += len(users)
+		lastId = users[len(users)-1].Id
+	}
+}
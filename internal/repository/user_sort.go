@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Util787/user-manager-api/entities"
+)
+
+var (
+	ErrInvalidSortField = errors.New("invalid sort field")
+	// cursor doesnt have values of all sort keys or they cant be parsed
+	ErrInvalidCursorValues = errors.New("invalid cursor values")
+)
+
+// whitelist of columns that can be used in ORDER BY, all of them are NOT NULL
+var userSortColumns = map[string]bool{
+	"id":          true,
+	"name":        true,
+	"surname":     true,
+	"age":         true,
+	"created_at":  true,
+	"updated_at":  true,
+	"nationality": true,
+}
+
+func IsSortableUserColumn(column string) bool {
+	return userSortColumns[column]
+}
+
+// userSortKeys adds id as the last key if it is not in sort already, so order is stable for equal values
+func userSortKeys(sort []entities.SortField) ([]entities.SortField, error) {
+	keys := make([]entities.SortField, 0, len(sort)+1)
+	hasId := false
+	for _, field := range sort {
+		if !IsSortableUserColumn(field.Field) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSortField, field.Field)
+		}
+		if field.Field == "id" {
+			hasId = true
+		}
+		keys = append(keys, field)
+	}
+	if !hasId {
+		keys = append(keys, entities.SortField{Field: "id"})
+	}
+	return keys, nil
+}
+
+// reversed order is used to read the previous page by cursor
+func orderByClauses(keys []entities.SortField, reversed bool) []string {
+	clauses := make([]string, 0, len(keys))
+	for _, key := range keys {
+		desc := key.Desc != reversed
+		if desc {
+			clauses = append(clauses, key.Field+" DESC")
+		} else {
+			clauses = append(clauses, key.Field+" ASC")
+		}
+	}
+	return clauses
+}
+
+// keysetCond selects rows that go after the cursor values in keys order (before them if reversed):
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+func keysetCond(keys []entities.SortField, values map[string]string, reversed bool) (sq.Or, error) {
+	cond := sq.Or{}
+	equalPrefix := sq.And{}
+
+	for _, key := range keys {
+		strValue, ok := values[key.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: no value for %s", ErrInvalidCursorValues, key.Field)
+		}
+		value, err := parseSortValue(key.Field, strValue)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidCursorValues, key.Field, err)
+		}
+
+		var after sq.Sqlizer
+		if key.Desc != reversed {
+			after = sq.Lt{key.Field: value}
+		} else {
+			after = sq.Gt{key.Field: value}
+		}
+
+		step := append(sq.And{}, equalPrefix...)
+		cond = append(cond, append(step, after))
+		equalPrefix = append(equalPrefix, sq.Eq{key.Field: value})
+	}
+
+	return cond, nil
+}
+
+// UserSortValues returns values of sort keys of the user for building a cursor
+func UserSortValues(user entities.User, sort []entities.SortField) (map[string]string, error) {
+	keys, err := userSortKeys(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(keys))
+	for _, key := range keys {
+		switch key.Field {
+		case "id":
+			values[key.Field] = strconv.Itoa(int(user.Id))
+		case "name":
+			values[key.Field] = user.Name
+		case "surname":
+			values[key.Field] = user.Surname
+		case "age":
+			values[key.Field] = strconv.Itoa(user.Age)
+		case "created_at":
+			values[key.Field] = user.Created_at.Format(time.RFC3339Nano)
+		case "updated_at":
+			values[key.Field] = user.Updated_at.Format(time.RFC3339Nano)
+		case "nationality":
+			values[key.Field] = user.Nationality
+		}
+	}
+	return values, nil
+}
+
+func parseSortValue(column, value string) (any, error) {
+	switch column {
+	case "id":
+		id, err := strconv.ParseInt(value, 10, 32)
+		return int32(id), err
+	case "age":
+		return strconv.Atoi(value)
+	case "created_at", "updated_at":
+		return time.Parse(time.RFC3339Nano, value)
+	}
+	return value, nil
+}
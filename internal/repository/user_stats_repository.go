@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrInvalidStatsOptions = errors.New("invalid stats options")
+
+// GetUserStats aggregates users matching filter. All queries run in one read only repeatable read transaction,
+// so the numbers are consistent with each other
+func (u *userRepository) GetUserStats(ctx context.Context, filter entities.UserFilter, opts entities.StatsOptions) (entities.UserStats, error) {
+	if opts.Interval != entities.StatsIntervalDay && opts.Interval != entities.StatsIntervalWeek {
+		return entities.UserStats{}, fmt.Errorf("%w: unknown interval %q", ErrInvalidStatsOptions, opts.Interval)
+	}
+	edges, err := ageBucketEdges(opts.AgeBuckets)
+	if err != nil {
+		return entities.UserStats{}, err
+	}
+
+	cond, err := userFilterCond(filter)
+	if err != nil {
+		return entities.UserStats{}, err
+	}
+	base := sq.Select().From("users").Where("deleted_at IS NULL").Where(cond).PlaceholderFormat(sq.Dollar)
+
+	tx, err := u.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return entities.UserStats{}, err
+	}
+	defer tx.Rollback()
+
+	stats := entities.UserStats{
+		ByGender:      []entities.GenderStats{},
+		ByNationality: []entities.NationalityStats{},
+		Interval:      opts.Interval,
+		CreatedSeries: []entities.TimeSeriesPoint{},
+		ByTag:         []entities.TagStats{},
+	}
+
+	err = selectBuilder(tx, &stats.ByGender, base.Columns("gender", "COUNT(*) AS count").GroupBy("gender").OrderBy("gender"))
+	if err != nil {
+		return entities.UserStats{}, err
+	}
+	for _, g := range stats.ByGender {
+		stats.Total += g.Count
+	}
+
+	err = selectBuilder(tx, &stats.ByNationality, base.Columns("nationality", "COUNT(*) AS count", "AVG(age)::float8 AS avg_age").
+		GroupBy("nationality").OrderBy("count DESC", "nationality"))
+	if err != nil {
+		return entities.UserStats{}, err
+	}
+
+	// ages are not negative and edges start from 0, so width_bucket returns bucket number from 1 to len(edges)
+	var buckets []struct {
+		Bucket int `db:"bucket"`
+		Count  int `db:"count"`
+	}
+	err = selectBuilder(tx, &buckets, base.Column(sq.Expr("GREATEST(width_bucket(age, ?::int[]), 1) AS bucket", edges)).
+		Column("COUNT(*) AS count").GroupBy("bucket"))
+	if err != nil {
+		return entities.UserStats{}, err
+	}
+	stats.AgeHistogram = make([]entities.AgeBucket, len(edges))
+	for i, from := range edges {
+		stats.AgeHistogram[i].From = from
+		if i+1 < len(edges) {
+			to := edges[i+1]
+			stats.AgeHistogram[i].To = &to
+		}
+	}
+	for _, b := range buckets {
+		stats.AgeHistogram[b.Bucket-1].Count = b.Count
+	}
+
+	err = selectBuilder(tx, &stats.CreatedSeries, base.Column(sq.Expr("date_trunc(?, created_at AT TIME ZONE 'UTC') AS period", opts.Interval)).
+		Column("COUNT(*) AS count").GroupBy("period").OrderBy("period"))
+	if err != nil {
+		return entities.UserStats{}, err
+	}
+
+	matched := sq.Select("id").From("users").Where("deleted_at IS NULL").Where(cond)
+	err = selectBuilder(tx, &stats.ByTag, sq.Select("t.name AS tag", "COUNT(*) AS count").From("user_tags ut").Join("tags t ON t.id = ut.tag_id").
+		Where(sq.Expr("ut.user_id IN (?)", matched)).GroupBy("t.name").OrderBy("count DESC", "tag").PlaceholderFormat(sq.Dollar))
+	if err != nil {
+		return entities.UserStats{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return entities.UserStats{}, err
+	}
+
+	return stats, nil
+}
+
+// ageBucketEdges checks that bounds are ascending and not negative, 0 is added as the first bound if missing
+func ageBucketEdges(bounds []int) ([]int, error) {
+	if len(bounds) == 0 {
+		return nil, fmt.Errorf("%w: no age buckets", ErrInvalidStatsOptions)
+	}
+	for i, bound := range bounds {
+		if bound < 0 {
+			return nil, fmt.Errorf("%w: age bucket bound %d is negative", ErrInvalidStatsOptions, bound)
+		}
+		if i > 0 && bound <= bounds[i-1] {
+			return nil, fmt.Errorf("%w: age bucket bounds must be ascending", ErrInvalidStatsOptions)
+		}
+	}
+
+	if bounds[0] == 0 {
+		return bounds, nil
+	}
+	return append([]int{0}, bounds...), nil
+}
+
+func selectBuilder(tx *sqlx.Tx, dest any, builder sq.SelectBuilder) error {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+	return tx.Select(dest, query, args...)
+}
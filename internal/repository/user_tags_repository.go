@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/jmoiron/sqlx"
+)
+
+// GetUserTags returns ErrUserNotFound if user doesnt exist or is deleted
+func (u *userRepository) GetUserTags(userId int32) ([]string, error) {
+	tx, err := u.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = lockLiveUser(tx, userId); err != nil {
+		return nil, err
+	}
+	return selectUserTags(tx, userId)
+}
+
+// AddUserTags creates missing tags and returns all tags of the user after the change
+func (u *userRepository) AddUserTags(userId int32, tags []string) ([]string, error) {
+	tx, err := u.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = lockLiveUser(tx, userId); err != nil {
+		return nil, err
+	}
+	if err = addTags(tx, []int32{userId}, tags, time.Now()); err != nil {
+		return nil, err
+	}
+
+	result, err := selectUserTags(tx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return result, tx.Commit()
+}
+
+// RemoveUserTags returns all tags of the user after the change, tags that the user doesnt have are skipped
+func (u *userRepository) RemoveUserTags(userId int32, tags []string) ([]string, error) {
+	tx, err := u.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = lockLiveUser(tx, userId); err != nil {
+		return nil, err
+	}
+	if err = removeTags(tx, []int32{userId}, tags); err != nil {
+		return nil, err
+	}
+
+	result, err := selectUserTags(tx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return result, tx.Commit()
+}
+
+// BulkTagUsers adds and removes tags of live users matched by filter in one transaction.
+// Affected is the number of matched users, updated_at of users isnt changed
+func (u *userRepository) BulkTagUsers(filter entities.BulkFilter, add, remove []string, opts entities.BulkOptions) (entities.BulkResult, error) {
+	cond, err := bulkFilterCond(filter, false)
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+
+	tx, err := u.db.Beginx()
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+	defer tx.Rollback()
+
+	if opts.DryRun {
+		return dryRunBulk(tx, cond)
+	}
+
+	query, args, err := sq.Select("id").From("users").Where(cond).OrderBy("id").Suffix("FOR UPDATE").PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+	var ids []int32
+	err = tx.Select(&ids, query, args...)
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+	if opts.MaxAffected > 0 && len(ids) > opts.MaxAffected {
+		return entities.BulkResult{}, ErrBulkLimitExceeded
+	}
+
+	if err = addTags(tx, ids, add, time.Now()); err != nil {
+		return entities.BulkResult{}, err
+	}
+	if err = removeTags(tx, ids, remove); err != nil {
+		return entities.BulkResult{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+	return entities.BulkResult{Affected: len(ids)}, nil
+}
+
+// lockLiveUser locks user row until the end of transaction, so tags arent added to a user that is being deleted
+func lockLiveUser(tx *sqlx.Tx, userId int32) error {
+	var id int32
+	err := tx.Get(&id, `SELECT id FROM users WHERE id = $1 AND deleted_at IS NULL FOR SHARE`, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrUserNotFound
+	}
+	return err
+}
+
+func selectUserTags(tx *sqlx.Tx, userId int32) ([]string, error) {
+	tags := []string{}
+	err := tx.Select(&tags, `SELECT t.name FROM user_tags ut JOIN tags t ON t.id = ut.tag_id WHERE ut.user_id = $1 ORDER BY t.name`, userId)
+	return tags, err
+}
+
+func addTags(tx *sqlx.Tx, userIds []int32, tags []string, now time.Time) error {
+	if len(userIds) == 0 || len(tags) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(`INSERT INTO tags (name, created_at) SELECT unnest($1::text[]), $2 ON CONFLICT (name) DO NOTHING`, tags, now)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO user_tags (user_id, tag_id, created_at)
+		SELECT u.id, t.id, $3 FROM unnest($1::int[]) AS u(id) CROSS JOIN tags t WHERE t.name = ANY($2)
+		ON CONFLICT DO NOTHING`, userIds, tags, now)
+	return err
+}
+
+func removeTags(tx *sqlx.Tx, userIds []int32, tags []string) error {
+	if len(userIds) == 0 || len(tags) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(`DELETE FROM user_tags WHERE user_id = ANY($1) AND tag_id IN (SELECT id FROM tags WHERE name = ANY($2))`, userIds, tags)
+	return err
+}
+
+// moveUserTags gives target all tags of source, source keeps nothing
+func moveUserTags(tx *sqlx.Tx, targetId, sourceId int32) error {
+	_, err := tx.Exec(`INSERT INTO user_tags (user_id, tag_id, created_at) SELECT $1, tag_id, created_at FROM user_tags WHERE user_id = $2
+		ON CONFLICT DO NOTHING`, targetId, sourceId)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`DELETE FROM user_tags WHERE user_id = $1`, sourceId)
+	return err
+}
+
+// tagFilterCond matches users with any or all of tags, tags must be unique
+func tagFilterCond(tags []string, mode string) sq.Sqlizer {
+	sub := sq.Select().From("user_tags ut").Join("tags t ON t.id = ut.tag_id").
+		Where("ut.user_id = users.id").Where(sq.Eq{"t.name": tags})
+	if mode == entities.TagModeAll {
+		return sq.Expr("(?) = ?", sub.Column("COUNT(*)"), len(tags))
+	}
+	return sq.Expr("EXISTS (?)", sub.Column("1"))
+}
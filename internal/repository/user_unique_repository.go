@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// scopes of full name unique index, see SetFullNameUniqueness
+const (
+	// only users with patronymic must have unique full names, people with the same name and surname are common. Default
+	FullNameUniqueWithPatronymic = "patronymic"
+	// every live user must have unique full name
+	FullNameUniqueAll = "all"
+	// full names are not checked
+	FullNameUniqueOff = "off"
+)
+
+var ErrInvalidUniqueScope = errors.New("invalid full name uniqueness scope, must be patronymic, all or off")
+
+const (
+	fullNameUniqueIndex   = "idx_users_full_name_unique"
+	pgUniqueViolationCode = "23505"
+)
+
+// mapUniqueViolation turns violation of full name unique index into ErrUserExists, other errors are returned as is
+func mapUniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolationCode && pgErr.ConstraintName == fullNameUniqueIndex {
+		return fmt.Errorf("%w: %s", ErrUserExists, pgErr.Detail)
+	}
+	return err
+}
+
+// SetFullNameUniqueness recreates full name unique index for scope without locking the table for writes.
+// New index is built next to the old one and replaces it only if it was built, so the old scope works until then.
+// If swapping fails the new index is left built and the next run rebuilds it.
+// Full names are compared by normalized keys, see namenorm.Key.
+// Building fails if live users already have equal full names, they can be found with FindDuplicates
+func (u *userRepository) SetFullNameUniqueness(ctx context.Context, scope string) error {
+	var where string
+	switch scope {
+	case FullNameUniqueWithPatronymic:
+		where = "deleted_at IS NULL AND patronymic_norm <> ''"
+	case FullNameUniqueAll:
+		where = "deleted_at IS NULL"
+	case FullNameUniqueOff:
+		_, err := u.db.ExecContext(ctx, `DROP INDEX CONCURRENTLY IF EXISTS `+fullNameUniqueIndex)
+		return err
+	default:
+		return ErrInvalidUniqueScope
+	}
+
+	newIndex := fullNameUniqueIndex + "_new"
+	// left invalid by previous failed run
+	_, err := u.db.ExecContext(ctx, `DROP INDEX CONCURRENTLY IF EXISTS `+newIndex)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.db.ExecContext(ctx, `CREATE UNIQUE INDEX CONCURRENTLY `+newIndex+` ON users (name_norm, surname_norm, patronymic_norm) WHERE `+where)
+	if err != nil {
+		buildErr := fmt.Errorf("failed to build unique index, probably some users have equal full names: %w", err)
+		// invalid index slows down writes until it is dropped, so it is dropped even if ctx is cancelled
+		_, err = u.db.ExecContext(context.WithoutCancel(ctx), `DROP INDEX CONCURRENTLY IF EXISTS `+newIndex)
+		if err != nil {
+			return errors.Join(buildErr, fmt.Errorf("failed to drop invalid index %s, drop it manually: %w", newIndex, err))
+		}
+		return buildErr
+	}
+
+	return u.swapFullNameUniqueIndex(ctx, newIndex)
+}
+
+// swapFullNameUniqueIndex replaces the old index with newIndex in one transaction, so there is no moment without unique index.
+// Plain DROP INDEX locks the table, but only for the time of dropping, lock_timeout keeps it from waiting behind long queries
+func (u *userRepository) swapFullNameUniqueIndex(ctx context.Context, newIndex string) error {
+	tx, err := u.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `SET LOCAL lock_timeout = '5s'`)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `DROP INDEX IF EXISTS `+fullNameUniqueIndex)
+	if err != nil {
+		return fmt.Errorf("failed to drop old unique index, run again when the table is less busy: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `ALTER INDEX `+newIndex+` RENAME TO `+fullNameUniqueIndex)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
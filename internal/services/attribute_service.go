@@ -0,0 +1,193 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/repository"
+)
+
+// ErrInvalidAttributeFilter is wrapped by errors about ?attr.<name>= filters of unknown attributes or with values of wrong type
+var ErrInvalidAttributeFilter = errors.New("invalid attribute filter")
+
+var attributeNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+type attributeService struct {
+	attrRepo repository.AttributeRepository
+}
+
+func NewAttributeService(repo repository.AttributeRepository) AttributeService {
+	return &attributeService{attrRepo: repo}
+}
+
+func (a *attributeService) CreateDefinition(def entities.AttributeDefinition) (entities.AttributeDefinition, error) {
+	if err := validateAttributeDefinition(def); err != nil {
+		return entities.AttributeDefinition{}, err
+	}
+	def, err := a.attrRepo.CreateDefinition(normalizeAttributeDefinition(def))
+	return def, domainError(err)
+}
+
+func (a *attributeService) GetDefinitions() ([]entities.AttributeDefinition, error) {
+	return a.attrRepo.GetDefinitions()
+}
+
+func (a *attributeService) GetDefinition(name string) (entities.AttributeDefinition, error) {
+	def, err := a.attrRepo.GetDefinition(name)
+	return def, domainError(err)
+}
+
+func (a *attributeService) UpdateDefinition(def entities.AttributeDefinition) (entities.AttributeDefinition, error) {
+	if err := validateAttributeDefinition(def); err != nil {
+		return entities.AttributeDefinition{}, err
+	}
+	def, err := a.attrRepo.UpdateDefinition(normalizeAttributeDefinition(def))
+	return def, domainError(err)
+}
+
+func (a *attributeService) DeleteDefinition(name string) error {
+	return domainError(a.attrRepo.DeleteDefinition(name))
+}
+
+// empty enum is stored as empty array, not null
+func normalizeAttributeDefinition(def entities.AttributeDefinition) entities.AttributeDefinition {
+	if def.Enum == nil {
+		def.Enum = entities.JSONValues{}
+	}
+	return def
+}
+
+func validateAttributeDefinition(def entities.AttributeDefinition) error {
+	var v validator
+	v.check(attributeNameRe.MatchString(def.Name), "name", FieldErrInvalidAttributeName)
+
+	switch def.Type {
+	case entities.AttributeTypeString, entities.AttributeTypeNumber, entities.AttributeTypeBoolean:
+		for i, value := range def.Enum {
+			v.check(isAttributeType(value, def.Type), "enum."+strconv.Itoa(i), FieldErrInvalidType)
+		}
+	default:
+		v.check(false, "type", FieldErrInvalidAttributeType)
+	}
+
+	if def.Pattern != "" {
+		_, err := compileAttributePattern(def.Pattern)
+		v.check(err == nil, "pattern", FieldErrInvalidPattern)
+		v.check(def.Type == entities.AttributeTypeString, "pattern", FieldErrPatternNotString)
+	}
+	return v.err()
+}
+
+// the whole value must match pattern, like in html pattern attribute
+func compileAttributePattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
+
+func isAttributeType(value any, attrType string) bool {
+	switch value.(type) {
+	case string:
+		return attrType == entities.AttributeTypeString
+	case float64:
+		return attrType == entities.AttributeTypeNumber
+	case bool:
+		return attrType == entities.AttributeTypeBoolean
+	default:
+		return false
+	}
+}
+
+// validateAttributes checks attributes against all definitions, null values are removed before the check.
+// Field names of errors are attributes.<name>
+func validateAttributes(defs []entities.AttributeDefinition, attrs entities.Attributes) (entities.Attributes, error) {
+	cleaned := make(entities.Attributes, len(attrs))
+	for name, value := range attrs {
+		if value != nil {
+			cleaned[name] = value
+		}
+	}
+
+	var v validator
+	// the same order of errors for the same input
+	names := make([]string, 0, len(cleaned))
+	for name := range cleaned {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		v.check(slices.ContainsFunc(defs, func(def entities.AttributeDefinition) bool { return def.Name == name }),
+			"attributes."+name, FieldErrUnknownField)
+	}
+
+	for _, def := range defs {
+		field := "attributes." + def.Name
+		value, ok := cleaned[def.Name]
+		if !ok {
+			v.check(!def.Required, field, FieldErrRequired)
+			continue
+		}
+		if !isAttributeType(value, def.Type) {
+			v.check(false, field, FieldErrInvalidType)
+			continue
+		}
+		if len(def.Enum) > 0 && !slices.Contains(def.Enum, value) {
+			v.fields = append(v.fields, newFieldError(field, FieldErrNotInEnum, formatEnum(def.Enum)))
+			continue
+		}
+		if s, isString := value.(string); isString && def.Pattern != "" {
+			re, err := compileAttributePattern(def.Pattern)
+			if err != nil || !re.MatchString(s) {
+				v.fields = append(v.fields, newFieldError(field, FieldErrPatternMismatch, def.Pattern))
+			}
+		}
+	}
+	return cleaned, v.err()
+}
+
+func formatEnum(enum entities.JSONValues) string {
+	values := make([]string, len(enum))
+	for i, value := range enum {
+		values[i] = fmt.Sprint(value)
+	}
+	return strings.Join(values, ", ")
+}
+
+// typeAttributeFilters converts string values of filters from the query to types of attribute definitions
+func typeAttributeFilters(defs []entities.AttributeDefinition, filters []entities.AttributeFilter) ([]entities.AttributeFilter, error) {
+	typed := make([]entities.AttributeFilter, len(filters))
+	for i, filter := range filters {
+		index := slices.IndexFunc(defs, func(def entities.AttributeDefinition) bool { return def.Name == filter.Name })
+		if index < 0 {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidAttributeFilter, NewParamError("attr."+filter.Name, FieldErrUnknownAttribute))
+		}
+		def := defs[index]
+
+		typed[i] = entities.AttributeFilter{Name: filter.Name, Values: make([]any, len(filter.Values))}
+		for j, value := range filter.Values {
+			str := fmt.Sprint(value)
+			var err error
+			switch def.Type {
+			case entities.AttributeTypeString:
+				typed[i].Values[j] = str
+			case entities.AttributeTypeNumber:
+				var number float64
+				number, err = strconv.ParseFloat(str, 64)
+				if err == nil && (math.IsNaN(number) || math.IsInf(number, 0)) {
+					err = strconv.ErrSyntax
+				}
+				typed[i].Values[j] = number
+			case entities.AttributeTypeBoolean:
+				typed[i].Values[j], err = strconv.ParseBool(str)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidAttributeFilter, NewParamError("attr."+filter.Name, FieldErrInvalidAttributeValue, str, def.Type))
+			}
+		}
+	}
+	return typed, nil
+}
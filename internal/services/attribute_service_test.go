@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAttributeDefinitions = []entities.AttributeDefinition{
+	{Name: "department", Type: entities.AttributeTypeString, Required: true, Enum: entities.JSONValues{"sales", "support"}},
+	{Name: "employee_no", Type: entities.AttributeTypeString, Pattern: `[A-Z]{2}-[0-9]+`},
+	{Name: "level", Type: entities.AttributeTypeNumber},
+	{Name: "remote", Type: entities.AttributeTypeBoolean},
+}
+
+func TestValidateAttributes(t *testing.T) {
+	tests := []struct {
+		testname       string
+		attrs          entities.Attributes
+		expected       entities.Attributes
+		expectedFields []string
+		expectedCodes  []string
+	}{
+		{
+			testname: "Valid attributes, nulls are removed",
+			attrs:    entities.Attributes{"department": "sales", "employee_no": "BY-42", "level": float64(3), "remote": true, "old": nil},
+			expected: entities.Attributes{"department": "sales", "employee_no": "BY-42", "level": float64(3), "remote": true},
+		},
+		{
+			testname:       "Missing required attribute",
+			attrs:          entities.Attributes{"department": nil},
+			expectedFields: []string{"attributes.department"},
+			expectedCodes:  []string{FieldErrRequired},
+		},
+		{
+			testname:       "Unknown attributes",
+			attrs:          entities.Attributes{"department": "sales", "salary": float64(1), "age": float64(30)},
+			expectedFields: []string{"attributes.age", "attributes.salary"},
+			expectedCodes:  []string{FieldErrUnknownField, FieldErrUnknownField},
+		},
+		{
+			testname:       "Invalid types",
+			attrs:          entities.Attributes{"department": "sales", "level": "3", "remote": "yes"},
+			expectedFields: []string{"attributes.level", "attributes.remote"},
+			expectedCodes:  []string{FieldErrInvalidType, FieldErrInvalidType},
+		},
+		{
+			testname:       "Not in enum",
+			attrs:          entities.Attributes{"department": "marketing"},
+			expectedFields: []string{"attributes.department"},
+			expectedCodes:  []string{FieldErrNotInEnum},
+		},
+		{
+			testname:       "Pattern must match the whole value",
+			attrs:          entities.Attributes{"department": "sales", "employee_no": "xBY-42"},
+			expectedFields: []string{"attributes.employee_no"},
+			expectedCodes:  []string{FieldErrPatternMismatch},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			attrs, err := validateAttributes(testAttributeDefinitions, test.attrs)
+
+			if len(test.expectedFields) == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, attrs)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			fields := make([]string, len(validationErr.Fields))
+			codes := make([]string, len(validationErr.Fields))
+			for i, f := range validationErr.Fields {
+				fields[i], codes[i] = f.Field, f.Code
+			}
+			assert.Equal(t, test.expectedFields, fields)
+			assert.Equal(t, test.expectedCodes, codes)
+		})
+	}
+}
+
+func TestValidateAttributeDefinition(t *testing.T) {
+	tests := []struct {
+		testname       string
+		def            entities.AttributeDefinition
+		expectedFields []string
+	}{
+		{testname: "Valid", def: entities.AttributeDefinition{Name: "employee_no", Type: entities.AttributeTypeString, Pattern: `[A-Z]{2}-[0-9]+`}},
+		{testname: "Invalid name", def: entities.AttributeDefinition{Name: "Employee No", Type: entities.AttributeTypeString}, expectedFields: []string{"name"}},
+		{testname: "Unknown type", def: entities.AttributeDefinition{Name: "birthday", Type: "date"}, expectedFields: []string{"type"}},
+		{
+			testname:       "Enum values of another type",
+			def:            entities.AttributeDefinition{Name: "level", Type: entities.AttributeTypeNumber, Enum: entities.JSONValues{float64(1), "2"}},
+			expectedFields: []string{"enum.1"},
+		},
+		{testname: "Invalid pattern", def: entities.AttributeDefinition{Name: "code", Type: entities.AttributeTypeString, Pattern: `[a-`}, expectedFields: []string{"pattern"}},
+		{testname: "Pattern of number", def: entities.AttributeDefinition{Name: "level", Type: entities.AttributeTypeNumber, Pattern: `[0-9]`}, expectedFields: []string{"pattern"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			err := validateAttributeDefinition(test.def)
+
+			if len(test.expectedFields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			fields := make([]string, len(validationErr.Fields))
+			for i, f := range validationErr.Fields {
+				fields[i] = f.Field
+			}
+			assert.Equal(t, test.expectedFields, fields)
+		})
+	}
+}
+
+func TestTypeAttributeFilters(t *testing.T) {
+	tests := []struct {
+		testname    string
+		filters     []entities.AttributeFilter
+		expected    []entities.AttributeFilter
+		expectedErr error
+	}{
+		{
+			testname: "Values are converted to types of definitions",
+			filters: []entities.AttributeFilter{
+				{Name: "department", Values: []any{"sales", "support"}},
+				{Name: "level", Values: []any{"3", "4.5"}},
+				{Name: "remote", Values: []any{"true"}},
+			},
+			expected: []entities.AttributeFilter{
+				{Name: "department", Values: []any{"sales", "support"}},
+				{Name: "level", Values: []any{float64(3), 4.5}},
+				{Name: "remote", Values: []any{true}},
+			},
+		},
+		{testname: "Unknown attribute", filters: []entities.AttributeFilter{{Name: "salary", Values: []any{"1"}}}, expectedErr: ErrInvalidAttributeFilter},
+		{testname: "Not a number", filters: []entities.AttributeFilter{{Name: "level", Values: []any{"high"}}}, expectedErr: ErrInvalidAttributeFilter},
+		{testname: "Infinite number", filters: []entities.AttributeFilter{{Name: "level", Values: []any{"Inf"}}}, expectedErr: ErrInvalidAttributeFilter},
+		{testname: "Not a boolean", filters: []entities.AttributeFilter{{Name: "remote", Values: []any{"yes"}}}, expectedErr: ErrInvalidAttributeFilter},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			filters, err := typeAttributeFilters(testAttributeDefinitions, test.filters)
+
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, filters)
+		})
+	}
+}
+
+// attribute filters of every method that accepts user filters are typed before the user repository is called
+func TestUnknownAttributeFilterIsRejected(t *testing.T) {
+	filter := entities.UserFilter{Attributes: []entities.AttributeFilter{{Name: "salary", Values: []any{"1"}}}}
+	bulkFilter := entities.BulkFilter{UserFilter: filter}
+	gender := "male"
+
+	tests := []struct {
+		testname string
+		call     func(attrRepo *mocks.MockAttributeRepository) error
+	}{
+		{
+			testname: "Bulk update",
+			call: func(attrRepo *mocks.MockAttributeRepository) error {
+				_, err := (&userService{attrRepo: attrRepo}).BulkUpdateUsers(bulkFilter, entities.UpdateUserParams{Gender: &gender}, entities.BulkOptions{})
+				return err
+			},
+		},
+		{
+			testname: "Bulk delete",
+			call: func(attrRepo *mocks.MockAttributeRepository) error {
+				_, err := (&userService{attrRepo: attrRepo}).BulkDeleteUsers(bulkFilter, false, entities.BulkOptions{})
+				return err
+			},
+		},
+		{
+			testname: "Bulk tag",
+			call: func(attrRepo *mocks.MockAttributeRepository) error {
+				_, err := (&userService{attrRepo: attrRepo}).BulkTagUsers(bulkFilter, entities.BulkTagsParams{Add: []string{"vip"}}, entities.BulkOptions{})
+				return err
+			},
+		},
+		{
+			testname: "Stats",
+			call: func(attrRepo *mocks.MockAttributeRepository) error {
+				_, err := (&statsService{attrRepo: attrRepo}).GetUserStats(context.Background(), filter, entities.StatsOptions{})
+				return err
+			},
+		},
+		{
+			testname: "Export",
+			call: func(attrRepo *mocks.MockAttributeRepository) error {
+				return (&exportService{attrRepo: attrRepo}).ExportUsers(context.Background(), io.Discard, ExportFormatCSV, filter)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			attrRepo := mocks.NewMockAttributeRepository(t)
+			attrRepo.EXPECT().GetDefinitions().Return(testAttributeDefinitions, nil)
+
+			err := test.call(attrRepo)
+
+			assert.ErrorIs(t, err, ErrInvalidAttributeFilter)
+		})
+	}
+}
+
+// attributes are saved as a whole map, so bulk update would wipe attributes of all matched users
+func TestBulkUpdateUsersRejectsAttributes(t *testing.T) {
+	s := &userService{}
+	attrs := entities.Attributes{"department": "sales"}
+
+	_, err := s.BulkUpdateUsers(entities.BulkFilter{Ids: []int32{1}}, entities.UpdateUserParams{Attributes: &attrs}, entities.BulkOptions{})
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{newFieldError("attributes", FieldErrNotAllowedInBulk)}, validationErr.Fields)
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/notifier"
+	"github.com/Util787/user-manager-api/internal/repository"
+)
+
+// ErrVerificationCooldown is returned if a verification code was sent to the contact recently
+var ErrVerificationCooldown = errors.New("verification code was sent recently, try again later")
+
+const (
+	verificationCodeTTL     = 10 * time.Minute
+	verificationCooldown    = time.Minute
+	maxVerificationAttempts = 5
+	// max length of email address by RFC 5321
+	maxEmailLength = 254
+)
+
+// E.164 numbers have up to 15 digits, the shortest numbers in use have 7
+var phoneRe = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// separators people type in phone numbers, they are removed before validation
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+
+var contactTypes = map[string][]string{
+	entities.ContactKindEmail: {entities.ContactTypePersonal, entities.ContactTypeWork, entities.ContactTypeOther},
+	entities.ContactKindPhone: {entities.ContactTypeMobile, entities.ContactTypeHome, entities.ContactTypeWork, entities.ContactTypeOther},
+}
+
+// verificationState is stored in redis until the code expires, value is checked so a code cant verify a changed contact.
+// Code is stored as hmac with server secret, a leaked state doesnt reveal the code
+type verificationState struct {
+	CodeHash string `json:"code_hash"`
+	Value    string `json:"value"`
+}
+
+type contactService struct {
+	contactRepo repository.ContactRepository
+	redisRepo   repository.RedisRepository
+	notifier    notifier.Notifier
+	// key of verification code hashes
+	codeSecret []byte
+}
+
+func NewContactService(contactRepo repository.ContactRepository, redisRepo repository.RedisRepository, n notifier.Notifier, codeSecret string) ContactService {
+	return &contactService{contactRepo: contactRepo, redisRepo: redisRepo, notifier: n, codeSecret: []byte(codeSecret)}
+}
+
+func (s *contactService) GetContacts(kind string, userId int32) ([]entities.Contact, error) {
+	contacts, err := s.contactRepo.GetContacts(kind, userId)
+	return contacts, domainError(err)
+}
+
+func (s *contactService) CreateContact(kind string, userId int32, params entities.CreateContactParams) (entities.Contact, error) {
+	value := normalizeContactValue(kind, params.Value)
+	contactType := strings.ToLower(strings.TrimSpace(params.Type))
+	if contactType == "" {
+		contactType = entities.ContactTypeOther
+	}
+
+	var v validator
+	v.checkContactValue(kind, "value", value)
+	v.checkContactType(kind, "type", contactType)
+	if err := v.err(); err != nil {
+		return entities.Contact{}, err
+	}
+
+	contact, err := s.contactRepo.CreateContact(kind, entities.Contact{UserId: userId, Value: value, Type: contactType, Primary: params.Primary})
+	return contact, domainError(err)
+}
+
+func (s *contactService) UpdateContact(kind string, userId, contactId int32, params entities.UpdateContactParams) (entities.Contact, error) {
+	if params.Type == nil && !params.Primary {
+		return entities.Contact{}, &ValidationError{Fields: []FieldError{newFieldError("", FieldErrNoFields)}}
+	}
+
+	var v validator
+	if params.Type != nil {
+		contactType := strings.ToLower(strings.TrimSpace(*params.Type))
+		params.Type = &contactType
+		v.checkContactType(kind, "type", contactType)
+	}
+	if err := v.err(); err != nil {
+		return entities.Contact{}, err
+	}
+
+	contact, err := s.contactRepo.UpdateContact(kind, userId, contactId, params)
+	return contact, domainError(err)
+}
+
+func (s *contactService) DeleteContact(kind string, userId, contactId int32) error {
+	return domainError(s.contactRepo.DeleteContact(kind, userId, contactId))
+}
+
+// SendVerificationCode replaces previous code of the contact, wrong attempts are counted from the start again
+func (s *contactService) SendVerificationCode(ctx context.Context, kind string, userId, contactId int32) (entities.VerificationSent, error) {
+	contact, err := s.contactRepo.GetContact(kind, userId, contactId)
+	if err != nil {
+		return entities.VerificationSent{}, domainError(err)
+	}
+	if contact.Verified_at != nil {
+		return entities.VerificationSent{}, fmt.Errorf("%w: %s is already verified", ErrConflict, kind)
+	}
+
+	key := verificationKey(kind, contactId)
+	ok, err := s.redisRepo.SetNX(ctx, key+":cooldown", 1, verificationCooldown)
+	if err != nil {
+		return entities.VerificationSent{}, err
+	}
+	if !ok {
+		return entities.VerificationSent{}, ErrVerificationCooldown
+	}
+
+	code, err := newVerificationCode()
+	if err != nil {
+		return entities.VerificationSent{}, err
+	}
+	expiresAt := time.Now().Add(verificationCodeTTL)
+	err = s.redisRepo.SetWithTTL(ctx, key, verificationState{CodeHash: s.hashVerificationCode(key, code), Value: contact.Value}, verificationCodeTTL)
+	if err != nil {
+		return entities.VerificationSent{}, err
+	}
+	// counter is seeded with 1 so a live code is told apart from a missing counter
+	err = s.redisRepo.SetWithTTL(ctx, key+":attempts", 1, verificationCodeTTL)
+	if err != nil {
+		return entities.VerificationSent{}, err
+	}
+
+	err = s.notifier.Send(ctx, notifier.Message{
+		Channel: kind,
+		To:      contact.Value,
+		Text:    fmt.Sprintf("Your verification code is %s, it expires in %d minutes", code, int(verificationCodeTTL.Minutes())),
+	})
+	if err != nil {
+		return entities.VerificationSent{}, err
+	}
+	return entities.VerificationSent{ExpiresAt: expiresAt}, nil
+}
+
+// VerifyContact checks the code, wrong, expired and exhausted codes are validation errors of code.
+// Code is deleted after maxVerificationAttempts wrong attempts and after successful verification
+func (s *contactService) VerifyContact(ctx context.Context, kind string, userId, contactId int32, code string) (entities.Contact, error) {
+	contact, err := s.contactRepo.GetContact(kind, userId, contactId)
+	if err != nil {
+		return entities.Contact{}, domainError(err)
+	}
+	if contact.Verified_at != nil {
+		return entities.Contact{}, fmt.Errorf("%w: %s is already verified", ErrConflict, kind)
+	}
+	invalidCode := &ValidationError{Fields: []FieldError{newFieldError("code", FieldErrInvalidVerificationCode)}}
+
+	key := verificationKey(kind, contactId)
+	var state verificationState
+	err = s.redisRepo.Get(ctx, key, &state)
+	if errors.Is(err, repository.ErrCacheMiss) {
+		return entities.Contact{}, invalidCode
+	}
+	if err != nil {
+		return entities.Contact{}, err
+	}
+
+	// attempts key is created with the code and seeded with 1, so 1 after Incr means it has expired
+	attempts, err := s.redisRepo.Incr(ctx, key+":attempts")
+	if err != nil {
+		return entities.Contact{}, err
+	}
+	if attempts == 1 || attempts > maxVerificationAttempts+1 {
+		return entities.Contact{}, joinValidationErrors(s.deleteVerification(ctx, key), invalidCode)
+	}
+	if state.Value != contact.Value || !hmac.Equal([]byte(state.CodeHash), []byte(s.hashVerificationCode(key, strings.TrimSpace(code)))) {
+		return entities.Contact{}, invalidCode
+	}
+
+	// code is deleted first so it cant be used twice
+	if err = s.deleteVerification(ctx, key); err != nil {
+		return entities.Contact{}, err
+	}
+	contact, err = s.contactRepo.MarkContactVerified(kind, userId, contactId, state.Value)
+	return contact, domainError(err)
+}
+
+func (s *contactService) deleteVerification(ctx context.Context, key string) error {
+	if err := s.redisRepo.Delete(ctx, key); err != nil {
+		return err
+	}
+	return s.redisRepo.Delete(ctx, key+":attempts")
+}
+
+func verificationKey(kind string, contactId int32) string {
+	return "contact_verification:" + kind + ":" + strconv.Itoa(int(contactId))
+}
+
+// newVerificationCode returns random 6 digits
+func newVerificationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// hashVerificationCode returns hmac of the code, key of the contact is mixed in so a hash is valid only for its contact.
+// Without the secret 6 digits are brute forced from a plain hash instantly
+func (s *contactService) hashVerificationCode(key, code string) string {
+	mac := hmac.New(sha256.New, s.codeSecret)
+	mac.Write([]byte(key + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// normalizeContactValue lowercases domains of emails and removes separators and 00 prefix of phones
+func normalizeContactValue(kind, value string) string {
+	value = strings.TrimSpace(value)
+	switch kind {
+	case entities.ContactKindEmail:
+		if at := strings.LastIndexByte(value, '@'); at >= 0 {
+			value = value[:at] + strings.ToLower(value[at:])
+		}
+	case entities.ContactKindPhone:
+		value = phoneSeparators.Replace(value)
+		if strings.HasPrefix(value, "00") {
+			value = "+" + value[2:]
+		}
+	}
+	return value
+}
+
+// checkContactValue adds error to field if email isnt a bare RFC 5322 address or phone isnt in E.164, value must be normalized
+func (v *validator) checkContactValue(kind, field, value string) {
+	switch kind {
+	case entities.ContactKindEmail:
+		v.check(isValidEmail(value), field, FieldErrInvalidEmail)
+	case entities.ContactKindPhone:
+		v.check(phoneRe.MatchString(value), field, FieldErrInvalidPhone)
+	}
+}
+
+func (v *validator) checkContactType(kind, field, contactType string) {
+	types := contactTypes[kind]
+	if !slices.Contains(types, contactType) {
+		v.fields = append(v.fields, newFieldError(field, FieldErrInvalidContactType, strings.Join(types, ", ")))
+	}
+}
+
+// isValidEmail accepts only address without display name and angle brackets
+func isValidEmail(email string) bool {
+	if len(email) > maxEmailLength {
+		return false
+	}
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Name == "" && addr.Address == email
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/repository"
+	"github.com/Util787/user-manager-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactValueValidation(t *testing.T) {
+	tests := []struct {
+		testname     string
+		kind         string
+		value        string
+		expected     string
+		expectedCode string
+	}{
+		{testname: "Email with uppercase domain", kind: entities.ContactKindEmail, value: " Ivan.Petrov@Example.COM ", expected: "Ivan.Petrov@example.com"},
+		{testname: "Email with plus", kind: entities.ContactKindEmail, value: "ivan+news@example.com", expected: "ivan+news@example.com"},
+		{testname: "Email with display name", kind: entities.ContactKindEmail, value: "Ivan <ivan@example.com>", expectedCode: FieldErrInvalidEmail},
+		{testname: "Email without domain", kind: entities.ContactKindEmail, value: "ivan@", expectedCode: FieldErrInvalidEmail},
+		{testname: "Phone with separators", kind: entities.ContactKindPhone, value: "+7 (999) 123-45-67", expected: "+79991234567"},
+		{testname: "Phone with 00 prefix", kind: entities.ContactKindPhone, value: "00375 29 123 45 67", expected: "+375291234567"},
+		{testname: "Phone without country code", kind: entities.ContactKindPhone, value: "89991234567", expectedCode: FieldErrInvalidPhone},
+		{testname: "Phone with letters", kind: entities.ContactKindPhone, value: "+7999CALLME", expectedCode: FieldErrInvalidPhone},
+		{testname: "Too long phone", kind: entities.ContactKindPhone, value: "+1234567890123456", expectedCode: FieldErrInvalidPhone},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			value := normalizeContactValue(test.kind, test.value)
+
+			var v validator
+			v.checkContactValue(test.kind, "value", value)
+			err := v.err()
+
+			if test.expectedCode != "" {
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, test.expectedCode, validationErr.Fields[0].Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
+
+// invalid input is rejected before the repository is called
+func TestContactServiceValidation(t *testing.T) {
+	s := &contactService{}
+	fax := "fax"
+
+	tests := []struct {
+		testname      string
+		call          func() error
+		expectedCodes []string
+	}{
+		{
+			testname: "Create with invalid value and type",
+			call: func() error {
+				_, err := s.CreateContact(entities.ContactKindPhone, 1, entities.CreateContactParams{Value: "12345", Type: "personal"})
+				return err
+			},
+			expectedCodes: []string{FieldErrInvalidPhone, FieldErrInvalidContactType},
+		},
+		{
+			testname: "Update without fields",
+			call: func() error {
+				_, err := s.UpdateContact(entities.ContactKindEmail, 1, 3, entities.UpdateContactParams{})
+				return err
+			},
+			expectedCodes: []string{FieldErrNoFields},
+		},
+		{
+			testname: "Update with unknown type",
+			call: func() error {
+				_, err := s.UpdateContact(entities.ContactKindEmail, 1, 3, entities.UpdateContactParams{Type: &fax})
+				return err
+			},
+			expectedCodes: []string{FieldErrInvalidContactType},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			err := test.call()
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			codes := make([]string, len(validationErr.Fields))
+			for i, f := range validationErr.Fields {
+				codes[i] = f.Code
+			}
+			assert.Equal(t, test.expectedCodes, codes)
+		})
+	}
+}
+
+func TestNewVerificationCode(t *testing.T) {
+	for range 100 {
+		code, err := newVerificationCode()
+		require.NoError(t, err)
+		assert.Regexp(t, `^[0-9]{6}$`, code)
+	}
+}
+
+func TestHashVerificationCode(t *testing.T) {
+	s := &contactService{codeSecret: []byte("secret")}
+	key := verificationKey(entities.ContactKindEmail, 3)
+
+	hash := s.hashVerificationCode(key, "123456")
+
+	assert.Equal(t, hash, s.hashVerificationCode(key, "123456"))
+	assert.NotEqual(t, hash, s.hashVerificationCode(key, "123457"))
+	assert.NotEqual(t, hash, s.hashVerificationCode(verificationKey(entities.ContactKindEmail, 4), "123456"), "hash of another contact")
+	assert.NotEqual(t, hash, (&contactService{codeSecret: []byte("other")}).hashVerificationCode(key, "123456"), "hash with another secret")
+}
+
+func TestVerifyContact(t *testing.T) {
+	const code = "123456"
+	contact := entities.Contact{Id: 3, UserId: 1, Value: "ivan@example.com"}
+	key := verificationKey(entities.ContactKindEmail, contact.Id)
+	secret := []byte("secret")
+	state := verificationState{CodeHash: (&contactService{codeSecret: secret}).hashVerificationCode(key, code), Value: contact.Value}
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		testname    string
+		code        string
+		getErr      error
+		attempts    int64
+		deleted     bool
+		expectedErr bool
+	}{
+		{testname: "Success", code: " 123456 ", attempts: 2, deleted: true},
+		{testname: "Last attempt", code: code, attempts: maxVerificationAttempts + 1, deleted: true},
+		{testname: "Wrong code", code: "654321", attempts: 2, expectedErr: true},
+		{testname: "Too many attempts", code: code, attempts: maxVerificationAttempts + 2, deleted: true, expectedErr: true},
+		{testname: "Expired code", code: code, getErr: repository.ErrCacheMiss, expectedErr: true},
+		{testname: "Expired attempts", code: code, attempts: 1, deleted: true, expectedErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			ctx := context.Background()
+			contactRepo := mocks.NewMockContactRepository(t)
+			redisRepo := mocks.NewMockRedisRepository(t)
+			s := &contactService{contactRepo: contactRepo, redisRepo: redisRepo, codeSecret: secret}
+
+			contactRepo.EXPECT().GetContact(entities.ContactKindEmail, contact.UserId, contact.Id).Return(contact, nil)
+			redisRepo.EXPECT().Get(ctx, key, mock.Anything).Run(func(_ context.Context, _ string, dest any) {
+				*dest.(*verificationState) = state
+			}).Return(test.getErr)
+			if test.getErr == nil {
+				redisRepo.EXPECT().Incr(ctx, key+":attempts").Return(test.attempts, nil)
+			}
+			if test.deleted {
+				redisRepo.EXPECT().Delete(ctx, key).Return(nil)
+				redisRepo.EXPECT().Delete(ctx, key+":attempts").Return(nil)
+			}
+			verified := contact
+			verified.Verified_at = &verifiedAt
+			if !test.expectedErr {
+				contactRepo.EXPECT().MarkContactVerified(entities.ContactKindEmail, contact.UserId, contact.Id, contact.Value).Return(verified, nil)
+			}
+
+			result, err := s.VerifyContact(ctx, entities.ContactKindEmail, contact.UserId, contact.Id, test.code)
+
+			if test.expectedErr {
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, FieldErrInvalidVerificationCode, validationErr.Fields[0].Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, verified, result)
+		})
+	}
+}
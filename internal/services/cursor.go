@@ -0,0 +1,31 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/Util787/user-manager-api/entities"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursor is opaque for clients, its content may change between versions
+func encodeCursor(cursor entities.Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (entities.Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return entities.Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor entities.Cursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		return entities.Cursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
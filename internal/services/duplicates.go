@@ -0,0 +1,106 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/Util787/user-manager-api/entities"
+)
+
+// fields that can be chosen in MergeUsersParams.Prefer
+var mergeableUserFields = []string{"name", "surname", "patronymic", "age", "gender", "nationality", "attributes"}
+
+func (u *userService) FindDuplicates(threshold float64, limit int, userId int32) ([]entities.DuplicateCandidate, error) {
+	candidates, err := u.userRepo.FindDuplicates(threshold, limit, userId)
+	if err != nil {
+		return nil, err
+	}
+	for i := range candidates {
+		candidates[i].Matches = duplicateMatches(candidates[i].First, candidates[i].Second)
+	}
+	return candidates, nil
+}
+
+func (u *userService) MergeUsers(params entities.MergeUsersParams) (entities.User, error) {
+	var v validator
+	v.check(params.TargetId != params.SourceId, "source_id", FieldErrSelfMerge)
+	for field, prefer := range params.Prefer {
+		if !slices.Contains(mergeableUserFields, field) {
+			v.check(false, "prefer."+field, FieldErrUnknownField)
+			continue
+		}
+		v.check(prefer == entities.MergePreferTarget || prefer == entities.MergePreferSource, "prefer."+field, FieldErrInvalidPrefer)
+	}
+	if err := v.err(); err != nil {
+		return entities.User{}, err
+	}
+
+	user, err := u.userRepo.MergeUsers(params.TargetId, params.SourceId, func(target, source entities.User) entities.User {
+		return mergeUserFields(target, source, params.Prefer)
+	})
+	if err != nil {
+		return entities.User{}, domainError(err)
+	}
+	invalidateStats(u.stats)
+	return user, nil
+}
+
+// mergeUserFields keeps target values unless source is preferred or target value is empty and target isnt preferred explicitly
+func mergeUserFields(target, source entities.User, prefer map[string]string) entities.User {
+	takeSource := func(field string, targetEmpty bool) bool {
+		return prefer[field] == entities.MergePreferSource || (targetEmpty && prefer[field] != entities.MergePreferTarget)
+	}
+
+	merged := target
+	if takeSource("name", target.Name == "") {
+		merged.Name = source.Name
+	}
+	if takeSource("surname", target.Surname == "") {
+		merged.Surname = source.Surname
+	}
+	if takeSource("patronymic", target.Patronymic == "") {
+		merged.Patronymic = source.Patronymic
+	}
+	if takeSource("age", target.Age == 0) {
+		merged.Age = source.Age
+	}
+	if takeSource("gender", target.Gender == "") {
+		merged.Gender = source.Gender
+	}
+	if takeSource("nationality", target.Nationality == "") {
+		merged.Nationality = source.Nationality
+	}
+	// attributes are merged by keys, prefer applies to keys that both users have
+	merged.Attributes = make(entities.Attributes, len(target.Attributes)+len(source.Attributes))
+	for key, value := range target.Attributes {
+		merged.Attributes[key] = value
+	}
+	for key, value := range source.Attributes {
+		if _, ok := target.Attributes[key]; !ok || prefer["attributes"] == entities.MergePreferSource {
+			merged.Attributes[key] = value
+		}
+	}
+	return merged
+}
+
+func duplicateMatches(a, b entities.User) []string {
+	matches := []string{}
+	if a.NameKey == b.NameKey {
+		matches = append(matches, "name")
+	}
+	if a.SurnameKey == b.SurnameKey {
+		matches = append(matches, "surname")
+	}
+	if a.PatronymicKey == b.PatronymicKey {
+		matches = append(matches, "patronymic")
+	}
+	if a.Age-b.Age <= 1 && b.Age-a.Age <= 1 {
+		matches = append(matches, "age")
+	}
+	if a.Gender == b.Gender {
+		matches = append(matches, "gender")
+	}
+	if a.Nationality == b.Nationality {
+		matches = append(matches, "nationality")
+	}
+	return matches
+}
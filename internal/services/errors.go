@@ -0,0 +1,232 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Util787/user-manager-api/internal/repository"
+)
+
+// domain errors returned by services, callers should check them with errors.Is and errors.As
+var (
+	// ErrNotFound is wrapped by errors about users, groups, memberships and contacts that dont exist or users that are deleted
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is wrapped by errors about changes that conflict with other users or groups, like equal full names or emails
+	ErrConflict = errors.New("conflict")
+	// ErrEnrichmentFailed is wrapped by errors of external apis that fill age, gender and nationality
+	ErrEnrichmentFailed = errors.New("enrichment failed")
+)
+
+// codes of field errors, clients and message catalogs should rely on them instead of messages
+const (
+	FieldErrNameTooShort            = "name_too_short"
+	FieldErrNameTooLong             = "name_too_long"
+	FieldErrNameInvalidChars        = "name_invalid_chars"
+	FieldErrNameMixedScripts        = "name_mixed_scripts"
+	FieldErrNameInvalidSeparators   = "name_invalid_separators"
+	FieldErrNameNotTitleCase        = "name_not_title_case"
+	FieldErrNameNotCapitalized      = "name_not_capitalized"
+	FieldErrInvalidGender           = "invalid_gender"
+	FieldErrRequiredGender          = "required_gender"
+	FieldErrNegativeAge             = "negative_age"
+	FieldErrNoFields                = "no_fields"
+	FieldErrSelfMerge               = "self_merge"
+	FieldErrUnknownField            = "unknown_field"
+	FieldErrInvalidPrefer           = "invalid_prefer"
+	FieldErrRequired                = "required"
+	FieldErrInvalidType             = "invalid_type"
+	FieldErrReadOnly                = "read_only"
+	FieldErrNotInEnum               = "not_in_enum"
+	FieldErrPatternMismatch         = "pattern_mismatch"
+	FieldErrInvalidAttributeName    = "invalid_attribute_name"
+	FieldErrInvalidAttributeType    = "invalid_attribute_type"
+	FieldErrInvalidPattern          = "invalid_pattern"
+	FieldErrPatternNotString        = "pattern_not_string"
+	FieldErrInvalidTag              = "invalid_tag"
+	FieldErrTagAddedAndRemoved      = "tag_added_and_removed"
+	FieldErrParentGroupNotFound     = "parent_group_not_found"
+	FieldErrGroupCycle              = "group_cycle"
+	FieldErrInvalidGroupRole        = "invalid_group_role"
+	FieldErrInvalidEmail            = "invalid_email"
+	FieldErrInvalidPhone            = "invalid_phone"
+	FieldErrInvalidContactType      = "invalid_contact_type"
+	FieldErrInvalidVerificationCode = "invalid_verification_code"
+	FieldErrNotAllowedInBulk        = "not_allowed_in_bulk"
+
+	// codes of invalid query params and filters, field of these errors is the name of the param
+	FieldErrInvalidMatch          = "invalid_match"
+	FieldErrInvalidNationality    = "invalid_nationality"
+	FieldErrNotNonNegativeInt     = "not_non_negative_int"
+	FieldErrInvalidAgeRange       = "invalid_age_range"
+	FieldErrInvalidTime           = "invalid_time"
+	FieldErrNotBoolean            = "not_boolean"
+	FieldErrInvalidTagMode        = "invalid_tag_mode"
+	FieldErrNoTags                = "no_tags"
+	FieldErrInvalidIds            = "invalid_ids"
+	FieldErrNoBulkFilter          = "no_bulk_filter"
+	FieldErrTooManyBounds         = "too_many_bounds"
+	FieldErrInvalidBound          = "invalid_bound"
+	FieldErrBoundsNotAscending    = "bounds_not_ascending"
+	FieldErrNoAttributeName       = "no_attribute_name"
+	FieldErrUnknownAttribute      = "unknown_attribute"
+	FieldErrInvalidAttributeValue = "invalid_attribute_value"
+
+	// codes of filter expression errors, positions are 1-based
+	FieldErrExprTooLong            = "expr_too_long"
+	FieldErrExprUnterminatedString = "expr_unterminated_string"
+	FieldErrExprUnexpectedBang     = "expr_unexpected_bang"
+	FieldErrExprUnexpectedToken    = "expr_unexpected_token"
+	FieldErrExprUnexpectedEnd      = "expr_unexpected_end"
+	FieldErrExprUnknownField       = "expr_unknown_field"
+	FieldErrExprTooManyComparisons = "expr_too_many_comparisons"
+	FieldErrExprOrderOfText        = "expr_order_of_text"
+	FieldErrExprContainsNotText    = "expr_contains_not_text"
+	FieldErrExprListTooLong        = "expr_list_too_long"
+	FieldErrExprNotInteger         = "expr_not_integer"
+	FieldErrExprNotDate            = "expr_not_date"
+	FieldErrExprNotString          = "expr_not_string"
+)
+
+// english messages of field error codes, some of them are formats for FieldError.Args
+var fieldErrorMessages = map[string]string{
+	FieldErrNameTooShort:            "must be at least %d characters long",
+	FieldErrNameTooLong:             "must be at most %d characters long",
+	FieldErrNameInvalidChars:        "can contain only letters of allowed scripts and separators between them",
+	FieldErrNameMixedScripts:        "must not mix letters of different scripts",
+	FieldErrNameInvalidSeparators:   "must start and end with a letter and have only one separator between letters",
+	FieldErrNameNotTitleCase:        "every part must start with a capital letter followed by lowercase letters",
+	FieldErrNameNotCapitalized:      "must start with a capital letter",
+	FieldErrInvalidGender:           "must be male or female",
+	FieldErrRequiredGender:          "is required and must be male or female",
+	FieldErrNegativeAge:             "must not be negative",
+	FieldErrNoFields:                "no fields to update",
+	FieldErrSelfMerge:               "user cant be merged into itself",
+	FieldErrUnknownField:            "unknown field",
+	FieldErrInvalidPrefer:           "must be target or source",
+	FieldErrRequired:                "is required",
+	FieldErrInvalidType:             "has invalid type",
+	FieldErrReadOnly:                "cant be changed",
+	FieldErrNotInEnum:               "must be one of: %s",
+	FieldErrPatternMismatch:         "must match pattern %s",
+	FieldErrInvalidAttributeName:    "must start with a lowercase latin letter followed by lowercase latin letters, digits or underscores, at most 63 characters",
+	FieldErrInvalidAttributeType:    "must be string, number or boolean",
+	FieldErrInvalidPattern:          "is not a valid regular expression",
+	FieldErrPatternNotString:        "can be set only for string attributes",
+	FieldErrInvalidTag:              "must start with a lowercase letter or digit followed by lowercase letters, digits, _ or -, at most 50 characters",
+	FieldErrTagAddedAndRemoved:      "cant be added and removed at once",
+	FieldErrParentGroupNotFound:     "group doesnt exist",
+	FieldErrGroupCycle:              "cant be the group itself or its subgroup",
+	FieldErrInvalidGroupRole:        "must be owner, admin or member",
+	FieldErrInvalidEmail:            "must be a valid email address without display name",
+	FieldErrInvalidPhone:            "must be a phone number in international format, like +79991234567",
+	FieldErrInvalidContactType:      "must be one of: %s",
+	FieldErrInvalidVerificationCode: "is wrong or expired, request a new code",
+	FieldErrNotAllowedInBulk:        "cant be changed in bulk update, update users one by one",
+
+	FieldErrInvalidMatch:          "can be only prefix, exact or contains",
+	FieldErrInvalidNationality:    "should be comma separated two letter country codes",
+	FieldErrNotNonNegativeInt:     "should be not negative number",
+	FieldErrInvalidAgeRange:       "must not be greater than age_lte",
+	FieldErrInvalidTime:           "should be a date (2006-01-02) or RFC 3339 time",
+	FieldErrNotBoolean:            "should be true or false",
+	FieldErrInvalidTagMode:        "can be only any or all",
+	FieldErrNoTags:                "should be comma separated tags",
+	FieldErrInvalidIds:            "should be comma separated numbers",
+	FieldErrNoBulkFilter:          "at least one filter or ids must be provided",
+	FieldErrTooManyBounds:         "no more than %d bounds allowed",
+	FieldErrInvalidBound:          "bound %q is not a non-negative integer",
+	FieldErrBoundsNotAscending:    "bounds must be ascending",
+	FieldErrNoAttributeName:       "attribute filter should be attr.<name>=<values>",
+	FieldErrUnknownAttribute:      "unknown attribute",
+	FieldErrInvalidAttributeValue: "%q is not a %s value",
+
+	FieldErrExprTooLong:            "is longer than %d characters",
+	FieldErrExprUnterminatedString: "has unterminated string at %d",
+	FieldErrExprUnexpectedBang:     `has unexpected "!" at %d, use "not" or "!="`,
+	FieldErrExprUnexpectedToken:    "has unexpected %q at %d",
+	FieldErrExprUnexpectedEnd:      "has unexpected end of expression",
+	FieldErrExprUnknownField:       "has unknown field %q at %d",
+	FieldErrExprTooManyComparisons: "has more than %d comparisons",
+	FieldErrExprOrderOfText:        "%s cant be used with text field %s at %d",
+	FieldErrExprContainsNotText:    "~ can be used only with text fields at %d",
+	FieldErrExprListTooLong:        "list of %s is longer than %d",
+	FieldErrExprNotInteger:         "%s must be compared with integer at %d",
+	FieldErrExprNotDate:            "%s must be compared with quoted date (2006-01-02) or RFC 3339 time at %d",
+	FieldErrExprNotString:          "%s must be compared with quoted string at %d",
+}
+
+// FieldError describes why one field of input is invalid, Field is empty if the whole input is invalid
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// values of the message format, like length limits
+	Args []any `json:"-"`
+}
+
+func newFieldError(field, code string, args ...any) FieldError {
+	message := fieldErrorMessages[code]
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	return FieldError{Field: field, Code: code, Message: message, Args: args}
+}
+
+// NewParamError returns *ValidationError of one invalid query param, handlers use it for params they parse themselves
+func NewParamError(param, code string, args ...any) error {
+	return &ValidationError{Fields: []FieldError{newFieldError(param, code, args...)}}
+}
+
+// ValidationError is returned when input breaks validation rules, all invalid fields are listed at once
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		if f.Field == "" {
+			parts[i] = f.Message
+			continue
+		}
+		parts[i] = f.Field + " " + f.Message
+	}
+	return "validation failed: " + strings.Join(parts, ", ")
+}
+
+// joinValidationErrors lists fields of all validation errors in one error, other errors are returned as is
+func joinValidationErrors(errs ...error) error {
+	var fields []FieldError
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			return err
+		}
+		fields = append(fields, validationErr.Fields...)
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: fields}
+}
+
+// domainError wraps repository errors into ErrNotFound and ErrConflict, other errors are returned as is
+func domainError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, repository.ErrUserNotFound), errors.Is(err, repository.ErrAttributeNotFound), errors.Is(err, sql.ErrNoRows),
+		errors.Is(err, repository.ErrGroupNotFound), errors.Is(err, repository.ErrGroupMemberNotFound), errors.Is(err, repository.ErrContactNotFound):
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case errors.Is(err, repository.ErrUserExists), errors.Is(err, repository.ErrAttributeExists),
+		errors.Is(err, repository.ErrGroupExists), errors.Is(err, repository.ErrGroupHasSubgroups), errors.Is(err, repository.ErrContactExists):
+		return fmt.Errorf("%w: %w", ErrConflict, err)
+	default:
+		return err
+	}
+}
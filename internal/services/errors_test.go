@@ -0,0 +1,57 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Util787/user-manager-api/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainError(t *testing.T) {
+	otherErr := errors.New("db error")
+
+	tests := []struct {
+		testname    string
+		err         error
+		expectedErr error
+	}{
+		{testname: "Nil", err: nil, expectedErr: nil},
+		{testname: "User not found", err: repository.ErrUserNotFound, expectedErr: ErrNotFound},
+		{testname: "Wrapped user not found", err: fmt.Errorf("merge: %w", repository.ErrUserNotFound), expectedErr: ErrNotFound},
+		{testname: "No rows", err: sql.ErrNoRows, expectedErr: ErrNotFound},
+		{testname: "Attribute not found", err: repository.ErrAttributeNotFound, expectedErr: ErrNotFound},
+		{testname: "Group not found", err: repository.ErrGroupNotFound, expectedErr: ErrNotFound},
+		{testname: "Member not found", err: repository.ErrGroupMemberNotFound, expectedErr: ErrNotFound},
+		{testname: "Contact not found", err: repository.ErrContactNotFound, expectedErr: ErrNotFound},
+		{testname: "User exists", err: fmt.Errorf("%w: Key (name_norm)", repository.ErrUserExists), expectedErr: ErrConflict},
+		{testname: "Attribute exists", err: repository.ErrAttributeExists, expectedErr: ErrConflict},
+		{testname: "Group exists", err: repository.ErrGroupExists, expectedErr: ErrConflict},
+		{testname: "Group has subgroups", err: repository.ErrGroupHasSubgroups, expectedErr: ErrConflict},
+		{testname: "Contact exists", err: repository.ErrContactExists, expectedErr: ErrConflict},
+		{testname: "Other error", err: otherErr, expectedErr: otherErr},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			err := domainError(test.err)
+
+			if test.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, test.expectedErr)
+			// repository error stays in the chain for logs and errors.Is
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestDomainErrorKeepsOtherErrors(t *testing.T) {
+	err := domainError(errors.New("db error"))
+
+	assert.NotErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrConflict)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/repository"
+)
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatJSON   = "json"
+)
+
+var ErrUnsupportedExportFormat = errors.New("unsupported export format, must be csv, ndjson or json")
+
+// same column names as in import so exported file can be imported back
+var exportCSVHeader = []string{"id", "created_at", "updated_at", "name", "surname", "patronymic", "age", "gender", "nationality"}
+
+type exportService struct {
+	userRepo repository.UserRepository
+	// definitions of custom attributes for attr filters
+	attrRepo repository.AttributeRepository
+}
+
+func NewExportService(repo repository.UserRepository, attrRepo repository.AttributeRepository) ExportService {
+	return &exportService{userRepo: repo, attrRepo: attrRepo}
+}
+
+// ExportUsers writes users to w row by row while they are read from db, nothing is written if format is unsupported
+func (e *exportService) ExportUsers(ctx context.Context, w io.Writer, format string, filter entities.UserFilter) error {
+	filter, err := typeFilter(e.attrRepo, filter)
+	if err != nil {
+		return err
+	}
+
+	buffered := bufio.NewWriter(w)
+	switch format {
+	case ExportFormatCSV:
+		err = e.exportCSV(ctx, buffered, filter)
+	case ExportFormatNDJSON:
+		err = e.exportNDJSON(ctx, buffered, filter)
+	case ExportFormatJSON:
+		err = e.exportJSON(ctx, buffered, filter)
+	default:
+		return ErrUnsupportedExportFormat
+	}
+	if err != nil {
+		return err
+	}
+
+	return buffered.Flush()
+}
+
+func (e *exportService) exportCSV(ctx context.Context, w io.Writer, filter entities.UserFilter) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write(exportCSVHeader)
+	if err != nil {
+		return err
+	}
+
+	err = e.userRepo.StreamUsers(ctx, filter, func(user entities.User) error {
+		return writer.Write([]string{
+			strconv.Itoa(int(user.Id)),
+			user.Created_at.Format(time.RFC3339),
+			user.Updated_at.Format(time.RFC3339),
+			user.Name,
+			user.Surname,
+			user.Patronymic,
+			strconv.Itoa(user.Age),
+			user.Gender,
+			user.Nationality,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func (e *exportService) exportNDJSON(ctx context.Context, w io.Writer, filter entities.UserFilter) error {
+	// Encode adds new line after every value
+	encoder := json.NewEncoder(w)
+	return e.userRepo.StreamUsers(ctx, filter, func(user entities.User) error {
+		return encoder.Encode(user)
+	})
+}
+
+func (e *exportService) exportJSON(ctx context.Context, w io.Writer, filter entities.UserFilter) error {
+	_, err := io.WriteString(w, "[")
+	if err != nil {
+		return err
+	}
+
+	first := true
+	err = e.userRepo.StreamUsers(ctx, filter, func(user entities.User) error {
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		data, err := json.Marshal(user)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]")
+	return err
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Util787/user-manager-api/internal/repository"
+)
+
+var ErrInvalidFields = errors.New("invalid fields")
+
+// ParseFields parses comma separated json names of user fields, repeated fields are ignored
+//
+// Example: "id,name,surname"
+func ParseFields(fields string) ([]string, error) {
+	if strings.TrimSpace(fields) == "" {
+		return nil, nil
+	}
+
+	var parsed []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if !repository.IsSelectableUserColumn(field) {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidFields, field)
+		}
+		if !slices.Contains(parsed, field) {
+			parsed = append(parsed, field)
+		}
+	}
+	return parsed, nil
+}
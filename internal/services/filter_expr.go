@@ -0,0 +1,368 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/repository"
+)
+
+// ErrInvalidFilterExpr is returned by ParseFilterExpr for any syntax or validation error
+var ErrInvalidFilterExpr = repository.ErrInvalidFilterExpr
+
+const (
+	maxFilterExprLength      = 2000
+	maxFilterExprComparisons = 50
+	maxFilterExprListSize    = 100
+)
+
+// ParseFilterExpr parses filter expression into AST, fields and value types are checked against repository whitelist.
+// Empty expression returns nil.
+//
+// Grammar, keywords are case insensitive:
+//
+//	expr       = and {"or" and}
+//	and        = unary {"and" unary}
+//	unary      = "not" unary | "(" expr ")" | comparison
+//	comparison = field ("==" | "=" | "!=" | ">" | ">=" | "<" | "<=" | "~") value | field ["not"] "in" "[" value {"," value} "]"
+//	value      = number | "string" | 'string'
+//
+// Example: age>30 and (gender=="female" or nationality in ["BY","UA"])
+func ParseFilterExpr(expr string) (entities.FilterExpr, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+	if len(expr) > maxFilterExprLength {
+		return nil, filterExprError(FieldErrExprTooLong, maxFilterExprLength)
+	}
+
+	tokens, err := lexFilterExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.unexpected(tok)
+	}
+
+	return node, nil
+}
+
+// filterExprError is a validation error of filter param with a typed code, it wraps ErrInvalidFilterExpr
+func filterExprError(code string, args ...any) error {
+	return fmt.Errorf("%w: %w", ErrInvalidFilterExpr, NewParamError("filter", code, args...))
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenComma
+)
+
+var punctuationTokens = map[rune]tokenKind{'(': tokenLParen, ')': tokenRParen, '[': tokenLBracket, ']': tokenRBracket, ',': tokenComma}
+
+type filterToken struct {
+	kind tokenKind
+	text string
+	// 1-based position in expression for error messages
+	pos int
+}
+
+func lexFilterExpr(expr string) ([]filterToken, error) {
+	runes := []rune(expr)
+	var tokens []filterToken
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == '[' || r == ']' || r == ',':
+			tokens = append(tokens, filterToken{kind: punctuationTokens[r], text: string(r), pos: pos})
+			i++
+		case r == '"' || r == '\'':
+			var b strings.Builder
+			closed := false
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					b.WriteRune(runes[i])
+					continue
+				}
+				if runes[i] == r {
+					closed = true
+					i++
+					break
+				}
+				b.WriteRune(runes[i])
+			}
+			if !closed {
+				return nil, filterExprError(FieldErrExprUnterminatedString, pos)
+			}
+			tokens = append(tokens, filterToken{kind: tokenString, text: b.String(), pos: pos})
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i++; i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.'); i++ {
+			}
+			tokens = append(tokens, filterToken{kind: tokenNumber, text: string(runes[start:i]), pos: pos})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i++; i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_'); i++ {
+			}
+			tokens = append(tokens, filterToken{kind: tokenIdent, text: string(runes[start:i]), pos: pos})
+		case strings.ContainsRune("=!<>~", r):
+			op := string(r)
+			if r != '~' && i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			i += len(op)
+
+			switch op {
+			case "!":
+				return nil, filterExprError(FieldErrExprUnexpectedBang, pos)
+			case "=":
+				op = entities.FilterEq
+			}
+			tokens = append(tokens, filterToken{kind: tokenOp, text: op, pos: pos})
+		default:
+			return nil, filterExprError(FieldErrExprUnexpectedToken, string(r), pos)
+		}
+	}
+
+	return append(tokens, filterToken{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
+
+type filterParser struct {
+	tokens      []filterToken
+	next        int
+	comparisons int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.next]
+}
+
+func (p *filterParser) advance() filterToken {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *filterParser) isKeyword(tok filterToken, keyword string) bool {
+	return tok.kind == tokenIdent && strings.EqualFold(tok.text, keyword)
+}
+
+func (p *filterParser) unexpected(tok filterToken) error {
+	if tok.kind == tokenEOF {
+		return filterExprError(FieldErrExprUnexpectedEnd)
+	}
+	return filterExprError(FieldErrExprUnexpectedToken, tok.text, tok.pos)
+}
+
+func (p *filterParser) parseOr() (entities.FilterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(p.peek(), entities.FilterOr) {
+		p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = entities.FilterLogical{Op: entities.FilterOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (entities.FilterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword(p.peek(), entities.FilterAnd) {
+		p.advance()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = entities.FilterLogical{Op: entities.FilterAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (entities.FilterExpr, error) {
+	tok := p.peek()
+
+	if p.isKeyword(tok, "not") {
+		p.advance()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return entities.FilterNot{Expr: inner}, nil
+	}
+
+	if tok.kind == tokenLParen {
+		p.advance()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.kind != tokenRParen {
+			return nil, p.unexpected(closing)
+		}
+		return inner, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (entities.FilterExpr, error) {
+	fieldTok := p.advance()
+	if fieldTok.kind != tokenIdent {
+		return nil, p.unexpected(fieldTok)
+	}
+	field := strings.ToLower(fieldTok.text)
+	kind, ok := repository.UserFilterFieldKind(field)
+	if !ok {
+		return nil, filterExprError(FieldErrExprUnknownField, fieldTok.text, fieldTok.pos)
+	}
+
+	p.comparisons++
+	if p.comparisons > maxFilterExprComparisons {
+		return nil, filterExprError(FieldErrExprTooManyComparisons, maxFilterExprComparisons)
+	}
+
+	opTok := p.advance()
+	switch {
+	case p.isKeyword(opTok, "in"):
+		values, err := p.parseList(field, kind)
+		if err != nil {
+			return nil, err
+		}
+		return entities.FilterComparison{Field: field, Op: entities.FilterIn, Value: values}, nil
+	case p.isKeyword(opTok, "not"):
+		if inTok := p.advance(); !p.isKeyword(inTok, "in") {
+			return nil, p.unexpected(inTok)
+		}
+		values, err := p.parseList(field, kind)
+		if err != nil {
+			return nil, err
+		}
+		return entities.FilterComparison{Field: field, Op: entities.FilterNotIn, Value: values}, nil
+	case opTok.kind == tokenOp:
+	default:
+		return nil, p.unexpected(opTok)
+	}
+
+	switch opTok.text {
+	case entities.FilterGt, entities.FilterGtOrEq, entities.FilterLt, entities.FilterLtOrEq:
+		if kind == repository.FilterKindString {
+			return nil, filterExprError(FieldErrExprOrderOfText, opTok.text, field, opTok.pos)
+		}
+	case entities.FilterContains:
+		if kind != repository.FilterKindString {
+			return nil, filterExprError(FieldErrExprContainsNotText, opTok.pos)
+		}
+	}
+
+	value, err := p.parseValue(field, kind)
+	if err != nil {
+		return nil, err
+	}
+	return entities.FilterComparison{Field: field, Op: opTok.text, Value: value}, nil
+}
+
+func (p *filterParser) parseList(field, kind string) ([]any, error) {
+	if tok := p.advance(); tok.kind != tokenLBracket {
+		return nil, p.unexpected(tok)
+	}
+
+	var values []any
+	for {
+		value, err := p.parseValue(field, kind)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		if len(values) > maxFilterExprListSize {
+			return nil, filterExprError(FieldErrExprListTooLong, field, maxFilterExprListSize)
+		}
+
+		tok := p.advance()
+		if tok.kind == tokenRBracket {
+			return values, nil
+		}
+		if tok.kind != tokenComma {
+			return nil, p.unexpected(tok)
+		}
+	}
+}
+
+// value is converted to the type of field: int (in int32 range), string or time.Time
+func (p *filterParser) parseValue(field, kind string) (any, error) {
+	tok := p.advance()
+
+	switch kind {
+	case repository.FilterKindInt:
+		// int fields are int4 in the database, bigger numbers would fail the query
+		if tok.kind == tokenNumber {
+			n, err := strconv.ParseInt(tok.text, 10, 32)
+			if err == nil {
+				return int(n), nil
+			}
+		}
+		if tok.kind == tokenEOF {
+			return nil, p.unexpected(tok)
+		}
+		return nil, filterExprError(FieldErrExprNotInteger, field, tok.pos)
+	case repository.FilterKindTime:
+		if tok.kind == tokenString {
+			if t, err := time.Parse(time.RFC3339, tok.text); err == nil {
+				return t, nil
+			}
+			if t, err := time.Parse(time.DateOnly, tok.text); err == nil {
+				return t, nil
+			}
+		}
+		if tok.kind == tokenEOF {
+			return nil, p.unexpected(tok)
+		}
+		return nil, filterExprError(FieldErrExprNotDate, field, tok.pos)
+	default:
+		if tok.kind == tokenString {
+			// country codes are stored in upper case, the same as in nationality query
+			if field == "nationality" {
+				return strings.ToUpper(strings.TrimSpace(tok.text)), nil
+			}
+			return tok.text, nil
+		}
+		if tok.kind == tokenEOF {
+			return nil, p.unexpected(tok)
+		}
+		return nil, filterExprError(FieldErrExprNotString, field, tok.pos)
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilterExpr(t *testing.T) {
+	cmp := func(field, op string, value any) entities.FilterComparison {
+		return entities.FilterComparison{Field: field, Op: op, Value: value}
+	}
+	and := func(left, right entities.FilterExpr) entities.FilterLogical {
+		return entities.FilterLogical{Op: entities.FilterAnd, Left: left, Right: right}
+	}
+	or := func(left, right entities.FilterExpr) entities.FilterLogical {
+		return entities.FilterLogical{Op: entities.FilterOr, Left: left, Right: right}
+	}
+
+	tests := []struct {
+		testname string
+		expr     string
+		expected entities.FilterExpr
+	}{
+		{testname: "Empty", expr: "  ", expected: nil},
+		{testname: "Single comparison", expr: "age>30", expected: cmp("age", entities.FilterGt, 30)},
+		{testname: "Single equals sign", expr: `gender = "male"`, expected: cmp("gender", entities.FilterEq, "male")},
+		{
+			testname: "And binds tighter than or",
+			expr:     `age>30 or age<18 and gender=="male"`,
+			expected: or(cmp("age", entities.FilterGt, 30), and(cmp("age", entities.FilterLt, 18), cmp("gender", entities.FilterEq, "male"))),
+		},
+		{
+			testname: "Parentheses",
+			expr:     `(age>30 or age<18) and gender=="male"`,
+			expected: and(or(cmp("age", entities.FilterGt, 30), cmp("age", entities.FilterLt, 18)), cmp("gender", entities.FilterEq, "male")),
+		},
+		{
+			testname: "Left associative",
+			expr:     "id>1 and id<9 and age>=18",
+			expected: and(and(cmp("id", entities.FilterGt, 1), cmp("id", entities.FilterLt, 9)), cmp("age", entities.FilterGtOrEq, 18)),
+		},
+		{
+			testname: "Not binds tighter than and",
+			expr:     `not age>30 and name~"al"`,
+			expected: and(entities.FilterNot{Expr: cmp("age", entities.FilterGt, 30)}, cmp("name", entities.FilterContains, "al")),
+		},
+		{
+			testname: "Double not",
+			expr:     "NOT not (age<=18)",
+			expected: entities.FilterNot{Expr: entities.FilterNot{Expr: cmp("age", entities.FilterLtOrEq, 18)}},
+		},
+		{
+			testname: "In list",
+			expr:     `nationality in ["BY", "UA"]`,
+			expected: cmp("nationality", entities.FilterIn, []any{"BY", "UA"}),
+		},
+		{
+			testname: "Not in list",
+			expr:     "id NOT IN [1,2,3]",
+			expected: cmp("id", entities.FilterNotIn, []any{1, 2, 3}),
+		},
+		{
+			testname: "Nationality is upper cased",
+			expr:     `nationality=="by" or nationality in [' ru ']`,
+			expected: or(cmp("nationality", entities.FilterEq, "BY"), cmp("nationality", entities.FilterIn, []any{"RU"})),
+		},
+		{
+			testname: "Single quotes and escapes",
+			expr:     `surname=='O\'Neil' or name=="say \"hi\" \\ bye"`,
+			expected: or(cmp("surname", entities.FilterEq, "O'Neil"), cmp("name", entities.FilterEq, `say "hi" \ bye`)),
+		},
+		{testname: "Negative number", expr: "age!=-1", expected: cmp("age", entities.FilterNotEq, -1)},
+		{testname: "Max int32", expr: "id==2147483647", expected: cmp("id", entities.FilterEq, 2147483647)},
+		{
+			testname: "Dates",
+			expr:     `created_at>="2025-01-02" and updated_at<"2025-01-02T10:00:00Z"`,
+			expected: and(
+				cmp("created_at", entities.FilterGtOrEq, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)),
+				cmp("updated_at", entities.FilterLt, time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)),
+			),
+		},
+		{testname: "Field is case insensitive", expr: `Name=="Ivan"`, expected: cmp("name", entities.FilterEq, "Ivan")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			expr, err := ParseFilterExpr(test.expr)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, expr)
+		})
+	}
+}
+
+func TestParseFilterExprErrors(t *testing.T) {
+	tests := []struct {
+		testname    string
+		expr        string
+		expectedErr string
+	}{
+		{testname: "Unknown field", expr: "age>1 and salary>10", expectedErr: `unknown field "salary" at 11`},
+		{testname: "Unterminated string", expr: `name=="Ivan`, expectedErr: "unterminated string at 7"},
+		{testname: "Bang without equals", expr: "!age", expectedErr: `unexpected "!" at 1`},
+		{testname: "Unexpected character", expr: "age>1 & age<9", expectedErr: `unexpected "&" at 7`},
+		{testname: "Unexpected token", expr: "age>1 age<9", expectedErr: `unexpected "age" at 7`},
+		{testname: "Unclosed parenthesis", expr: "(age>1", expectedErr: "unexpected end of expression"},
+		{testname: "Missing value", expr: "age>", expectedErr: "unexpected end of expression"},
+		{testname: "Not without in", expr: "id not [1]", expectedErr: `unexpected "[" at 8`},
+		{testname: "In without list", expr: `gender in "male"`, expectedErr: `unexpected "male" at 11`},
+		{testname: "Unclosed list", expr: "id in [1,2", expectedErr: "unexpected end of expression"},
+		{testname: "String for int field", expr: `age=="30"`, expectedErr: "age must be compared with integer at 6"},
+		{testname: "Fraction for int field", expr: "age==3.5", expectedErr: "age must be compared with integer at 6"},
+		{testname: "Int overflow", expr: "id == 99999999999", expectedErr: "id must be compared with integer at 7"},
+		{testname: "Int underflow", expr: "id in [1, -2147483649]", expectedErr: "id must be compared with integer at 11"},
+		{testname: "Unquoted string", expr: "gender==male", expectedErr: "gender must be compared with quoted string at 9"},
+		{testname: "Invalid date", expr: `created_at>"yesterday"`, expectedErr: "created_at must be compared with quoted date"},
+		{testname: "Order of text field", expr: `name>"A"`, expectedErr: "> cant be used with text field name at 5"},
+		{testname: "Contains for int field", expr: "age~1", expectedErr: "~ can be used only with text fields at 4"},
+		{testname: "Too long", expr: strings.Repeat(" ", maxFilterExprLength) + "age>1", expectedErr: "longer than 2000 characters"},
+		{testname: "Too many comparisons", expr: strings.Repeat("age>1 or ", maxFilterExprComparisons) + "age>1", expectedErr: "more than 50 comparisons"},
+		{testname: "Too long list", expr: "id in [" + strings.Repeat("1,", maxFilterExprListSize) + "1]", expectedErr: "list of id is longer than 100"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			_, err := ParseFilterExpr(test.expr)
+
+			require.ErrorIs(t, err, ErrInvalidFilterExpr)
+			assert.Contains(t, err.Error(), test.expectedErr)
+			// clients get typed code of filter param
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "filter", validationErr.Fields[0].Field)
+			assert.Contains(t, fieldErrorMessages, validationErr.Fields[0].Code)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/repository"
+	"golang.org/x/text/unicode/norm"
+)
+
+const maxGroupNameLength = 100
+
+var groupRoles = []string{entities.GroupRoleOwner, entities.GroupRoleAdmin, entities.GroupRoleMember}
+
+type groupService struct {
+	groupRepo repository.GroupRepository
+	userRepo  repository.UserRepository
+}
+
+func NewGroupService(groupRepo repository.GroupRepository, userRepo repository.UserRepository) GroupService {
+	return &groupService{groupRepo: groupRepo, userRepo: userRepo}
+}
+
+func (g *groupService) CreateGroup(params entities.CreateGroupParams) (entities.Group, error) {
+	params.Name = normalizeGroupText(params.Name)
+	params.Description = normalizeGroupText(params.Description)
+
+	var v validator
+	v.checkGroupName("name", params.Name)
+	if err := v.err(); err != nil {
+		return entities.Group{}, err
+	}
+
+	group, err := g.groupRepo.CreateGroup(params)
+	return group, groupError(err)
+}
+
+func (g *groupService) GetGroups(pageSize, page int, filter entities.GroupFilter) ([]entities.Group, int, error) {
+	return g.groupRepo.GetGroups(pageSize, page, filter)
+}
+
+func (g *groupService) GetGroupById(id int32) (entities.Group, error) {
+	group, err := g.groupRepo.GetGroupById(id)
+	return group, groupError(err)
+}
+
+func (g *groupService) UpdateGroup(id int32, params entities.UpdateGroupParams) (entities.Group, error) {
+	if params.Name == nil && params.Description == nil && params.ParentId == nil {
+		return entities.Group{}, &ValidationError{Fields: []FieldError{newFieldError("", FieldErrNoFields)}}
+	}
+
+	var v validator
+	if params.Name != nil {
+		name := normalizeGroupText(*params.Name)
+		params.Name = &name
+		v.checkGroupName("name", name)
+	}
+	if params.Description != nil {
+		description := normalizeGroupText(*params.Description)
+		params.Description = &description
+	}
+	if err := v.err(); err != nil {
+		return entities.Group{}, err
+	}
+
+	group, err := g.groupRepo.UpdateGroup(id, params)
+	return group, groupError(err)
+}
+
+func (g *groupService) DeleteGroup(id int32) error {
+	return groupError(g.groupRepo.DeleteGroup(id))
+}
+
+func (g *groupService) GetGroupMembers(groupId int32, recursive bool) ([]entities.GroupMember, error) {
+	members, err := g.groupRepo.GetGroupMembers(groupId, recursive)
+	if err != nil {
+		return nil, groupError(err)
+	}
+	if len(members) == 0 {
+		return members, nil
+	}
+
+	// in recursive listings a user can be a member of several subgroups
+	var ids []int32
+	for _, member := range members {
+		if !slices.Contains(ids, member.UserId) {
+			ids = append(ids, member.UserId)
+		}
+	}
+	users, err := g.userRepo.GetUsersByIds(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byId := make(map[int32]*entities.User, len(users))
+	for i := range users {
+		byId[users[i].Id] = &users[i]
+	}
+	// users deleted after members were selected are left without user
+	for i := range members {
+		members[i].User = byId[members[i].UserId]
+	}
+	return members, nil
+}
+
+func (g *groupService) SetGroupMember(groupId, userId int32, params entities.GroupMemberParams) (entities.GroupMember, error) {
+	role := strings.ToLower(strings.TrimSpace(params.Role))
+	if role == "" {
+		role = entities.GroupRoleMember
+	}
+
+	var v validator
+	v.check(slices.Contains(groupRoles, role), "role", FieldErrInvalidGroupRole)
+	if err := v.err(); err != nil {
+		return entities.GroupMember{}, err
+	}
+
+	member, err := g.groupRepo.SetGroupMember(groupId, userId, role)
+	return member, groupError(err)
+}
+
+func (g *groupService) RemoveGroupMember(groupId, userId int32) error {
+	return groupError(g.groupRepo.RemoveGroupMember(groupId, userId))
+}
+
+func (g *groupService) GetUserGroups(userId int32, recursive bool) ([]entities.UserGroup, error) {
+	groups, err := g.groupRepo.GetUserGroups(userId, recursive)
+	return groups, groupError(err)
+}
+
+// normalizeGroupText trims names and descriptions of groups, inner spaces are kept
+func normalizeGroupText(s string) string {
+	return strings.TrimSpace(norm.NFC.String(s))
+}
+
+// checkGroupName adds error to field if name is empty or too long, name must be normalized
+func (v *validator) checkGroupName(field, name string) {
+	if name == "" {
+		v.fields = append(v.fields, newFieldError(field, FieldErrRequired))
+		return
+	}
+	if utf8.RuneCountInString(name) > maxGroupNameLength {
+		v.fields = append(v.fields, newFieldError(field, FieldErrNameTooLong, maxGroupNameLength))
+	}
+}
+
+// groupError turns errors about parent of a group into validation errors, other errors are wrapped by domainError
+func groupError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrGroupParentNotFound):
+		return &ValidationError{Fields: []FieldError{newFieldError("parent_id", FieldErrParentGroupNotFound)}}
+	case errors.Is(err, repository.ErrGroupCycle):
+		return &ValidationError{Fields: []FieldError{newFieldError("parent_id", FieldErrGroupCycle)}}
+	default:
+		return domainError(err)
+	}
+}
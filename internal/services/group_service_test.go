@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// invalid input is rejected before the repository is called
+func TestGroupServiceValidation(t *testing.T) {
+	g := &groupService{}
+	blank := "  "
+	longName := strings.Repeat("a", maxGroupNameLength+1)
+
+	tests := []struct {
+		testname      string
+		call          func() error
+		expectedCodes []string
+	}{
+		{
+			testname: "Create with blank name",
+			call: func() error {
+				_, err := g.CreateGroup(entities.CreateGroupParams{Name: blank})
+				return err
+			},
+			expectedCodes: []string{FieldErrRequired},
+		},
+		{
+			testname: "Create with too long name",
+			call: func() error {
+				_, err := g.CreateGroup(entities.CreateGroupParams{Name: longName})
+				return err
+			},
+			expectedCodes: []string{FieldErrNameTooLong},
+		},
+		{
+			testname: "Update without fields",
+			call: func() error {
+				_, err := g.UpdateGroup(1, entities.UpdateGroupParams{})
+				return err
+			},
+			expectedCodes: []string{FieldErrNoFields},
+		},
+		{
+			testname: "Update with blank name",
+			call: func() error {
+				_, err := g.UpdateGroup(1, entities.UpdateGroupParams{Name: &blank})
+				return err
+			},
+			expectedCodes: []string{FieldErrRequired},
+		},
+		{
+			testname: "Set member with unknown role",
+			call: func() error {
+				_, err := g.SetGroupMember(1, 1, entities.GroupMemberParams{Role: "guest"})
+				return err
+			},
+			expectedCodes: []string{FieldErrInvalidGroupRole},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			err := test.call()
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			codes := make([]string, len(validationErr.Fields))
+			for i, f := range validationErr.Fields {
+				codes[i] = f.Code
+			}
+			assert.Equal(t, test.expectedCodes, codes)
+		})
+	}
+}
+
+func TestGroupError(t *testing.T) {
+	tests := []struct {
+		testname     string
+		err          error
+		expectedCode string
+		expectedErr  error
+	}{
+		{testname: "Missing parent", err: repository.ErrGroupParentNotFound, expectedCode: FieldErrParentGroupNotFound},
+		{testname: "Cycle", err: fmt.Errorf("move: %w", repository.ErrGroupCycle), expectedCode: FieldErrGroupCycle},
+		{testname: "Missing group", err: repository.ErrGroupNotFound, expectedErr: ErrNotFound},
+		{testname: "Missing membership", err: repository.ErrGroupMemberNotFound, expectedErr: ErrNotFound},
+		{testname: "Sibling with the same name", err: repository.ErrGroupExists, expectedErr: ErrConflict},
+		{testname: "Group with subgroups", err: repository.ErrGroupHasSubgroups, expectedErr: ErrConflict},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			err := groupError(test.err)
+
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Len(t, validationErr.Fields, 1)
+			assert.Equal(t, "parent_id", validationErr.Fields[0].Field)
+			assert.Equal(t, test.expectedCode, validationErr.Fields[0].Code)
+		})
+	}
+}
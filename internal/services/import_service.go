@@ -0,0 +1,271 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/namenorm"
+	"github.com/Util787/user-manager-api/internal/repository"
+)
+
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+)
+
+// ndjson lines longer than this stop the import with an error
+const maxImportLineSize = 64 * 1024
+
+var ErrUnsupportedImportFormat = errors.New("unsupported import format, must be csv or ndjson")
+
+// codes of rejected rows, they are the same as problem codes of the api for the same errors
+const (
+	RejectCodeInvalidRow       = "invalid_body"
+	RejectCodeValidationFailed = "validation_failed"
+	RejectCodeConflict         = "conflict"
+	RejectCodeEnrichmentFailed = "enrichment_failed"
+	RejectCodeInternal         = "internal_error"
+)
+
+type importService struct {
+	userRepo    repository.UserRepository
+	infoRequest InfoRequestService
+	// can be nil if there is no cache, like in import command
+	stats StatsService
+	names NamePolicy
+	// definitions of custom attributes
+	attrRepo repository.AttributeRepository
+}
+
+func NewImportService(repo repository.UserRepository, infoRequest InfoRequestService, stats StatsService, names NamePolicy, attrRepo repository.AttributeRepository) ImportService {
+	return &importService{userRepo: repo, infoRequest: infoRequest, stats: stats, names: names, attrRepo: attrRepo}
+}
+
+// ImportUsers reads records one by one and creates users, invalid rows dont stop the import and are reported with reasons.
+// Error is returned if the input cant be read to the end, then the report has rows handled before the error, they stay imported
+func (i *importService) ImportUsers(r io.Reader, format string, enrich bool) (entities.ImportReport, error) {
+	if format != ImportFormatCSV && format != ImportFormatNDJSON {
+		return entities.ImportReport{}, ErrUnsupportedImportFormat
+	}
+	// attribute definitions are read once, rows are checked against the definitions at the start of the import
+	defs, err := i.attrRepo.GetDefinitions()
+	if err != nil {
+		return entities.ImportReport{}, err
+	}
+
+	report := entities.ImportReport{
+		Accepted: []entities.ImportedRow{},
+		Rejected: []entities.RejectedRow{},
+	}
+
+	handleRecord := func(row int, record entities.ImportRecord, parseErr error) {
+		report.Total++
+		if parseErr != nil {
+			report.Rejected = append(report.Rejected, entities.RejectedRow{Row: row, Code: RejectCodeInvalidRow, Reason: parseErr.Error()})
+			return
+		}
+
+		id, err := i.importRecord(record, defs, enrich)
+		if err != nil {
+			report.Rejected = append(report.Rejected, entities.RejectedRow{Row: row, Code: rejectCode(err), Reason: err.Error()})
+			return
+		}
+		report.Accepted = append(report.Accepted, entities.ImportedRow{Row: row, Id: id})
+	}
+
+	switch format {
+	case ImportFormatCSV:
+		err = readCSVRecords(r, handleRecord)
+	case ImportFormatNDJSON:
+		err = readNDJSONRecords(r, handleRecord)
+	}
+	if len(report.Accepted) > 0 {
+		invalidateStats(i.stats)
+	}
+	return report, err
+}
+
+// same rules as for creating a single user
+func (i *importService) importRecord(record entities.ImportRecord, defs []entities.AttributeDefinition, enrich bool) (int32, error) {
+	record.Name = namenorm.Display(record.Name)
+	record.Surname = namenorm.Display(record.Surname)
+	record.Patronymic = namenorm.Display(record.Patronymic)
+	record.Gender = strings.ToLower(strings.TrimSpace(record.Gender))
+	record.Nationality = strings.ToUpper(strings.TrimSpace(record.Nationality))
+
+	attrs, attrsErr := validateAttributes(defs, record.Attributes)
+	if err := joinValidationErrors(validateImportRecord(i.names, record), attrsErr); err != nil {
+		return 0, err
+	}
+
+	if enrich && (record.Age == nil || record.Gender == "" || record.Nationality == "") {
+		age, gender, nationality, err := i.infoRequest.RequestAdditionalInfo(record.Name)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %w", ErrEnrichmentFailed, err)
+		}
+		if record.Age == nil {
+			record.Age = &age
+		}
+		if record.Gender == "" {
+			record.Gender = gender
+		}
+		if record.Nationality == "" {
+			record.Nationality = nationality
+		}
+	}
+
+	if !IsValidGender(record.Gender) {
+		return 0, &ValidationError{Fields: []FieldError{newFieldError("gender", FieldErrRequiredGender)}}
+	}
+
+	user := entities.User{
+		Name:        record.Name,
+		Surname:     record.Surname,
+		Patronymic:  record.Patronymic,
+		Gender:      record.Gender,
+		Nationality: record.Nationality,
+		Attributes:  attrs,
+	}
+	if record.Age != nil {
+		user.Age = *record.Age
+	}
+
+	createdUser, err := i.userRepo.CreateUser(user)
+	if err != nil {
+		return 0, domainError(err)
+	}
+	return createdUser.Id, nil
+}
+
+// rejectCode maps error of a row the same way as the api maps errors of a single user
+func rejectCode(err error) string {
+	var validationErr *ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return RejectCodeValidationFailed
+	case errors.Is(err, ErrConflict):
+		return RejectCodeConflict
+	case errors.Is(err, ErrEnrichmentFailed):
+		return RejectCodeEnrichmentFailed
+	default:
+		return RejectCodeInternal
+	}
+}
+
+// gender and age can be empty, they are filled by enrichment
+func validateImportRecord(policy NamePolicy, record entities.ImportRecord) error {
+	var v validator
+	v.checkName(policy, "name", record.Name)
+	v.checkName(policy, "surname", record.Surname)
+	if record.Patronymic != "" {
+		v.checkName(policy, "patronymic", record.Patronymic)
+	}
+	v.check(record.Gender == "" || IsValidGender(record.Gender), "gender", FieldErrInvalidGender)
+	v.check(record.Age == nil || *record.Age >= 0, "age", FieldErrNegativeAge)
+	return v.err()
+}
+
+// first row is a header, name and surname columns are required, attributes column is a json object.
+// Rows are numbered like in a spreadsheet so header is row 1
+func readCSVRecords(r io.Reader, handle func(row int, record entities.ImportRecord, parseErr error)) error {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for idx, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))] = idx
+	}
+	if _, ok := columns["name"]; !ok {
+		return errors.New("csv header must contain name column")
+	}
+	if _, ok := columns["surname"]; !ok {
+		return errors.New("csv header must contain surname column")
+	}
+
+	get := func(fields []string, column string) string {
+		idx, ok := columns[column]
+		if !ok {
+			return ""
+		}
+		return fields[idx]
+	}
+
+	row := 1
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		row++
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				handle(row, entities.ImportRecord{}, err)
+				continue
+			}
+			return err
+		}
+
+		record := entities.ImportRecord{
+			Name:        get(fields, "name"),
+			Surname:     get(fields, "surname"),
+			Patronymic:  get(fields, "patronymic"),
+			Gender:      get(fields, "gender"),
+			Nationality: get(fields, "nationality"),
+		}
+		if ageStr := strings.TrimSpace(get(fields, "age")); ageStr != "" {
+			age, err := strconv.Atoi(ageStr)
+			if err != nil {
+				handle(row, entities.ImportRecord{}, errors.New("age should be number"))
+				continue
+			}
+			record.Age = &age
+		}
+		if attrsStr := strings.TrimSpace(get(fields, "attributes")); attrsStr != "" {
+			if err := json.Unmarshal([]byte(attrsStr), &record.Attributes); err != nil {
+				handle(row, entities.ImportRecord{}, errors.New("attributes should be json object"))
+				continue
+			}
+		}
+
+		handle(row, record, nil)
+	}
+}
+
+// every non empty line is a json object, rows are numbered by lines
+func readNDJSONRecords(r io.Reader, handle func(row int, record entities.ImportRecord, parseErr error)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxImportLineSize)
+
+	row := 0
+	for scanner.Scan() {
+		row++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var record entities.ImportRecord
+		err := json.Unmarshal([]byte(line), &record)
+		if err != nil {
+			handle(row, entities.ImportRecord{}, fmt.Errorf("invalid json: %w", err))
+			continue
+		}
+
+		handle(row, record, nil)
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/repository"
+	"github.com/Util787/user-manager-api/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportUsers(t *testing.T) {
+	policy, err := NewNamePolicy(defaultNamePolicyConfig)
+	require.NoError(t, err)
+	userRepo := mocks.NewMockUserRepository(t)
+	attrRepo := mocks.NewMockAttributeRepository(t)
+	s := NewImportService(userRepo, nil, nil, policy, attrRepo)
+
+	attrRepo.EXPECT().GetDefinitions().Return(testAttributeDefinitions, nil).Once()
+	userRepo.EXPECT().CreateUser(entities.User{
+		Name: "Ivan", Surname: "Petrov", Gender: "male", Age: 30,
+		Attributes: entities.Attributes{"department": "sales", "remote": true},
+	}).Return(entities.User{Id: 7}, nil).Once()
+	userRepo.EXPECT().CreateUser(entities.User{
+		Name: "Ivan", Surname: "Petrov", Gender: "male", Age: 31,
+		Attributes: entities.Attributes{"department": "support"},
+	}).Return(entities.User{}, repository.ErrUserExists).Once()
+
+	input := strings.Join([]string{
+		`name,surname,age,gender,attributes`,
+		`Ivan,Petrov,30,male,"{""department"":""sales"",""remote"":true,""level"":null}"`,
+		`Anna,Petrova,25,female,"{""remote"":""yes""}"`,
+		`Oleg,Sidorov,40,male,not json`,
+		`Ivan,Petrov,31,male,"{""department"":""support""}"`,
+	}, "\n")
+
+	report, err := s.ImportUsers(strings.NewReader(input), ImportFormatCSV, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, []entities.ImportedRow{{Row: 2, Id: 7}}, report.Accepted)
+	require.Len(t, report.Rejected, 3)
+	assert.Equal(t, 3, report.Rejected[0].Row)
+	assert.Equal(t, RejectCodeValidationFailed, report.Rejected[0].Code)
+	assert.Contains(t, report.Rejected[0].Reason, "attributes.department")
+	assert.Contains(t, report.Rejected[0].Reason, "attributes.remote")
+	assert.Equal(t, entities.RejectedRow{Row: 4, Code: RejectCodeInvalidRow, Reason: "attributes should be json object"}, report.Rejected[1])
+	// duplicates have the same code as conflicts of the api
+	assert.Equal(t, 5, report.Rejected[2].Row)
+	assert.Equal(t, RejectCodeConflict, report.Rejected[2].Code)
+}
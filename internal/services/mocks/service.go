@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"io"
 
 	"github.com/Util787/user-manager-api/entities"
 	mock "github.com/stretchr/testify/mock"
@@ -38,155 +39,326 @@ func (_m *MockUserService) EXPECT() *MockUserService_Expecter {
 	return &MockUserService_Expecter{mock: &_m.Mock}
 }
 
-// CreateUser provides a mock function for the type MockUserService
-func (_mock *MockUserService) CreateUser(params entities.User) (entities.User, error) {
-	ret := _mock.Called(params)
+// AddUserTags provides a mock function for the type MockUserService
+func (_mock *MockUserService) AddUserTags(id int32, tags []string) (entities.UserTags, error) {
+	ret := _mock.Called(id, tags)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for AddUserTags")
 	}
 
-	var r0 entities.User
+	var r0 entities.UserTags
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(entities.User) (entities.User, error)); ok {
-		return returnFunc(params)
+	if returnFunc, ok := ret.Get(0).(func(int32, []string) (entities.UserTags, error)); ok {
+		return returnFunc(id, tags)
 	}
-	if returnFunc, ok := ret.Get(0).(func(entities.User) entities.User); ok {
-		r0 = returnFunc(params)
+	if returnFunc, ok := ret.Get(0).(func(int32, []string) entities.UserTags); ok {
+		r0 = returnFunc(id, tags)
 	} else {
-		r0 = ret.Get(0).(entities.User)
+		r0 = ret.Get(0).(entities.UserTags)
 	}
-	if returnFunc, ok := ret.Get(1).(func(entities.User) error); ok {
-		r1 = returnFunc(params)
+	if returnFunc, ok := ret.Get(1).(func(int32, []string) error); ok {
+		r1 = returnFunc(id, tags)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUserService_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
-type MockUserService_CreateUser_Call struct {
+// MockUserService_AddUserTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddUserTags'
+type MockUserService_AddUserTags_Call struct {
 	*mock.Call
 }
 
-// CreateUser is a helper method to define mock.On call
-//   - params entities.User
-func (_e *MockUserService_Expecter) CreateUser(params interface{}) *MockUserService_CreateUser_Call {
-	return &MockUserService_CreateUser_Call{Call: _e.mock.On("CreateUser", params)}
+// AddUserTags is a helper method to define mock.On call
+//   - id int32
+//   - tags []string
+func (_e *MockUserService_Expecter) AddUserTags(id interface{}, tags interface{}) *MockUserService_AddUserTags_Call {
+	return &MockUserService_AddUserTags_Call{Call: _e.mock.On("AddUserTags", id, tags)}
 }
 
-func (_c *MockUserService_CreateUser_Call) Run(run func(params entities.User)) *MockUserService_CreateUser_Call {
+func (_c *MockUserService_AddUserTags_Call) Run(run func(id int32, tags []string)) *MockUserService_AddUserTags_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 entities.User
+		var arg0 int32
 		if args[0] != nil {
-			arg0 = args[0].(entities.User)
+			arg0 = args[0].(int32)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserService_CreateUser_Call) Return(user entities.User, err error) *MockUserService_CreateUser_Call {
-	_c.Call.Return(user, err)
+func (_c *MockUserService_AddUserTags_Call) Return(userTags entities.UserTags, err error) *MockUserService_AddUserTags_Call {
+	_c.Call.Return(userTags, err)
 	return _c
 }
 
-func (_c *MockUserService_CreateUser_Call) RunAndReturn(run func(params entities.User) (entities.User, error)) *MockUserService_CreateUser_Call {
+func (_c *MockUserService_AddUserTags_Call) RunAndReturn(run func(id int32, tags []string) (entities.UserTags, error)) *MockUserService_AddUserTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteUser provides a mock function for the type MockUserService
-func (_mock *MockUserService) DeleteUser(id int32) error {
-	ret := _mock.Called(id)
+// BulkDeleteUsers provides a mock function for the type MockUserService
+func (_mock *MockUserService) BulkDeleteUsers(filter entities.BulkFilter, hard bool, opts entities.BulkOptions) (entities.BulkResult, error) {
+	ret := _mock.Called(filter, hard, opts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUser")
+		panic("no return value specified for BulkDeleteUsers")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(int32) error); ok {
-		r0 = returnFunc(id)
+	var r0 entities.BulkResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(entities.BulkFilter, bool, entities.BulkOptions) (entities.BulkResult, error)); ok {
+		return returnFunc(filter, hard, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(entities.BulkFilter, bool, entities.BulkOptions) entities.BulkResult); ok {
+		r0 = returnFunc(filter, hard, opts)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(entities.BulkResult)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(entities.BulkFilter, bool, entities.BulkOptions) error); ok {
+		r1 = returnFunc(filter, hard, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockUserService_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
-type MockUserService_DeleteUser_Call struct {
+// MockUserService_BulkDeleteUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkDeleteUsers'
+type MockUserService_BulkDeleteUsers_Call struct {
 	*mock.Call
 }
 
-// DeleteUser is a helper method to define mock.On call
-//   - id int32
-func (_e *MockUserService_Expecter) DeleteUser(id interface{}) *MockUserService_DeleteUser_Call {
-	return &MockUserService_DeleteUser_Call{Call: _e.mock.On("DeleteUser", id)}
+// BulkDeleteUsers is a helper method to define mock.On call
+//   - filter entities.BulkFilter
+//   - hard bool
+//   - opts entities.BulkOptions
+func (_e *MockUserService_Expecter) BulkDeleteUsers(filter interface{}, hard interface{}, opts interface{}) *MockUserService_BulkDeleteUsers_Call {
+	return &MockUserService_BulkDeleteUsers_Call{Call: _e.mock.On("BulkDeleteUsers", filter, hard, opts)}
 }
 
-func (_c *MockUserService_DeleteUser_Call) Run(run func(id int32)) *MockUserService_DeleteUser_Call {
+func (_c *MockUserService_BulkDeleteUsers_Call) Run(run func(filter entities.BulkFilter, hard bool, opts entities.BulkOptions)) *MockUserService_BulkDeleteUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int32
+		var arg0 entities.BulkFilter
 		if args[0] != nil {
-			arg0 = args[0].(int32)
+			arg0 = args[0].(entities.BulkFilter)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		var arg2 entities.BulkOptions
+		if args[2] != nil {
+			arg2 = args[2].(entities.BulkOptions)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserService_DeleteUser_Call) Return(err error) *MockUserService_DeleteUser_Call {
-	_c.Call.Return(err)
+func (_c *MockUserService_BulkDeleteUsers_Call) Return(bulkResult entities.BulkResult, err error) *MockUserService_BulkDeleteUsers_Call {
+	_c.Call.Return(bulkResult, err)
 	return _c
 }
 
-func (_c *MockUserService_DeleteUser_Call) RunAndReturn(run func(id int32) error) *MockUserService_DeleteUser_Call {
+func (_c *MockUserService_BulkDeleteUsers_Call) RunAndReturn(run func(filter entities.BulkFilter, hard bool, opts entities.BulkOptions) (entities.BulkResult, error)) *MockUserService_BulkDeleteUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ExistByFullName provides a mock function for the type MockUserService
-func (_mock *MockUserService) ExistByFullName(params entities.FullName) (bool, error) {
-	ret := _mock.Called(params)
+// BulkTagUsers provides a mock function for the type MockUserService
+func (_mock *MockUserService) BulkTagUsers(filter entities.BulkFilter, params entities.BulkTagsParams, opts entities.BulkOptions) (entities.BulkResult, error) {
+	ret := _mock.Called(filter, params, opts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ExistByFullName")
+		panic("no return value specified for BulkTagUsers")
 	}
 
-	var r0 bool
+	var r0 entities.BulkResult
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(entities.FullName) (bool, error)); ok {
-		return returnFunc(params)
+	if returnFunc, ok := ret.Get(0).(func(entities.BulkFilter, entities.BulkTagsParams, entities.BulkOptions) (entities.BulkResult, error)); ok {
+		return returnFunc(filter, params, opts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(entities.FullName) bool); ok {
-		r0 = returnFunc(params)
+	if returnFunc, ok := ret.Get(0).(func(entities.BulkFilter, entities.BulkTagsParams, entities.BulkOptions) entities.BulkResult); ok {
+		r0 = returnFunc(filter, params, opts)
+	} else {
+		r0 = ret.Get(0).(entities.BulkResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(entities.BulkFilter, entities.BulkTagsParams, entities.BulkOptions) error); ok {
+		r1 = returnFunc(filter, params, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_BulkTagUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkTagUsers'
+type MockUserService_BulkTagUsers_Call struct {
+	*mock.Call
+}
+
+// BulkTagUsers is a helper method to define mock.On call
+//   - filter entities.BulkFilter
+//   - params entities.BulkTagsParams
+//   - opts entities.BulkOptions
+func (_e *MockUserService_Expecter) BulkTagUsers(filter interface{}, params interface{}, opts interface{}) *MockUserService_BulkTagUsers_Call {
+	return &MockUserService_BulkTagUsers_Call{Call: _e.mock.On("BulkTagUsers", filter, params, opts)}
+}
+
+func (_c *MockUserService_BulkTagUsers_Call) Run(run func(filter entities.BulkFilter, params entities.BulkTagsParams, opts entities.BulkOptions)) *MockUserService_BulkTagUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 entities.BulkFilter
+		if args[0] != nil {
+			arg0 = args[0].(entities.BulkFilter)
+		}
+		var arg1 entities.BulkTagsParams
+		if args[1] != nil {
+			arg1 = args[1].(entities.BulkTagsParams)
+		}
+		var arg2 entities.BulkOptions
+		if args[2] != nil {
+			arg2 = args[2].(entities.BulkOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_BulkTagUsers_Call) Return(bulkResult entities.BulkResult, err error) *MockUserService_BulkTagUsers_Call {
+	_c.Call.Return(bulkResult, err)
+	return _c
+}
+
+func (_c *MockUserService_BulkTagUsers_Call) RunAndReturn(run func(filter entities.BulkFilter, params entities.BulkTagsParams, opts entities.BulkOptions) (entities.BulkResult, error)) *MockUserService_BulkTagUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkUpdateUsers provides a mock function for the type MockUserService
+func (_mock *MockUserService) BulkUpdateUsers(filter entities.BulkFilter, params entities.UpdateUserParams, opts entities.BulkOptions) (entities.BulkResult, error) {
+	ret := _mock.Called(filter, params, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkUpdateUsers")
+	}
+
+	var r0 entities.BulkResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(entities.BulkFilter, entities.UpdateUserParams, entities.BulkOptions) (entities.BulkResult, error)); ok {
+		return returnFunc(filter, params, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(entities.BulkFilter, entities.UpdateUserParams, entities.BulkOptions) entities.BulkResult); ok {
+		r0 = returnFunc(filter, params, opts)
+	} else {
+		r0 = ret.Get(0).(entities.BulkResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(entities.BulkFilter, entities.UpdateUserParams, entities.BulkOptions) error); ok {
+		r1 = returnFunc(filter, params, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_BulkUpdateUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkUpdateUsers'
+type MockUserService_BulkUpdateUsers_Call struct {
+	*mock.Call
+}
+
+// BulkUpdateUsers is a helper method to define mock.On call
+//   - filter entities.BulkFilter
+//   - params entities.UpdateUserParams
+//   - opts entities.BulkOptions
+func (_e *MockUserService_Expecter) BulkUpdateUsers(filter interface{}, params interface{}, opts interface{}) *MockUserService_BulkUpdateUsers_Call {
+	return &MockUserService_BulkUpdateUsers_Call{Call: _e.mock.On("BulkUpdateUsers", filter, params, opts)}
+}
+
+func (_c *MockUserService_BulkUpdateUsers_Call) Run(run func(filter entities.BulkFilter, params entities.UpdateUserParams, opts entities.BulkOptions)) *MockUserService_BulkUpdateUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 entities.BulkFilter
+		if args[0] != nil {
+			arg0 = args[0].(entities.BulkFilter)
+		}
+		var arg1 entities.UpdateUserParams
+		if args[1] != nil {
+			arg1 = args[1].(entities.UpdateUserParams)
+		}
+		var arg2 entities.BulkOptions
+		if args[2] != nil {
+			arg2 = args[2].(entities.BulkOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_BulkUpdateUsers_Call) Return(bulkResult entities.BulkResult, err error) *MockUserService_BulkUpdateUsers_Call {
+	_c.Call.Return(bulkResult, err)
+	return _c
+}
+
+func (_c *MockUserService_BulkUpdateUsers_Call) RunAndReturn(run func(filter entities.BulkFilter, params entities.UpdateUserParams, opts entities.BulkOptions) (entities.BulkResult, error)) *MockUserService_BulkUpdateUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function for the type MockUserService
+func (_mock *MockUserService) CreateUser(fullName entities.FullName) (entities.User, error) {
+	ret := _mock.Called(fullName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 entities.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(entities.FullName) (entities.User, error)); ok {
+		return returnFunc(fullName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(entities.FullName) entities.User); ok {
+		r0 = returnFunc(fullName)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(entities.User)
 	}
 	if returnFunc, ok := ret.Get(1).(func(entities.FullName) error); ok {
-		r1 = returnFunc(params)
+		r1 = returnFunc(fullName)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUserService_ExistByFullName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistByFullName'
-type MockUserService_ExistByFullName_Call struct {
+// MockUserService_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockUserService_CreateUser_Call struct {
 	*mock.Call
 }
 
-// ExistByFullName is a helper method to define mock.On call
-//   - params entities.FullName
-func (_e *MockUserService_Expecter) ExistByFullName(params interface{}) *MockUserService_ExistByFullName_Call {
-	return &MockUserService_ExistByFullName_Call{Call: _e.mock.On("ExistByFullName", params)}
+// CreateUser is a helper method to define mock.On call
+//   - fullName entities.FullName
+func (_e *MockUserService_Expecter) CreateUser(fullName interface{}) *MockUserService_CreateUser_Call {
+	return &MockUserService_CreateUser_Call{Call: _e.mock.On("CreateUser", fullName)}
 }
 
-func (_c *MockUserService_ExistByFullName_Call) Run(run func(params entities.FullName)) *MockUserService_ExistByFullName_Call {
+func (_c *MockUserService_CreateUser_Call) Run(run func(fullName entities.FullName)) *MockUserService_CreateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 entities.FullName
 		if args[0] != nil {
@@ -199,79 +371,144 @@ func (_c *MockUserService_ExistByFullName_Call) Run(run func(params entities.Ful
 	return _c
 }
 
-func (_c *MockUserService_ExistByFullName_Call) Return(b bool, err error) *MockUserService_ExistByFullName_Call {
-	_c.Call.Return(b, err)
+func (_c *MockUserService_CreateUser_Call) Return(user entities.User, err error) *MockUserService_CreateUser_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockUserService_ExistByFullName_Call) RunAndReturn(run func(params entities.FullName) (bool, error)) *MockUserService_ExistByFullName_Call {
+func (_c *MockUserService_CreateUser_Call) RunAndReturn(run func(fullName entities.FullName) (entities.User, error)) *MockUserService_CreateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ExistById provides a mock function for the type MockUserService
-func (_mock *MockUserService) ExistById(id int32) (bool, error) {
+// DeleteUser provides a mock function for the type MockUserService
+func (_mock *MockUserService) DeleteUser(id int32) error {
 	ret := _mock.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ExistById")
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int32) error); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockUserService_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - id int32
+func (_e *MockUserService_Expecter) DeleteUser(id interface{}) *MockUserService_DeleteUser_Call {
+	return &MockUserService_DeleteUser_Call{Call: _e.mock.On("DeleteUser", id)}
+}
+
+func (_c *MockUserService_DeleteUser_Call) Run(run func(id int32)) *MockUserService_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int32
+		if args[0] != nil {
+			arg0 = args[0].(int32)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_DeleteUser_Call) Return(err error) *MockUserService_DeleteUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_DeleteUser_Call) RunAndReturn(run func(id int32) error) *MockUserService_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindDuplicates provides a mock function for the type MockUserService
+func (_mock *MockUserService) FindDuplicates(threshold float64, limit int, userId int32) ([]entities.DuplicateCandidate, error) {
+	ret := _mock.Called(threshold, limit, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDuplicates")
 	}
 
-	var r0 bool
+	var r0 []entities.DuplicateCandidate
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int32) (bool, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(float64, int, int32) ([]entities.DuplicateCandidate, error)); ok {
+		return returnFunc(threshold, limit, userId)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int32) bool); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(float64, int, int32) []entities.DuplicateCandidate); ok {
+		r0 = returnFunc(threshold, limit, userId)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.DuplicateCandidate)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int32) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(1).(func(float64, int, int32) error); ok {
+		r1 = returnFunc(threshold, limit, userId)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUserService_ExistById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistById'
-type MockUserService_ExistById_Call struct {
+// MockUserService_FindDuplicates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDuplicates'
+type MockUserService_FindDuplicates_Call struct {
 	*mock.Call
 }
 
-// ExistById is a helper method to define mock.On call
-//   - id int32
-func (_e *MockUserService_Expecter) ExistById(id interface{}) *MockUserService_ExistById_Call {
-	return &MockUserService_ExistById_Call{Call: _e.mock.On("ExistById", id)}
+// FindDuplicates is a helper method to define mock.On call
+//   - threshold float64
+//   - limit int
+//   - userId int32
+func (_e *MockUserService_Expecter) FindDuplicates(threshold interface{}, limit interface{}, userId interface{}) *MockUserService_FindDuplicates_Call {
+	return &MockUserService_FindDuplicates_Call{Call: _e.mock.On("FindDuplicates", threshold, limit, userId)}
 }
 
-func (_c *MockUserService_ExistById_Call) Run(run func(id int32)) *MockUserService_ExistById_Call {
+func (_c *MockUserService_FindDuplicates_Call) Run(run func(threshold float64, limit int, userId int32)) *MockUserService_FindDuplicates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int32
+		var arg0 float64
 		if args[0] != nil {
-			arg0 = args[0].(int32)
+			arg0 = args[0].(float64)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int32
+		if args[2] != nil {
+			arg2 = args[2].(int32)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserService_ExistById_Call) Return(b bool, err error) *MockUserService_ExistById_Call {
-	_c.Call.Return(b, err)
+func (_c *MockUserService_FindDuplicates_Call) Return(duplicateCandidates []entities.DuplicateCandidate, err error) *MockUserService_FindDuplicates_Call {
+	_c.Call.Return(duplicateCandidates, err)
 	return _c
 }
 
-func (_c *MockUserService_ExistById_Call) RunAndReturn(run func(id int32) (bool, error)) *MockUserService_ExistById_Call {
+func (_c *MockUserService_FindDuplicates_Call) RunAndReturn(run func(threshold float64, limit int, userId int32) ([]entities.DuplicateCandidate, error)) *MockUserService_FindDuplicates_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetAllUsers provides a mock function for the type MockUserService
-func (_mock *MockUserService) GetAllUsers(pageSize int, page int, name string, surname string, patronymic string, gender string) ([]entities.User, int, error) {
-	ret := _mock.Called(pageSize, page, name, surname, patronymic, gender)
+func (_mock *MockUserService) GetAllUsers(pageSize int, page int, filter entities.UserFilter, sort []entities.SortField, fields []string) ([]entities.User, int, error) {
+	ret := _mock.Called(pageSize, page, filter, sort, fields)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllUsers")
@@ -280,23 +517,23 @@ func (_mock *MockUserService) GetAllUsers(pageSize int, page int, name string, s
 	var r0 []entities.User
 	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(int, int, string, string, string, string) ([]entities.User, int, error)); ok {
-		return returnFunc(pageSize, page, name, surname, patronymic, gender)
+	if returnFunc, ok := ret.Get(0).(func(int, int, entities.UserFilter, []entities.SortField, []string) ([]entities.User, int, error)); ok {
+		return returnFunc(pageSize, page, filter, sort, fields)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, int, string, string, string, string) []entities.User); ok {
-		r0 = returnFunc(pageSize, page, name, surname, patronymic, gender)
+	if returnFunc, ok := ret.Get(0).(func(int, int, entities.UserFilter, []entities.SortField, []string) []entities.User); ok {
+		r0 = returnFunc(pageSize, page, filter, sort, fields)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entities.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, int, string, string, string, string) int); ok {
-		r1 = returnFunc(pageSize, page, name, surname, patronymic, gender)
+	if returnFunc, ok := ret.Get(1).(func(int, int, entities.UserFilter, []entities.SortField, []string) int); ok {
+		r1 = returnFunc(pageSize, page, filter, sort, fields)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(int, int, string, string, string, string) error); ok {
-		r2 = returnFunc(pageSize, page, name, surname, patronymic, gender)
+	if returnFunc, ok := ret.Get(2).(func(int, int, entities.UserFilter, []entities.SortField, []string) error); ok {
+		r2 = returnFunc(pageSize, page, filter, sort, fields)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -311,15 +548,14 @@ type MockUserService_GetAllUsers_Call struct {
 // GetAllUsers is a helper method to define mock.On call
 //   - pageSize int
 //   - page int
-//   - name string
-//   - surname string
-//   - patronymic string
-//   - gender string
-func (_e *MockUserService_Expecter) GetAllUsers(pageSize interface{}, page interface{}, name interface{}, surname interface{}, patronymic interface{}, gender interface{}) *MockUserService_GetAllUsers_Call {
-	return &MockUserService_GetAllUsers_Call{Call: _e.mock.On("GetAllUsers", pageSize, page, name, surname, patronymic, gender)}
+//   - filter entities.UserFilter
+//   - sort []entities.SortField
+//   - fields []string
+func (_e *MockUserService_Expecter) GetAllUsers(pageSize interface{}, page interface{}, filter interface{}, sort interface{}, fields interface{}) *MockUserService_GetAllUsers_Call {
+	return &MockUserService_GetAllUsers_Call{Call: _e.mock.On("GetAllUsers", pageSize, page, filter, sort, fields)}
 }
 
-func (_c *MockUserService_GetAllUsers_Call) Run(run func(pageSize int, page int, name string, surname string, patronymic string, gender string)) *MockUserService_GetAllUsers_Call {
+func (_c *MockUserService_GetAllUsers_Call) Run(run func(pageSize int, page int, filter entities.UserFilter, sort []entities.SortField, fields []string)) *MockUserService_GetAllUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int
 		if args[0] != nil {
@@ -329,21 +565,17 @@ func (_c *MockUserService_GetAllUsers_Call) Run(run func(pageSize int, page int,
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
-		var arg2 string
+		var arg2 entities.UserFilter
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(entities.UserFilter)
 		}
-		var arg3 string
+		var arg3 []entities.SortField
 		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg3 = args[3].([]entities.SortField)
 		}
-		var arg4 string
+		var arg4 []string
 		if args[4] != nil {
-			arg4 = args[4].(string)
-		}
-		var arg5 string
-		if args[5] != nil {
-			arg5 = args[5].(string)
+			arg4 = args[4].([]string)
 		}
 		run(
 			arg0,
@@ -351,7 +583,6 @@ func (_c *MockUserService_GetAllUsers_Call) Run(run func(pageSize int, page int,
 			arg2,
 			arg3,
 			arg4,
-			arg5,
 		)
 	})
 	return _c
@@ -362,14 +593,14 @@ func (_c *MockUserService_GetAllUsers_Call) Return(users []entities.User, totalC
 	return _c
 }
 
-func (_c *MockUserService_GetAllUsers_Call) RunAndReturn(run func(pageSize int, page int, name string, surname string, patronymic string, gender string) ([]entities.User, int, error)) *MockUserService_GetAllUsers_Call {
+func (_c *MockUserService_GetAllUsers_Call) RunAndReturn(run func(pageSize int, page int, filter entities.UserFilter, sort []entities.SortField, fields []string) ([]entities.User, int, error)) *MockUserService_GetAllUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetUserById provides a mock function for the type MockUserService
-func (_mock *MockUserService) GetUserById(id int32) (entities.User, error) {
-	ret := _mock.Called(id)
+func (_mock *MockUserService) GetUserById(id int32, fields []string) (entities.User, error) {
+	ret := _mock.Called(id, fields)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUserById")
@@ -377,16 +608,16 @@ func (_mock *MockUserService) GetUserById(id int32) (entities.User, error) {
 
 	var r0 entities.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int32) (entities.User, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(int32, []string) (entities.User, error)); ok {
+		return returnFunc(id, fields)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int32) entities.User); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(int32, []string) entities.User); ok {
+		r0 = returnFunc(id, fields)
 	} else {
 		r0 = ret.Get(0).(entities.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(int32) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(1).(func(int32, []string) error); ok {
+		r1 = returnFunc(id, fields)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -400,18 +631,24 @@ type MockUserService_GetUserById_Call struct {
 
 // GetUserById is a helper method to define mock.On call
 //   - id int32
-func (_e *MockUserService_Expecter) GetUserById(id interface{}) *MockUserService_GetUserById_Call {
-	return &MockUserService_GetUserById_Call{Call: _e.mock.On("GetUserById", id)}
+//   - fields []string
+func (_e *MockUserService_Expecter) GetUserById(id interface{}, fields interface{}) *MockUserService_GetUserById_Call {
+	return &MockUserService_GetUserById_Call{Call: _e.mock.On("GetUserById", id, fields)}
 }
 
-func (_c *MockUserService_GetUserById_Call) Run(run func(id int32)) *MockUserService_GetUserById_Call {
+func (_c *MockUserService_GetUserById_Call) Run(run func(id int32, fields []string)) *MockUserService_GetUserById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int32
 		if args[0] != nil {
 			arg0 = args[0].(int32)
 		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -422,246 +659,2581 @@ func (_c *MockUserService_GetUserById_Call) Return(user entities.User, err error
 	return _c
 }
 
-func (_c *MockUserService_GetUserById_Call) RunAndReturn(run func(id int32) (entities.User, error)) *MockUserService_GetUserById_Call {
+func (_c *MockUserService_GetUserById_Call) RunAndReturn(run func(id int32, fields []string) (entities.User, error)) *MockUserService_GetUserById_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateUser provides a mock function for the type MockUserService
-func (_mock *MockUserService) UpdateUser(id int32, params entities.UpdateUserParams) error {
-	ret := _mock.Called(id, params)
+// GetUserTags provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetUserTags(id int32) (entities.UserTags, error) {
+	ret := _mock.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUser")
+		panic("no return value specified for GetUserTags")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(int32, entities.UpdateUserParams) error); ok {
-		r0 = returnFunc(id, params)
+	var r0 entities.UserTags
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int32) (entities.UserTags, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int32) entities.UserTags); ok {
+		r0 = returnFunc(id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(entities.UserTags)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(int32) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockUserService_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+// MockUserService_GetUserTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserTags'
+type MockUserService_GetUserTags_Call struct {
+	*mock.Call
+}
+
+// GetUserTags is a helper method to define mock.On call
+//   - id int32
+func (_e *MockUserService_Expecter) GetUserTags(id interface{}) *MockUserService_GetUserTags_Call {
+	return &MockUserService_GetUserTags_Call{Call: _e.mock.On("GetUserTags", id)}
+}
+
+func (_c *MockUserService_GetUserTags_Call) Run(run func(id int32)) *MockUserService_GetUserTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int32
+		if args[0] != nil {
+			arg0 = args[0].(int32)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetUserTags_Call) Return(userTags entities.UserTags, err error) *MockUserService_GetUserTags_Call {
+	_c.Call.Return(userTags, err)
+	return _c
+}
+
+func (_c *MockUserService_GetUserTags_Call) RunAndReturn(run func(id int32) (entities.UserTags, error)) *MockUserService_GetUserTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsersByCursor provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetUsersByCursor(filter entities.UserFilter, sort []entities.SortField, cursor string, limit int, withTotal bool, fields []string) (entities.UsersCursorPage, error) {
+	ret := _mock.Called(filter, sort, cursor, limit, withTotal, fields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersByCursor")
+	}
+
+	var r0 entities.UsersCursorPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(entities.UserFilter, []entities.SortField, string, int, bool, []string) (entities.UsersCursorPage, error)); ok {
+		return returnFunc(filter, sort, cursor, limit, withTotal, fields)
+	}
+	if returnFunc, ok := ret.Get(0).(func(entities.UserFilter, []entities.SortField, string, int, bool, []string) entities.UsersCursorPage); ok {
+		r0 = returnFunc(filter, sort, cursor, limit, withTotal, fields)
+	} else {
+		r0 = ret.Get(0).(entities.UsersCursorPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(entities.UserFilter, []entities.SortField, string, int, bool, []string) error); ok {
+		r1 = returnFunc(filter, sort, cursor, limit, withTotal, fields)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_GetUsersByCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersByCursor'
+type MockUserService_GetUsersByCursor_Call struct {
+	*mock.Call
+}
+
+// GetUsersByCursor is a helper method to define mock.On call
+//   - filter entities.UserFilter
+//   - sort []entities.SortField
+//   - cursor string
+//   - limit int
+//   - withTotal bool
+//   - fields []string
+func (_e *MockUserService_Expecter) GetUsersByCursor(filter interface{}, sort interface{}, cursor interface{}, limit interface{}, withTotal interface{}, fields interface{}) *MockUserService_GetUsersByCursor_Call {
+	return &MockUserService_GetUsersByCursor_Call{Call: _e.mock.On("GetUsersByCursor", filter, sort, cursor, limit, withTotal, fields)}
+}
+
+func (_c *MockUserService_GetUsersByCursor_Call) Run(run func(filter entities.UserFilter, sort []entities.SortField, cursor string, limit int, withTotal bool, fields []string)) *MockUserService_GetUsersByCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 entities.UserFilter
+		if args[0] != nil {
+			arg0 = args[0].(entities.UserFilter)
+		}
+		var arg1 []entities.SortField
+		if args[1] != nil {
+			arg1 = args[1].([]entities.SortField)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		var arg5 []string
+		if args[5] != nil {
+			arg5 = args[5].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetUsersByCursor_Call) Return(usersCursorPage entities.UsersCursorPage, err error) *MockUserService_GetUsersByCursor_Call {
+	_c.Call.Return(usersCursorPage, err)
+	return _c
+}
+
+func (_c *MockUserService_GetUsersByCursor_Call) RunAndReturn(run func(filter entities.UserFilter, sort []entities.SortField, cursor string, limit int, withTotal bool, fields []string) (entities.UsersCursorPage, error)) *MockUserService_GetUsersByCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsersByIds provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetUsersByIds(ids []int32) ([]entities.User, error) {
+	ret := _mock.Called(ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersByIds")
+	}
+
+	var r0 []entities.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]int32) ([]entities.User, error)); ok {
+		return returnFunc(ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]int32) []entities.User); ok {
+		r0 = returnFunc(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]int32) error); ok {
+		r1 = returnFunc(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_GetUsersByIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersByIds'
+type MockUserService_GetUsersByIds_Call struct {
+	*mock.Call
+}
+
+// GetUsersByIds is a helper method to define mock.On call
+//   - ids []int32
+func (_e *MockUserService_Expecter) GetUsersByIds(ids interface{}) *MockUserService_GetUsersByIds_Call {
+	return &MockUserService_GetUsersByIds_Call{Call: _e.mock.On("GetUsersByIds", ids)}
+}
+
+func (_c *MockUserService_GetUsersByIds_Call) Run(run func(ids []int32)) *MockUserService_GetUsersByIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []int32
+		if args[0] != nil {
+			arg0 = args[0].([]int32)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetUsersByIds_Call) Return(users []entities.User, err error) *MockUserService_GetUsersByIds_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockUserService_GetUsersByIds_Call) RunAndReturn(run func(ids []int32) ([]entities.User, error)) *MockUserService_GetUsersByIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeUsers provides a mock function for the type MockUserService
+func (_mock *MockUserService) MergeUsers(params entities.MergeUsersParams) (entities.User, error) {
+	ret := _mock.Called(params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeUsers")
+	}
+
+	var r0 entities.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(entities.MergeUsersParams) (entities.User, error)); ok {
+		return returnFunc(params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(entities.MergeUsersParams) entities.User); ok {
+		r0 = returnFunc(params)
+	} else {
+		r0 = ret.Get(0).(entities.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(entities.MergeUsersParams) error); ok {
+		r1 = returnFunc(params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_MergeUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeUsers'
+type MockUserService_MergeUsers_Call struct {
+	*mock.Call
+}
+
+// MergeUsers is a helper method to define mock.On call
+//   - params entities.MergeUsersParams
+func (_e *MockUserService_Expecter) MergeUsers(params interface{}) *MockUserService_MergeUsers_Call {
+	return &MockUserService_MergeUsers_Call{Call: _e.mock.On("MergeUsers", params)}
+}
+
+func (_c *MockUserService_MergeUsers_Call) Run(run func(params entities.MergeUsersParams)) *MockUserService_MergeUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 entities.MergeUsersParams
+		if args[0] != nil {
+			arg0 = args[0].(entities.MergeUsersParams)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_MergeUsers_Call) Return(user entities.User, err error) *MockUserService_MergeUsers_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_MergeUsers_Call) RunAndReturn(run func(params entities.MergeUsersParams) (entities.User, error)) *MockUserService_MergeUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchUser provides a mock function for the type MockUserService
+func (_mock *MockUserService) PatchUser(id int32, format string, patch []byte) (entities.User, error) {
+	ret := _mock.Called(id, format, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchUser")
+	}
+
+	var r0 entities.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int32, string, []byte) (entities.User, error)); ok {
+		return returnFunc(id, format, patch)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int32, string, []byte) entities.User); ok {
+		r0 = returnFunc(id, format, patch)
+	} else {
+		r0 = ret.Get(0).(entities.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int32, string, []byte) error); ok {
+		r1 = returnFunc(id, format, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_PatchUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchUser'
+type MockUserService_PatchUser_Call struct {
+	*mock.Call
+}
+
+// PatchUser is a helper method to define mock.On call
+//   - id int32
+//   - format string
+//   - patch []byte
+func (_e *MockUserService_Expecter) PatchUser(id interface{}, format interface{}, patch interface{}) *MockUserService_PatchUser_Call {
+	return &MockUserService_PatchUser_Call{Call: _e.mock.On("PatchUser", id, format, patch)}
+}
+
+func (_c *MockUserService_PatchUser_Call) Run(run func(id int32, format string, patch []byte)) *MockUserService_PatchUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int32
+		if args[0] != nil {
+			arg0 = args[0].(int32)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_PatchUser_Call) Return(user entities.User, err error) *MockUserService_PatchUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_PatchUser_Call) RunAndReturn(run func(id int32, format string, patch []byte) (entities.User, error)) *MockUserService_PatchUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveUserTags provides a mock function for the type MockUserService
+func (_mock *MockUserService) RemoveUserTags(id int32, tags []string) (entities.UserTags, error) {
+	ret := _mock.Called(id, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveUserTags")
+	}
+
+	var r0 entities.UserTags
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int32, []string) (entities.UserTags, error)); ok {
+		return returnFunc(id, tags)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int32, []string) entities.UserTags); ok {
+		r0 = returnFunc(id, tags)
+	} else {
+		r0 = ret.Get(0).(entities.UserTags)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int32, []string) error); ok {
+		r1 = returnFunc(id, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_RemoveUserTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveUserTags'
+type MockUserService_RemoveUserTags_Call struct {
+	*mock.Call
+}
+
+// RemoveUserTags is a helper method to define mock.On call
+//   - id int32
+//   - tags []string
+func (_e *MockUserService_Expecter) RemoveUserTags(id interface{}, tags interface{}) *MockUserService_RemoveUserTags_Call {
+	return &MockUserService_RemoveUserTags_Call{Call: _e.mock.On("RemoveUserTags", id, tags)}
+}
+
+func (_c *MockUserService_RemoveUserTags_Call) Run(run func(id int32, tags []string)) *MockUserService_RemoveUserTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int32
+		if args[0] != nil {
+			arg0 = args[0].(int32)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_RemoveUserTags_Call) Return(userTags entities.UserTags, err error) *MockUserService_RemoveUserTags_Call {
+	_c.Call.Return(userTags, err)
+	return _c
+}
+
+func (_c *MockUserService_RemoveUserTags_Call) RunAndReturn(run func(id int32, tags []string) (entities.UserTags, error)) *MockUserService_RemoveUserTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceUser provides a mock function for the type MockUserService
+func (_mock *MockUserService) ReplaceUser(id int32, params entities.ReplaceUserParams) error {
+	ret := _mock.Called(id, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int32, entities.ReplaceUserParams) error); ok {
+		r0 = returnFunc(id, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_ReplaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceUser'
+type MockUserService_ReplaceUser_Call struct {
+	*mock.Call
+}
+
+// ReplaceUser is a helper method to define mock.On call
+//   - id int32
+//   - params entities.ReplaceUserParams
+func (_e *MockUserService_Expecter) ReplaceUser(id interface{}, params interface{}) *MockUserService_ReplaceUser_Call {
+	return &MockUserService_ReplaceUser_Call{Call: _e.mock.On("ReplaceUser", id, params)}
+}
+
+func (_c *MockUserService_ReplaceUser_Call) Run(run func(id int32, params entities.ReplaceUserParams)) *MockUserService_ReplaceUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int32
+		if args[0] != nil {
+			arg0 = args[0].(int32)
+		}
+		var arg1 entities.ReplaceUserParams
+		if args[1] != nil {
+			arg1 = args[1].(entities.ReplaceUserParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_ReplaceUser_Call) Return(err error) *MockUserService_ReplaceUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_ReplaceUser_Call) RunAndReturn(run func(id int32, params entities.ReplaceUserParams) error) *MockUserService_ReplaceUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchUsers provides a mock function for the type MockUserService
+func (_mock *MockUserService) SearchUsers(query string, threshold float64, limit int) ([]entities.UserSearchResult, error) {
+	ret := _mock.Called(query, threshold, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchUsers")
+	}
+
+	var r0 []entities.UserSearchResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, float64, int) ([]entities.UserSearchResult, error)); ok {
+		return returnFunc(query, threshold, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, float64, int) []entities.UserSearchResult); ok {
+		r0 = returnFunc(query, threshold, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.UserSearchResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, float64, int) error); ok {
+		r1 = returnFunc(query, threshold, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_SearchUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchUsers'
+type MockUserService_SearchUsers_Call struct {
+	*mock.Call
+}
+
+// SearchUsers is a helper method to define mock.On call
+//   - query string
+//   - threshold float64
+//   - limit int
+func (_e *MockUserService_Expecter) SearchUsers(query interface{}, threshold interface{}, limit interface{}) *MockUserService_SearchUsers_Call {
+	return &MockUserService_SearchUsers_Call{Call: _e.mock.On("SearchUsers", query, threshold, limit)}
+}
+
+func (_c *MockUserService_SearchUsers_Call) Run(run func(query string, threshold float64, limit int)) *MockUserService_SearchUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 float64
+		if args[1] != nil {
+			arg1 = args[1].(float64)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_SearchUsers_Call) Return(userSearchResults []entities.UserSearchResult, err error) *MockUserService_SearchUsers_Call {
+	_c.Call.Return(userSearchResults, err)
+	return _c
+}
+
+func (_c *MockUserService_SearchUsers_Call) RunAndReturn(run func(query string, threshold float64, limit int) ([]entities.UserSearchResult, error)) *MockUserService_SearchUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUser provides a mock function for the type MockUserService
+func (_mock *MockUserService) UpdateUser(id int32, params entities.UpdateUserParams) error {
+	ret := _mock.Called(id, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int32, entities.UpdateUserParams) error); ok {
+		r0 = returnFunc(id, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
 type MockUserService_UpdateUser_Call struct {
 	*mock.Call
 }
 
-// UpdateUser is a helper method to define mock.On call
-//   - id int32
-//   - params entities.UpdateUserParams
-func (_e *MockUserService_Expecter) UpdateUser(id interface{}, params interface{}) *MockUserService_UpdateUser_Call {
-	return &MockUserService_UpdateUser_Call{Call: _e.mock.On("UpdateUser", id, params)}
+// UpdateUser is a helper method to define mock.On call
+//   - id int32
+//   - params entities.UpdateUserParams
+func (_e *MockUserService_Expecter) UpdateUser(id interface{}, params interface{}) *MockUserService_UpdateUser_Call {
+	return &MockUserService_UpdateUser_Call{Call: _e.mock.On("UpdateUser", id, params)}
+}
+
+func (_c *MockUserService_UpdateUser_Call) Run(run func(id int32, params entities.UpdateUserParams)) *MockUserService_UpdateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int32
+		if args[0] != nil {
+			arg0 = args[0].(int32)
+		}
+		var arg1 entities.UpdateUserParams
+		if args[1] != nil {
+			arg1 = args[1].(entities.UpdateUserParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_UpdateUser_Call) Return(err error) *MockUserService_UpdateUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_UpdateUser_Call) RunAndReturn(run func(id int32, params entities.UpdateUserParams) error) *MockUserService_UpdateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRedisService creates a new instance of MockRedisService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRedisService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRedisService {
+	mock := &MockRedisService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRedisService is an autogenerated mock type for the RedisService type
+type MockRedisService struct {
+	mock.Mock
+}
+
+type MockRedisService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRedisService) EXPECT() *MockRedisService_Expecter {
+	return &MockRedisService_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockRedisService
+func (_mock *MockRedisService) Delete(ctx context.Context, key string) error {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRedisService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockRedisService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockRedisService_Expecter) Delete(ctx interface{}, key interface{}) *MockRedisService_Delete_Call {
+	return &MockRedisService_Delete_Call{Call: _e.mock.On("Delete", ctx, key)}
+}
+
+func (_c *MockRedisService_Delete_Call) Run(run func(ctx context.Context, key string)) *MockRedisService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRedisService_Delete_Call) Return(err error) *MockRedisService_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRedisService_Delete_Call) RunAndReturn(run func(ctx context.Context, key string) error) *MockRedisService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockRedisService
+func (_mock *MockRedisService) Get(ctx context.Context, key string, dest any) error {
+	ret := _mock.Called(ctx, key, dest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, any) error); ok {
+		r0 = returnFunc(ctx, key, dest)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRedisService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockRedisService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - dest any
+func (_e *MockRedisService_Expecter) Get(ctx interface{}, key interface{}, dest interface{}) *MockRedisService_Get_Call {
+	return &MockRedisService_Get_Call{Call: _e.mock.On("Get", ctx, key, dest)}
+}
+
+func (_c *MockRedisService_Get_Call) Run(run func(ctx context.Context, key string, dest any)) *MockRedisService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 any
+		if args[2] != nil {
+			arg2 = args[2].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRedisService_Get_Call) Return(err error) *MockRedisService_Get_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRedisService_Get_Call) RunAndReturn(run func(ctx context.Context, key string, dest any) error) *MockRedisService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MGet provides a mock function for the type MockRedisService
+func (_mock *MockRedisService) MGet(ctx context.Context, keys []string) ([][]byte, error) {
+	ret := _mock.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MGet")
+	}
+
+	var r0 [][]byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([][]byte, error)); ok {
+		return returnFunc(ctx, keys)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) [][]byte); ok {
+		r0 = returnFunc(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRedisService_MGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MGet'
+type MockRedisService_MGet_Call struct {
+	*mock.Call
+}
+
+// MGet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *MockRedisService_Expecter) MGet(ctx interface{}, keys interface{}) *MockRedisService_MGet_Call {
+	return &MockRedisService_MGet_Call{Call: _e.mock.On("MGet", ctx, keys)}
+}
+
+func (_c *MockRedisService_MGet_Call) Run(run func(ctx context.Context, keys []string)) *MockRedisService_MGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRedisService_MGet_Call) Return(bytesList [][]byte, err error) *MockRedisService_MGet_Call {
+	_c.Call.Return(bytesList, err)
+	return _c
+}
+
+func (_c *MockRedisService_MGet_Call) RunAndReturn(run func(ctx context.Context, keys []string) ([][]byte, error)) *MockRedisService_MGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function for the type MockRedisService
+func (_mock *MockRedisService) Set(ctx context.Context, key string, value any) error {
+	ret := _mock.Called(ctx, key, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, any) error); ok {
+		r0 = returnFunc(ctx, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRedisService_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockRedisService_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value any
+func (_e *MockRedisService_Expecter) Set(ctx interface{}, key interface{}, value interface{}) *MockRedisService_Set_Call {
+	return &MockRedisService_Set_Call{Call: _e.mock.On("Set", ctx, key, value)}
+}
+
+func (_c *MockRedisService_Set_Call) Run(run func(ctx context.Context, key string, value any)) *MockRedisService_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 any
+		if args[2] != nil {
+			arg2 = args[2].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRedisService_Set_Call) Return(err error) *MockRedisService_Set_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRedisService_Set_Call) RunAndReturn(run func(ctx context.Context, key string, value any) error) *MockRedisService_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMany provides a mock function for the type MockRedisService
+func (_mock *MockRedisService) SetMany(ctx context.Context, values map[string]any) error {
+	ret := _mock.Called(ctx, values)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMany")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, map[string]any) error); ok {
+		r0 = returnFunc(ctx, values)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRedisService_SetMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMany'
+type MockRedisService_SetMany_Call struct {
+	*mock.Call
+}
+
+// SetMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - values map[string]any
+func (_e *MockRedisService_Expecter) SetMany(ctx interface{}, values interface{}) *MockRedisService_SetMany_Call {
+	return &MockRedisService_SetMany_Call{Call: _e.mock.On("SetMany", ctx, values)}
+}
+
+func (_c *MockRedisService_SetMany_Call) Run(run func(ctx context.Context, values map[string]any)) *MockRedisService_SetMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 map[string]any
+		if args[1] != nil {
+			arg1 = args[1].(map[string]any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRedisService_SetMany_Call) Return(err error) *MockRedisService_SetMany_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRedisService_SetMany_Call) RunAndReturn(run func(ctx context.Context, values map[string]any) error) *MockRedisService_SetMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInfoRequestService creates a new instance of MockInfoRequestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInfoRequestService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInfoRequestService {
+	mock := &MockInfoRequestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockInfoRequestService is an autogenerated mock type for the InfoRequestService type
+type MockInfoRequestService struct {
+	mock.Mock
+}
+
+type MockInfoRequestService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInfoRequestService) EXPECT() *MockInfoRequestService_Expecter {
+	return &MockInfoRequestService_Expecter{mock: &_m.Mock}
+}
+
+// RequestAdditionalInfo provides a mock function for the type MockInfoRequestService
+func (_mock *MockInfoRequestService) RequestAdditionalInfo(name string) (int, string, string, error) {
+	ret := _mock.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestAdditionalInfo")
+	}
+
+	var r0 int
+	var r1 string
+	var r2 string
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(string) (int, string, string, error)); ok {
+		return returnFunc(name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) int); ok {
+		r0 = returnFunc(name)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) string); ok {
+		r1 = returnFunc(name)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(string) string); ok {
+		r2 = returnFunc(name)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+	if returnFunc, ok := ret.Get(3).(func(string) error); ok {
+		r3 = returnFunc(name)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// MockInfoRequestService_RequestAdditionalInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestAdditionalInfo'
+type MockInfoRequestService_RequestAdditionalInfo_Call struct {
+	*mock.Call
+}
+
+// RequestAdditionalInfo is a helper method to define mock.On call
+//   - name string
+func (_e *MockInfoRequestService_Expecter) RequestAdditionalInfo(name interface{}) *MockInfoRequestService_RequestAdditionalInfo_Call {
+	return &MockInfoRequestService_RequestAdditionalInfo_Call{Call: _e.mock.On("RequestAdditionalInfo", name)}
+}
+
+func (_c *MockInfoRequestService_RequestAdditionalInfo_Call) Run(run func(name string)) *MockInfoRequestService_RequestAdditionalInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInfoRequestService_RequestAdditionalInfo_Call) Return(age int, gender string, nationality string, err error) *MockInfoRequestService_RequestAdditionalInfo_Call {
+	_c.Call.Return(age, gender, nationality, err)
+	return _c
+}
+
+func (_c *MockInfoRequestService_RequestAdditionalInfo_Call) RunAndReturn(run func(name string) (int, string, string, error)) *MockInfoRequestService_RequestAdditionalInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockImportService creates a new instance of MockImportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockImportService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockImportService {
+	mock := &MockImportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockImportService is an autogenerated mock type for the ImportService type
+type MockImportService struct {
+	mock.Mock
+}
+
+type MockImportService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockImportService) EXPECT() *MockImportService_Expecter {
+	return &MockImportService_Expecter{mock: &_m.Mock}
+}
+
+// ImportUsers provides a mock function for the type MockImportService
+func (_mock *MockImportService) ImportUsers(r io.Reader, format string, enrich bool) (entities.ImportReport, error) {
+	ret := _mock.Called(r, format, enrich)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportUsers")
+	}
+
+	var r0 entities.ImportReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(io.Reader, string, bool) (entities.ImportReport, error)); ok {
+		return returnFunc(r, format, enrich)
+	}
+	if returnFunc, ok := ret.Get(0).(func(io.Reader, string, bool) entities.ImportReport); ok {
+		r0 = returnFunc(r, format, enrich)
+	} else {
+		r0 = ret.Get(0).(entities.ImportReport)
+	}
+	if returnFunc, ok := ret.Get(1).(func(io.Reader, string, bool) error); ok {
+		r1 = returnFunc(r, format, enrich)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockImportService_ImportUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportUsers'
+type MockImportService_ImportUsers_Call struct {
+	*mock.Call
+}
+
+// ImportUsers is a helper method to define mock.On call
+//   - r io.Reader
+//   - format string
+//   - enrich bool
+func (_e *MockImportService_Expecter) ImportUsers(r interface{}, format interface{}, enrich interface{}) *MockImportService_ImportUsers_Call {
+	return &MockImportService_ImportUsers_Call{Call: _e.mock.On("ImportUsers", r, format, enrich)}
+}
+
+func (_c *MockImportService_ImportUsers_Call) Run(run func(r io.Reader, format string, enrich bool)) *MockImportService_ImportUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 io.Reader
+		if args[0] != nil {
+			arg0 = args[0].(io.Reader)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportService_ImportUsers_Call) Return(importReport entities.ImportReport, err error) *MockImportService_ImportUsers_Call {
+	_c.Call.Return(importReport, err)
+	return _c
+}
+
+func (_c *MockImportService_ImportUsers_Call) RunAndReturn(run func(r io.Reader, format string, enrich bool) (entities.ImportReport, error)) *MockImportService_ImportUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExportService creates a new instance of MockExportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportService {
+	mock := &MockExportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExportService is an autogenerated mock type for the ExportService type
+type MockExportService struct {
+	mock.Mock
+}
+
+type MockExportService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportService) EXPECT() *MockExportService_Expecter {
+	return &MockExportService_Expecter{mock: &_m.Mock}
+}
+
+// ExportUsers provides a mock function for the type MockExportService
+func (_mock *MockExportService) ExportUsers(ctx context.Context, w io.Writer, format string, filter entities.UserFilter) error {
+	ret := _mock.Called(ctx, w, format, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportUsers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Writer, string, entities.UserFilter) error); ok {
+		r0 = returnFunc(ctx, w, format, filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExportService_ExportUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportUsers'
+type MockExportService_ExportUsers_Call struct {
+	*mock.Call
+}
+
+// ExportUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w io.Writer
+//   - format string
+//   - filter entities.UserFilter
+func (_e *MockExportService_Expecter) ExportUsers(ctx interface{}, w interface{}, format interface{}, filter interface{}) *MockExportService_ExportUsers_Call {
+	return &MockExportService_ExportUsers_Call{Call: _e.mock.On("ExportUsers", ctx, w, format, filter)}
+}
+
+func (_c *MockExportService_ExportUsers_Call) Run(run func(ctx context.Context, w io.Writer, format string, filter entities.UserFilter)) *MockExportService_ExportUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 io.Writer
+		if args[1] != nil {
+			arg1 = args[1].(io.Writer)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 entities.UserFilter
+		if args[3] != nil {
+			arg3 = args[3].(entities.UserFilter)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportService_ExportUsers_Call) Return(err error) *MockExportService_ExportUsers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExportService_ExportUsers_Call) RunAndReturn(run func(ctx context.Context, w io.Writer, format string, filter entities.UserFilter) error) *MockExportService_ExportUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStatsService creates a new instance of MockStatsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStatsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStatsService {
+	mock := &MockStatsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStatsService is an autogenerated mock type for the StatsService type
+type MockStatsService struct {
+	mock.Mock
+}
+
+type MockStatsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStatsService) EXPECT() *MockStatsService_Expecter {
+	return &MockStatsService_Expecter{mock: &_m.Mock}
+}
+
+// GetUserStats provides a mock function for the type MockStatsService
+func (_mock *MockStatsService) GetUserStats(ctx context.Context, filter entities.UserFilter, opts entities.StatsOptions) (entities.UserStats, error) {
+	ret := _mock.Called(ctx, filter, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserStats")
+	}
+
+	var r0 entities.UserStats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.UserFilter, entities.StatsOptions) (entities.UserStats, error)); ok {
+		return returnFunc(ctx, filter, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.UserFilter, entities.StatsOptions) entities.UserStats); ok {
+		r0 = returnFunc(ctx, filter, opts)
+	} else {
+		r0 = ret.Get(0).(entities.UserStats)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.UserFilter, entities.StatsOptions) error); ok {
+		r1 = returnFunc(ctx, filter, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStatsService_GetUserStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserStats'
+type MockStatsService_GetUserStats_Call struct {
+	*mock.Call
+}
+
+// GetUserStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entities.UserFilter
+//   - opts entities.StatsOptions
+func (_e *MockStatsService_Expecter) GetUserStats(ctx interface{}, filter interface{}, opts interface{}) *MockStatsService_GetUserStats_Call {
+	return &MockStatsService_GetUserStats_Call{Call: _e.mock.On("GetUserStats", ctx, filter, opts)}
+}
+
+func (_c *MockStatsService_GetUserStats_Call) Run(run func(ctx context.Context, filter entities.UserFilter, opts entities.StatsOptions)) *MockStatsService_GetUserStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 entities.UserFilter
+		if args[1] != nil {
+			arg1 = args[1].(entities.UserFilter)
+		}
+		var arg2 entities.StatsOptions
+		if args[2] != nil {
+			arg2 = args[2].(entities.StatsOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsService_GetUserStats_Call) Return(userStats entities.UserStats, err error) *MockStatsService_GetUserStats_Call {
+	_c.Call.Return(userStats, err)
+	return _c
+}
+
+func (_c *MockStatsService_GetUserStats_Call) RunAndReturn(run func(ctx context.Context, filter entities.UserFilter, opts entities.StatsOptions) (entities.UserStats, error)) *MockStatsService_GetUserStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateStats provides a mock function for the type MockStatsService
+func (_mock *MockStatsService) InvalidateStats(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateStats")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStatsService_InvalidateStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateStats'
+type MockStatsService_InvalidateStats_Call struct {
+	*mock.Call
+}
+
+// InvalidateStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStatsService_Expecter) InvalidateStats(ctx interface{}) *MockStatsService_InvalidateStats_Call {
+	return &MockStatsService_InvalidateStats_Call{Call: _e.mock.On("InvalidateStats", ctx)}
+}
+
+func (_c *MockStatsService_InvalidateStats_Call) Run(run func(ctx context.Context)) *MockStatsService_InvalidateStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsService_InvalidateStats_Call) Return(err error) *MockStatsService_InvalidateStats_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStatsService_InvalidateStats_Call) RunAndReturn(run func(ctx context.Context) error) *MockStatsService_InvalidateStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAttributeService creates a new instance of MockAttributeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAttributeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAttributeService {
+	mock := &MockAttributeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAttributeService is an autogenerated mock type for the AttributeService type
+type MockAttributeService struct {
+	mock.Mock
+}
+
+type MockAttributeService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAttributeService) EXPECT() *MockAttributeService_Expecter {
+	return &MockAttributeService_Expecter{mock: &_m.Mock}
+}
+
+// CreateDefinition provides a mock function for the type MockAttributeService
+func (_mock *MockAttributeService) CreateDefinition(def entities.AttributeDefinition) (entities.AttributeDefinition, error) {
+	ret := _mock.Called(def)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDefinition")
+	}
+
+	var r0 entities.AttributeDefinition
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(entities.AttributeDefinition) (entities.AttributeDefinition, error)); ok {
+		return returnFunc(def)
+	}
+	if returnFunc, ok := ret.Get(0).(func(entities.AttributeDefinition) entities.AttributeDefinition); ok {
+		r0 = returnFunc(def)
+	} else {
+		r0 = ret.Get(0).(entities.AttributeDefinition)
+	}
+	if returnFunc, ok := ret.Get(1).(func(entities.AttributeDefinition) error); ok {
+		r1 = returnFunc(def)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAttributeService_CreateDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDefinition'
+type MockAttributeService_CreateDefinition_Call struct {
+	*mock.Call
+}
+
+// CreateDefinition is a helper method to define mock.On call
+//   - def entities.AttributeDefinition
+func (_e *MockAttributeService_Expecter) CreateDefinition(def interface{}) *MockAttributeService_CreateDefinition_Call {
+	return &MockAttributeService_CreateDefinition_Call{Call: _e.mock.On("CreateDefinition", def)}
+}
+
+func (_c *MockAttributeService_CreateDefinition_Call) Run(run func(def entities.AttributeDefinition)) *MockAttributeService_CreateDefinition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 entities.AttributeDefinition
+		if args[0] != nil {
+			arg0 = args[0].(entities.AttributeDefinition)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAttributeService_CreateDefinition_Call) Return(attributeDefinition entities.AttributeDefinition, err error) *MockAttributeService_CreateDefinition_Call {
+	_c.Call.Return(attributeDefinition, err)
+	return _c
+}
+
+func (_c *MockAttributeService_CreateDefinition_Call) RunAndReturn(run func(def entities.AttributeDefinition) (entities.AttributeDefinition, error)) *MockAttributeService_CreateDefinition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDefinition provides a mock function for the type MockAttributeService
+func (_mock *MockAttributeService) DeleteDefinition(name string) error {
+	ret := _mock.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDefinition")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAttributeService_DeleteDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDefinition'
+type MockAttributeService_DeleteDefinition_Call struct {
+	*mock.Call
+}
+
+// DeleteDefinition is a helper method to define mock.On call
+//   - name string
+func (_e *MockAttributeService_Expecter) DeleteDefinition(name interface{}) *MockAttributeService_DeleteDefinition_Call {
+	return &MockAttributeService_DeleteDefinition_Call{Call: _e.mock.On("DeleteDefinition", name)}
+}
+
+func (_c *MockAttributeService_DeleteDefinition_Call) Run(run func(name string)) *MockAttributeService_DeleteDefinition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAttributeService_DeleteDefinition_Call) Return(err error) *MockAttributeService_DeleteDefinition_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAttributeService_DeleteDefinition_Call) RunAndReturn(run func(name string) error) *MockAttributeService_DeleteDefinition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDefinition provides a mock function for the type MockAttributeService
+func (_mock *MockAttributeService) GetDefinition(name string) (entities.AttributeDefinition, error) {
+	ret := _mock.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDefinition")
+	}
+
+	var r0 entities.AttributeDefinition
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (entities.AttributeDefinition, error)); ok {
+		return returnFunc(name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) entities.AttributeDefinition); ok {
+		r0 = returnFunc(name)
+	} else {
+		r0 = ret.Get(0).(entities.AttributeDefinition)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAttributeService_GetDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDefinition'
+type MockAttributeService_GetDefinition_Call struct {
+	*mock.Call
+}
+
+// GetDefinition is a helper method to define mock.On call
+//   - name string
+func (_e *MockAttributeService_Expecter) GetDefinition(name interface{}) *MockAttributeService_GetDefinition_Call {
+	return &MockAttributeService_GetDefinition_Call{Call: _e.mock.On("GetDefinition", name)}
+}
+
+func (_c *MockAttributeService_GetDefinition_Call) Run(run func(name string)) *MockAttributeService_GetDefinition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAttributeService_GetDefinition_Call) Return(attributeDefinition entities.AttributeDefinition, err error) *MockAttributeService_GetDefinition_Call {
+	_c.Call.Return(attributeDefinition, err)
+	return _c
+}
+
+func (_c *MockAttributeService_GetDefinition_Call) RunAndReturn(run func(name string) (entities.AttributeDefinition, error)) *MockAttributeService_GetDefinition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDefinitions provides a mock function for the type MockAttributeService
+func (_mock *MockAttributeService) GetDefinitions() ([]entities.AttributeDefinition, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDefinitions")
+	}
+
+	var r0 []entities.AttributeDefinition
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]entities.AttributeDefinition, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []entities.AttributeDefinition); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.AttributeDefinition)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAttributeService_GetDefinitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDefinitions'
+type MockAttributeService_GetDefinitions_Call struct {
+	*mock.Call
+}
+
+// GetDefinitions is a helper method to define mock.On call
+func (_e *MockAttributeService_Expecter) GetDefinitions() *MockAttributeService_GetDefinitions_Call {
+	return &MockAttributeService_GetDefinitions_Call{Call: _e.mock.On("GetDefinitions")}
+}
+
+func (_c *MockAttributeService_GetDefinitions_Call) Run(run func()) *MockAttributeService_GetDefinitions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAttributeService_GetDefinitions_Call) Return(attributeDefinitions []entities.AttributeDefinition, err error) *MockAttributeService_GetDefinitions_Call {
+	_c.Call.Return(attributeDefinitions, err)
+	return _c
+}
+
+func (_c *MockAttributeService_GetDefinitions_Call) RunAndReturn(run func() ([]entities.AttributeDefinition, error)) *MockAttributeService_GetDefinitions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateDefinition provides a mock function for the type MockAttributeService
+func (_mock *MockAttributeService) UpdateDefinition(def entities.AttributeDefinition) (entities.AttributeDefinition, error) {
+	ret := _mock.Called(def)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDefinition")
+	}
+
+	var r0 entities.AttributeDefinition
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(entities.AttributeDefinition) (entities.AttributeDefinition, error)); ok {
+		return returnFunc(def)
+	}
+	if returnFunc, ok := ret.Get(0).(func(entities.AttributeDefinition) entities.AttributeDefinition); ok {
+		r0 = returnFunc(def)
+	} else {
+		r0 = ret.Get(0).(entities.AttributeDefinition)
+	}
+	if returnFunc, ok := ret.Get(1).(func(entities.AttributeDefinition) error); ok {
+		r1 = returnFunc(def)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAttributeService_UpdateDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDefinition'
+type MockAttributeService_UpdateDefinition_Call struct {
+	*mock.Call
+}
+
+// UpdateDefinition is a helper method to define mock.On call
+//   - def entities.AttributeDefinition
+func (_e *MockAttributeService_Expecter) UpdateDefinition(def interface{}) *MockAttributeService_UpdateDefinition_Call {
+	return &MockAttributeService_UpdateDefinition_Call{Call: _e.mock.On("UpdateDefinition", def)}
+}
+
+func (_c *MockAttributeService_UpdateDefinition_Call) Run(run func(def entities.AttributeDefinition)) *MockAttributeService_UpdateDefinition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 entities.AttributeDefinition
+		if args[0] != nil {
+			arg0 = args[0].(entities.AttributeDefinition)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAttributeService_UpdateDefinition_Call) Return(attributeDefinition entities.AttributeDefinition, err error) *MockAttributeService_UpdateDefinition_Call {
+	_c.Call.Return(attributeDefinition, err)
+	return _c
+}
+
+func (_c *MockAttributeService_UpdateDefinition_Call) RunAndReturn(run func(def entities.AttributeDefinition) (entities.AttributeDefinition, error)) *MockAttributeService_UpdateDefinition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGroupService creates a new instance of MockGroupService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGroupService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGroupService {
+	mock := &MockGroupService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockGroupService is an autogenerated mock type for the GroupService type
+type MockGroupService struct {
+	mock.Mock
+}
+
+type MockGroupService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGroupService) EXPECT() *MockGroupService_Expecter {
+	return &MockGroupService_Expecter{mock: &_m.Mock}
+}
+
+// CreateGroup provides a mock function for the type MockGroupService
+func (_mock *MockGroupService) CreateGroup(params entities.CreateGroupParams) (entities.Group, error) {
+	ret := _mock.Called(params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGroup")
+	}
+
+	var r0 entities.Group
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(entities.CreateGroupParams) (entities.Group, error)); ok {
+		return returnFunc(params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(entities.CreateGroupParams) entities.Group); ok {
+		r0 = returnFunc(params)
+	} else {
+		r0 = ret.Get(0).(entities.Group)
+	}
+	if returnFunc, ok := ret.Get(1).(func(entities.CreateGroupParams) error); ok {
+		r1 = returnFunc(params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGroupService_CreateGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGroup'
+type MockGroupService_CreateGroup_Call struct {
+	*mock.Call
+}
+
+// CreateGroup is a helper method to define mock.On call
+//   - params entities.CreateGroupParams
+func (_e *MockGroupService_Expecter) CreateGroup(params interface{}) *MockGroupService_CreateGroup_Call {
+	return &MockGroupService_CreateGroup_Call{Call: _e.mock.On("CreateGroup", params)}
+}
+
+func (_c *MockGroupService_CreateGroup_Call) Run(run func(params entities.CreateGroupParams)) *MockGroupService_CreateGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 entities.CreateGroupParams
+		if args[0] != nil {
+			arg0 = args[0].(entities.CreateGroupParams)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGroupService_CreateGroup_Call) Return(group entities.Group, err error) *MockGroupService_CreateGroup_Call {
+	_c.Call.Return(group, err)
+	return _c
+}
+
+func (_c *MockGroupService_CreateGroup_Call) RunAndReturn(run func(params entities.CreateGroupParams) (entities.Group, error)) *MockGroupService_CreateGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteGroup provides a mock function for the type MockGroupService
+func (_mock *MockGroupService) DeleteGroup(id int32) error {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroup")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int32) error); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGroupService_DeleteGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGroup'
+type MockGroupService_DeleteGroup_Call struct {
+	*mock.Call
+}
+
+// DeleteGroup is a helper method to define mock.On call
+//   - id int32
+func (_e *MockGroupService_Expecter) DeleteGroup(id interface{}) *MockGroupService_DeleteGroup_Call {
+	return &MockGroupService_DeleteGroup_Call{Call: _e.mock.On("DeleteGroup", id)}
+}
+
+func (_c *MockGroupService_DeleteGroup_Call) Run(run func(id int32)) *MockGroupService_DeleteGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int32
+		if args[0] != nil {
+			arg0 = args[0].(int32)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGroupService_DeleteGroup_Call) Return(err error) *MockGroupService_DeleteGroup_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGroupService_DeleteGroup_Call) RunAndReturn(run func(id int32) error) *MockGroupService_DeleteGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroupById provides a mock function for the type MockGroupService
+func (_mock *MockGroupService) GetGroupById(id int32) (entities.Group, error) {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupById")
+	}
+
+	var r0 entities.Group
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int32) (entities.Group, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int32) entities.Group); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Get(0).(entities.Group)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int32) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGroupService_GetGroupById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroupById'
+type MockGroupService_GetGroupById_Call struct {
+	*mock.Call
+}
+
+// GetGroupById is a helper method to define mock.On call
+//   - id int32
+func (_e *MockGroupService_Expecter) GetGroupById(id interface{}) *MockGroupService_GetGroupById_Call {
+	return &MockGroupService_GetGroupById_Call{Call: _e.mock.On("GetGroupById", id)}
+}
+
+func (_c *MockGroupService_GetGroupById_Call) Run(run func(id int32)) *MockGroupService_GetGroupById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int32
+		if args[0] != nil {
+			arg0 = args[0].(int32)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGroupService_GetGroupById_Call) Return(group entities.Group, err error) *MockGroupService_GetGroupById_Call {
+	_c.Call.Return(group, err)
+	return _c
+}
+
+func (_c *MockGroupService_GetGroupById_Call) RunAndReturn(run func(id int32) (entities.Group, error)) *MockGroupService_GetGroupById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroupMembers provides a mock function for the type MockGroupService
+func (_mock *MockGroupService) GetGroupMembers(groupId int32, recursive bool) ([]entities.GroupMember, error) {
+	ret := _mock.Called(groupId, recursive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupMembers")
+	}
+
+	var r0 []entities.GroupMember
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int32, bool) ([]entities.GroupMember, error)); ok {
+		return returnFunc(groupId, recursive)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int32, bool) []entities.GroupMember); ok {
+		r0 = returnFunc(groupId, recursive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.GroupMember)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int32, bool) error); ok {
+		r1 = returnFunc(groupId, recursive)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGroupService_GetGroupMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroupMembers'
+type MockGroupService_GetGroupMembers_Call struct {
+	*mock.Call
+}
+
+// GetGroupMembers is a helper method to define mock.On call
+//   - groupId int32
+//   - recursive bool
+func (_e *MockGroupService_Expecter) GetGroupMembers(groupId interface{}, recursive interface{}) *MockGroupService_GetGroupMembers_Call {
+	return &MockGroupService_GetGroupMembers_Call{Call: _e.mock.On("GetGroupMembers", groupId, recursive)}
+}
+
+func (_c *MockGroupService_GetGroupMembers_Call) Run(run func(groupId int32, recursive bool)) *MockGroupService_GetGroupMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int32
+		if args[0] != nil {
+			arg0 = args[0].(int32)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGroupService_GetGroupMembers_Call) Return(groupMembers []entities.GroupMember, err error) *MockGroupService_GetGroupMembers_Call {
+	_c.Call.Return(groupMembers, err)
+	return _c
+}
+
+func (_c *MockGroupService_GetGroupMembers_Call) RunAndReturn(run func(groupId int32, recursive bool) ([]entities.GroupMember, error)) *MockGroupService_GetGroupMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroups provides a mock function for the type MockGroupService
+func (_mock *MockGroupService) GetGroups(pageSize int, page int, filter entities.GroupFilter) ([]entities.Group, int, error) {
+	ret := _mock.Called(pageSize, page, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroups")
+	}
+
+	var r0 []entities.Group
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, entities.GroupFilter) ([]entities.Group, int, error)); ok {
+		return returnFunc(pageSize, page, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, entities.GroupFilter) []entities.Group); ok {
+		r0 = returnFunc(pageSize, page, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Group)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, entities.GroupFilter) int); ok {
+		r1 = returnFunc(pageSize, page, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(int, int, entities.GroupFilter) error); ok {
+		r2 = returnFunc(pageSize, page, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockGroupService_GetGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroups'
+type MockGroupService_GetGroups_Call struct {
+	*mock.Call
+}
+
+// GetGroups is a helper method to define mock.On call
+//   - pageSize int
+//   - page int
+//   - filter entities.GroupFilter
+func (_e *MockGroupService_Expecter) GetGroups(pageSize interface{}, page interface{}, filter interface{}) *MockGroupService_GetGroups_Call {
+	return &MockGroupService_GetGroups_Call{Call: _e.mock.On("GetGroups", pageSize, page, filter)}
+}
+
+func (_c *MockGroupService_GetGroups_Call) Run(run func(pageSize int, page int, filter entities.GroupFilter)) *MockGroupService_GetGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 entities.GroupFilter
+		if args[2] != nil {
+			arg2 = args[2].(entities.GroupFilter)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGroupService_GetGroups_Call) Return(groups []entities.Group, totalCount int, err error) *MockGroupService_GetGroups_Call {
+	_c.Call.Return(groups, totalCount, err)
+	return _c
+}
+
+func (_c *MockGroupService_GetGroups_Call) RunAndReturn(run func(pageSize int, page int, filter entities.GroupFilter) ([]entities.Group, int, error)) *MockGroupService_GetGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserGroups provides a mock function for the type MockGroupService
+func (_mock *MockGroupService) GetUserGroups(userId int32, recursive bool) ([]entities.UserGroup, error) {
+	ret := _mock.Called(userId, recursive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserGroups")
+	}
+
+	var r0 []entities.UserGroup
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int32, bool) ([]entities.UserGroup, error)); ok {
+		return returnFunc(userId, recursive)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int32, bool) []entities.UserGroup); ok {
+		r0 = returnFunc(userId, recursive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.UserGroup)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int32, bool) error); ok {
+		r1 = returnFunc(userId, recursive)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGroupService_GetUserGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserGroups'
+type MockGroupService_GetUserGroups_Call struct {
+	*mock.Call
+}
+
+// GetUserGroups is a helper method to define mock.On call
+//   - userId int32
+//   - recursive bool
+func (_e *MockGroupService_Expecter) GetUserGroups(userId interface{}, recursive interface{}) *MockGroupService_GetUserGroups_Call {
+	return &MockGroupService_GetUserGroups_Call{Call: _e.mock.On("GetUserGroups", userId, recursive)}
+}
+
+func (_c *MockGroupService_GetUserGroups_Call) Run(run func(userId int32, recursive bool)) *MockGroupService_GetUserGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int32
+		if args[0] != nil {
+			arg0 = args[0].(int32)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGroupService_GetUserGroups_Call) Return(userGroups []entities.UserGroup, err error) *MockGroupService_GetUserGroups_Call {
+	_c.Call.Return(userGroups, err)
+	return _c
+}
+
+func (_c *MockGroupService_GetUserGroups_Call) RunAndReturn(run func(userId int32, recursive bool) ([]entities.UserGroup, error)) *MockGroupService_GetUserGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveGroupMember provides a mock function for the type MockGroupService
+func (_mock *MockGroupService) RemoveGroupMember(groupId int32, userId int32) error {
+	ret := _mock.Called(groupId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveGroupMember")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int32, int32) error); ok {
+		r0 = returnFunc(groupId, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGroupService_RemoveGroupMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveGroupMember'
+type MockGroupService_RemoveGroupMember_Call struct {
+	*mock.Call
+}
+
+// RemoveGroupMember is a helper method to define mock.On call
+//   - groupId int32
+//   - userId int32
+func (_e *MockGroupService_Expecter) RemoveGroupMember(groupId interface{}, userId interface{}) *MockGroupService_RemoveGroupMember_Call {
+	return &MockGroupService_RemoveGroupMember_Call{Call: _e.mock.On("RemoveGroupMember", groupId, userId)}
+}
+
+func (_c *MockGroupService_RemoveGroupMember_Call) Run(run func(groupId int32, userId int32)) *MockGroupService_RemoveGroupMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int32
+		if args[0] != nil {
+			arg0 = args[0].(int32)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGroupService_RemoveGroupMember_Call) Return(err error) *MockGroupService_RemoveGroupMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGroupService_RemoveGroupMember_Call) RunAndReturn(run func(groupId int32, userId int32) error) *MockGroupService_RemoveGroupMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetGroupMember provides a mock function for the type MockGroupService
+func (_mock *MockGroupService) SetGroupMember(groupId int32, userId int32, params entities.GroupMemberParams) (entities.GroupMember, error) {
+	ret := _mock.Called(groupId, userId, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetGroupMember")
+	}
+
+	var r0 entities.GroupMember
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int32, int32, entities.GroupMemberParams) (entities.GroupMember, error)); ok {
+		return returnFunc(groupId, userId, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int32, int32, entities.GroupMemberParams) entities.GroupMember); ok {
+		r0 = returnFunc(groupId, userId, params)
+	} else {
+		r0 = ret.Get(0).(entities.GroupMember)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int32, int32, entities.GroupMemberParams) error); ok {
+		r1 = returnFunc(groupId, userId, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGroupService_SetGroupMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetGroupMember'
+type MockGroupService_SetGroupMember_Call struct {
+	*mock.Call
+}
+
+// SetGroupMember is a helper method to define mock.On call
+//   - groupId int32
+//   - userId int32
+//   - params entities.GroupMemberParams
+func (_e *MockGroupService_Expecter) SetGroupMember(groupId interface{}, userId interface{}, params interface{}) *MockGroupService_SetGroupMember_Call {
+	return &MockGroupService_SetGroupMember_Call{Call: _e.mock.On("SetGroupMember", groupId, userId, params)}
+}
+
+func (_c *MockGroupService_SetGroupMember_Call) Run(run func(groupId int32, userId int32, params entities.GroupMemberParams)) *MockGroupService_SetGroupMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int32
+		if args[0] != nil {
+			arg0 = args[0].(int32)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		var arg2 entities.GroupMemberParams
+		if args[2] != nil {
+			arg2 = args[2].(entities.GroupMemberParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGroupService_SetGroupMember_Call) Return(groupMember entities.GroupMember, err error) *MockGroupService_SetGroupMember_Call {
+	_c.Call.Return(groupMember, err)
+	return _c
+}
+
+func (_c *MockGroupService_SetGroupMember_Call) RunAndReturn(run func(groupId int32, userId int32, params entities.GroupMemberParams) (entities.GroupMember, error)) *MockGroupService_SetGroupMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateGroup provides a mock function for the type MockGroupService
+func (_mock *MockGroupService) UpdateGroup(id int32, params entities.UpdateGroupParams) (entities.Group, error) {
+	ret := _mock.Called(id, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateGroup")
+	}
+
+	var r0 entities.Group
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int32, entities.UpdateGroupParams) (entities.Group, error)); ok {
+		return returnFunc(id, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int32, entities.UpdateGroupParams) entities.Group); ok {
+		r0 = returnFunc(id, params)
+	} else {
+		r0 = ret.Get(0).(entities.Group)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int32, entities.UpdateGroupParams) error); ok {
+		r1 = returnFunc(id, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGroupService_UpdateGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateGroup'
+type MockGroupService_UpdateGroup_Call struct {
+	*mock.Call
+}
+
+// UpdateGroup is a helper method to define mock.On call
+//   - id int32
+//   - params entities.UpdateGroupParams
+func (_e *MockGroupService_Expecter) UpdateGroup(id interface{}, params interface{}) *MockGroupService_UpdateGroup_Call {
+	return &MockGroupService_UpdateGroup_Call{Call: _e.mock.On("UpdateGroup", id, params)}
+}
+
+func (_c *MockGroupService_UpdateGroup_Call) Run(run func(id int32, params entities.UpdateGroupParams)) *MockGroupService_UpdateGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int32
+		if args[0] != nil {
+			arg0 = args[0].(int32)
+		}
+		var arg1 entities.UpdateGroupParams
+		if args[1] != nil {
+			arg1 = args[1].(entities.UpdateGroupParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGroupService_UpdateGroup_Call) Return(group entities.Group, err error) *MockGroupService_UpdateGroup_Call {
+	_c.Call.Return(group, err)
+	return _c
+}
+
+func (_c *MockGroupService_UpdateGroup_Call) RunAndReturn(run func(id int32, params entities.UpdateGroupParams) (entities.Group, error)) *MockGroupService_UpdateGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockContactService creates a new instance of MockContactService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockContactService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockContactService {
+	mock := &MockContactService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockContactService is an autogenerated mock type for the ContactService type
+type MockContactService struct {
+	mock.Mock
+}
+
+type MockContactService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockContactService) EXPECT() *MockContactService_Expecter {
+	return &MockContactService_Expecter{mock: &_m.Mock}
+}
+
+// CreateContact provides a mock function for the type MockContactService
+func (_mock *MockContactService) CreateContact(kind string, userId int32, params entities.CreateContactParams) (entities.Contact, error) {
+	ret := _mock.Called(kind, userId, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateContact")
+	}
+
+	var r0 entities.Contact
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, int32, entities.CreateContactParams) (entities.Contact, error)); ok {
+		return returnFunc(kind, userId, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int32, entities.CreateContactParams) entities.Contact); ok {
+		r0 = returnFunc(kind, userId, params)
+	} else {
+		r0 = ret.Get(0).(entities.Contact)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int32, entities.CreateContactParams) error); ok {
+		r1 = returnFunc(kind, userId, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockContactService_CreateContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateContact'
+type MockContactService_CreateContact_Call struct {
+	*mock.Call
+}
+
+// CreateContact is a helper method to define mock.On call
+//   - kind string
+//   - userId int32
+//   - params entities.CreateContactParams
+func (_e *MockContactService_Expecter) CreateContact(kind interface{}, userId interface{}, params interface{}) *MockContactService_CreateContact_Call {
+	return &MockContactService_CreateContact_Call{Call: _e.mock.On("CreateContact", kind, userId, params)}
 }
 
-func (_c *MockUserService_UpdateUser_Call) Run(run func(id int32, params entities.UpdateUserParams)) *MockUserService_UpdateUser_Call {
+func (_c *MockContactService_CreateContact_Call) Run(run func(kind string, userId int32, params entities.CreateContactParams)) *MockContactService_CreateContact_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int32
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].(int32)
+			arg0 = args[0].(string)
 		}
-		var arg1 entities.UpdateUserParams
+		var arg1 int32
 		if args[1] != nil {
-			arg1 = args[1].(entities.UpdateUserParams)
+			arg1 = args[1].(int32)
+		}
+		var arg2 entities.CreateContactParams
+		if args[2] != nil {
+			arg2 = args[2].(entities.CreateContactParams)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserService_UpdateUser_Call) Return(err error) *MockUserService_UpdateUser_Call {
-	_c.Call.Return(err)
+func (_c *MockContactService_CreateContact_Call) Return(contact entities.Contact, err error) *MockContactService_CreateContact_Call {
+	_c.Call.Return(contact, err)
 	return _c
 }
 
-func (_c *MockUserService_UpdateUser_Call) RunAndReturn(run func(id int32, params entities.UpdateUserParams) error) *MockUserService_UpdateUser_Call {
+func (_c *MockContactService_CreateContact_Call) RunAndReturn(run func(kind string, userId int32, params entities.CreateContactParams) (entities.Contact, error)) *MockContactService_CreateContact_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockRedisService creates a new instance of MockRedisService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockRedisService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockRedisService {
-	mock := &MockRedisService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockRedisService is an autogenerated mock type for the RedisService type
-type MockRedisService struct {
-	mock.Mock
-}
-
-type MockRedisService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockRedisService) EXPECT() *MockRedisService_Expecter {
-	return &MockRedisService_Expecter{mock: &_m.Mock}
-}
-
-// Delete provides a mock function for the type MockRedisService
-func (_mock *MockRedisService) Delete(ctx context.Context, key string) error {
-	ret := _mock.Called(ctx, key)
+// DeleteContact provides a mock function for the type MockContactService
+func (_mock *MockContactService) DeleteContact(kind string, userId int32, contactId int32) error {
+	ret := _mock.Called(kind, userId, contactId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for DeleteContact")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, key)
+	if returnFunc, ok := ret.Get(0).(func(string, int32, int32) error); ok {
+		r0 = returnFunc(kind, userId, contactId)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRedisService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockRedisService_Delete_Call struct {
+// MockContactService_DeleteContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContact'
+type MockContactService_DeleteContact_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
-//   - ctx context.Context
-//   - key string
-func (_e *MockRedisService_Expecter) Delete(ctx interface{}, key interface{}) *MockRedisService_Delete_Call {
-	return &MockRedisService_Delete_Call{Call: _e.mock.On("Delete", ctx, key)}
+// DeleteContact is a helper method to define mock.On call
+//   - kind string
+//   - userId int32
+//   - contactId int32
+func (_e *MockContactService_Expecter) DeleteContact(kind interface{}, userId interface{}, contactId interface{}) *MockContactService_DeleteContact_Call {
+	return &MockContactService_DeleteContact_Call{Call: _e.mock.On("DeleteContact", kind, userId, contactId)}
 }
 
-func (_c *MockRedisService_Delete_Call) Run(run func(ctx context.Context, key string)) *MockRedisService_Delete_Call {
+func (_c *MockContactService_DeleteContact_Call) Run(run func(kind string, userId int32, contactId int32)) *MockContactService_DeleteContact_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].(context.Context)
+			arg0 = args[0].(string)
 		}
-		var arg1 string
+		var arg1 int32
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int32)
+		}
+		var arg2 int32
+		if args[2] != nil {
+			arg2 = args[2].(int32)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRedisService_Delete_Call) Return(err error) *MockRedisService_Delete_Call {
+func (_c *MockContactService_DeleteContact_Call) Return(err error) *MockContactService_DeleteContact_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRedisService_Delete_Call) RunAndReturn(run func(ctx context.Context, key string) error) *MockRedisService_Delete_Call {
+func (_c *MockContactService_DeleteContact_Call) RunAndReturn(run func(kind string, userId int32, contactId int32) error) *MockContactService_DeleteContact_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Get provides a mock function for the type MockRedisService
-func (_mock *MockRedisService) Get(ctx context.Context, key string, dest any) error {
-	ret := _mock.Called(ctx, key, dest)
+// GetContacts provides a mock function for the type MockContactService
+func (_mock *MockContactService) GetContacts(kind string, userId int32) ([]entities.Contact, error) {
+	ret := _mock.Called(kind, userId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for GetContacts")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, any) error); ok {
-		r0 = returnFunc(ctx, key, dest)
+	var r0 []entities.Contact
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, int32) ([]entities.Contact, error)); ok {
+		return returnFunc(kind, userId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int32) []entities.Contact); ok {
+		r0 = returnFunc(kind, userId)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Contact)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(string, int32) error); ok {
+		r1 = returnFunc(kind, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRedisService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockRedisService_Get_Call struct {
+// MockContactService_GetContacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContacts'
+type MockContactService_GetContacts_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
-//   - ctx context.Context
-//   - key string
-//   - dest any
-func (_e *MockRedisService_Expecter) Get(ctx interface{}, key interface{}, dest interface{}) *MockRedisService_Get_Call {
-	return &MockRedisService_Get_Call{Call: _e.mock.On("Get", ctx, key, dest)}
+// GetContacts is a helper method to define mock.On call
+//   - kind string
+//   - userId int32
+func (_e *MockContactService_Expecter) GetContacts(kind interface{}, userId interface{}) *MockContactService_GetContacts_Call {
+	return &MockContactService_GetContacts_Call{Call: _e.mock.On("GetContacts", kind, userId)}
 }
 
-func (_c *MockRedisService_Get_Call) Run(run func(ctx context.Context, key string, dest any)) *MockRedisService_Get_Call {
+func (_c *MockContactService_GetContacts_Call) Run(run func(kind string, userId int32)) *MockContactService_GetContacts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].(context.Context)
+			arg0 = args[0].(string)
 		}
-		var arg1 string
+		var arg1 int32
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 any
-		if args[2] != nil {
-			arg2 = args[2].(any)
+			arg1 = args[1].(int32)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRedisService_Get_Call) Return(err error) *MockRedisService_Get_Call {
-	_c.Call.Return(err)
+func (_c *MockContactService_GetContacts_Call) Return(contacts []entities.Contact, err error) *MockContactService_GetContacts_Call {
+	_c.Call.Return(contacts, err)
 	return _c
 }
 
-func (_c *MockRedisService_Get_Call) RunAndReturn(run func(ctx context.Context, key string, dest any) error) *MockRedisService_Get_Call {
+func (_c *MockContactService_GetContacts_Call) RunAndReturn(run func(kind string, userId int32) ([]entities.Contact, error)) *MockContactService_GetContacts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Set provides a mock function for the type MockRedisService
-func (_mock *MockRedisService) Set(ctx context.Context, key string, value any) error {
-	ret := _mock.Called(ctx, key, value)
+// SendVerificationCode provides a mock function for the type MockContactService
+func (_mock *MockContactService) SendVerificationCode(ctx context.Context, kind string, userId int32, contactId int32) (entities.VerificationSent, error) {
+	ret := _mock.Called(ctx, kind, userId, contactId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Set")
+		panic("no return value specified for SendVerificationCode")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, any) error); ok {
-		r0 = returnFunc(ctx, key, value)
+	var r0 entities.VerificationSent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int32, int32) (entities.VerificationSent, error)); ok {
+		return returnFunc(ctx, kind, userId, contactId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int32, int32) entities.VerificationSent); ok {
+		r0 = returnFunc(ctx, kind, userId, contactId)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(entities.VerificationSent)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int32, int32) error); ok {
+		r1 = returnFunc(ctx, kind, userId, contactId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRedisService_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
-type MockRedisService_Set_Call struct {
+// MockContactService_SendVerificationCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendVerificationCode'
+type MockContactService_SendVerificationCode_Call struct {
 	*mock.Call
 }
 
-// Set is a helper method to define mock.On call
+// SendVerificationCode is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key string
-//   - value any
-func (_e *MockRedisService_Expecter) Set(ctx interface{}, key interface{}, value interface{}) *MockRedisService_Set_Call {
-	return &MockRedisService_Set_Call{Call: _e.mock.On("Set", ctx, key, value)}
+//   - kind string
+//   - userId int32
+//   - contactId int32
+func (_e *MockContactService_Expecter) SendVerificationCode(ctx interface{}, kind interface{}, userId interface{}, contactId interface{}) *MockContactService_SendVerificationCode_Call {
+	return &MockContactService_SendVerificationCode_Call{Call: _e.mock.On("SendVerificationCode", ctx, kind, userId, contactId)}
 }
 
-func (_c *MockRedisService_Set_Call) Run(run func(ctx context.Context, key string, value any)) *MockRedisService_Set_Call {
+func (_c *MockContactService_SendVerificationCode_Call) Run(run func(ctx context.Context, kind string, userId int32, contactId int32)) *MockContactService_SendVerificationCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -671,124 +3243,192 @@ func (_c *MockRedisService_Set_Call) Run(run func(ctx context.Context, key strin
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 any
+		var arg2 int32
 		if args[2] != nil {
-			arg2 = args[2].(any)
+			arg2 = args[2].(int32)
+		}
+		var arg3 int32
+		if args[3] != nil {
+			arg3 = args[3].(int32)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRedisService_Set_Call) Return(err error) *MockRedisService_Set_Call {
-	_c.Call.Return(err)
+func (_c *MockContactService_SendVerificationCode_Call) Return(verificationSent entities.VerificationSent, err error) *MockContactService_SendVerificationCode_Call {
+	_c.Call.Return(verificationSent, err)
 	return _c
 }
 
-func (_c *MockRedisService_Set_Call) RunAndReturn(run func(ctx context.Context, key string, value any) error) *MockRedisService_Set_Call {
+func (_c *MockContactService_SendVerificationCode_Call) RunAndReturn(run func(ctx context.Context, kind string, userId int32, contactId int32) (entities.VerificationSent, error)) *MockContactService_SendVerificationCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockInfoRequestService creates a new instance of MockInfoRequestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockInfoRequestService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockInfoRequestService {
-	mock := &MockInfoRequestService{}
-	mock.Mock.Test(t)
+// UpdateContact provides a mock function for the type MockContactService
+func (_mock *MockContactService) UpdateContact(kind string, userId int32, contactId int32, params entities.UpdateContactParams) (entities.Contact, error) {
+	ret := _mock.Called(kind, userId, contactId, params)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateContact")
+	}
 
-	return mock
+	var r0 entities.Contact
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, int32, int32, entities.UpdateContactParams) (entities.Contact, error)); ok {
+		return returnFunc(kind, userId, contactId, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int32, int32, entities.UpdateContactParams) entities.Contact); ok {
+		r0 = returnFunc(kind, userId, contactId, params)
+	} else {
+		r0 = ret.Get(0).(entities.Contact)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int32, int32, entities.UpdateContactParams) error); ok {
+		r1 = returnFunc(kind, userId, contactId, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockInfoRequestService is an autogenerated mock type for the InfoRequestService type
-type MockInfoRequestService struct {
-	mock.Mock
+// MockContactService_UpdateContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateContact'
+type MockContactService_UpdateContact_Call struct {
+	*mock.Call
 }
 
-type MockInfoRequestService_Expecter struct {
-	mock *mock.Mock
+// UpdateContact is a helper method to define mock.On call
+//   - kind string
+//   - userId int32
+//   - contactId int32
+//   - params entities.UpdateContactParams
+func (_e *MockContactService_Expecter) UpdateContact(kind interface{}, userId interface{}, contactId interface{}, params interface{}) *MockContactService_UpdateContact_Call {
+	return &MockContactService_UpdateContact_Call{Call: _e.mock.On("UpdateContact", kind, userId, contactId, params)}
 }
 
-func (_m *MockInfoRequestService) EXPECT() *MockInfoRequestService_Expecter {
-	return &MockInfoRequestService_Expecter{mock: &_m.Mock}
+func (_c *MockContactService_UpdateContact_Call) Run(run func(kind string, userId int32, contactId int32, params entities.UpdateContactParams)) *MockContactService_UpdateContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		var arg2 int32
+		if args[2] != nil {
+			arg2 = args[2].(int32)
+		}
+		var arg3 entities.UpdateContactParams
+		if args[3] != nil {
+			arg3 = args[3].(entities.UpdateContactParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
 }
 
-// RequestAdditionalInfo provides a mock function for the type MockInfoRequestService
-func (_mock *MockInfoRequestService) RequestAdditionalInfo(name string) (int, string, string, error) {
-	ret := _mock.Called(name)
+func (_c *MockContactService_UpdateContact_Call) Return(contact entities.Contact, err error) *MockContactService_UpdateContact_Call {
+	_c.Call.Return(contact, err)
+	return _c
+}
+
+func (_c *MockContactService_UpdateContact_Call) RunAndReturn(run func(kind string, userId int32, contactId int32, params entities.UpdateContactParams) (entities.Contact, error)) *MockContactService_UpdateContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyContact provides a mock function for the type MockContactService
+func (_mock *MockContactService) VerifyContact(ctx context.Context, kind string, userId int32, contactId int32, code string) (entities.Contact, error) {
+	ret := _mock.Called(ctx, kind, userId, contactId, code)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RequestAdditionalInfo")
+		panic("no return value specified for VerifyContact")
 	}
 
-	var r0 int
-	var r1 string
-	var r2 string
-	var r3 error
-	if returnFunc, ok := ret.Get(0).(func(string) (int, string, string, error)); ok {
-		return returnFunc(name)
-	}
-	if returnFunc, ok := ret.Get(0).(func(string) int); ok {
-		r0 = returnFunc(name)
-	} else {
-		r0 = ret.Get(0).(int)
-	}
-	if returnFunc, ok := ret.Get(1).(func(string) string); ok {
-		r1 = returnFunc(name)
-	} else {
-		r1 = ret.Get(1).(string)
+	var r0 entities.Contact
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int32, int32, string) (entities.Contact, error)); ok {
+		return returnFunc(ctx, kind, userId, contactId, code)
 	}
-	if returnFunc, ok := ret.Get(2).(func(string) string); ok {
-		r2 = returnFunc(name)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int32, int32, string) entities.Contact); ok {
+		r0 = returnFunc(ctx, kind, userId, contactId, code)
 	} else {
-		r2 = ret.Get(2).(string)
+		r0 = ret.Get(0).(entities.Contact)
 	}
-	if returnFunc, ok := ret.Get(3).(func(string) error); ok {
-		r3 = returnFunc(name)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int32, int32, string) error); ok {
+		r1 = returnFunc(ctx, kind, userId, contactId, code)
 	} else {
-		r3 = ret.Error(3)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2, r3
+	return r0, r1
 }
 
-// MockInfoRequestService_RequestAdditionalInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestAdditionalInfo'
-type MockInfoRequestService_RequestAdditionalInfo_Call struct {
+// MockContactService_VerifyContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyContact'
+type MockContactService_VerifyContact_Call struct {
 	*mock.Call
 }
 
-// RequestAdditionalInfo is a helper method to define mock.On call
-//   - name string
-func (_e *MockInfoRequestService_Expecter) RequestAdditionalInfo(name interface{}) *MockInfoRequestService_RequestAdditionalInfo_Call {
-	return &MockInfoRequestService_RequestAdditionalInfo_Call{Call: _e.mock.On("RequestAdditionalInfo", name)}
+// VerifyContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - kind string
+//   - userId int32
+//   - contactId int32
+//   - code string
+func (_e *MockContactService_Expecter) VerifyContact(ctx interface{}, kind interface{}, userId interface{}, contactId interface{}, code interface{}) *MockContactService_VerifyContact_Call {
+	return &MockContactService_VerifyContact_Call{Call: _e.mock.On("VerifyContact", ctx, kind, userId, contactId, code)}
 }
 
-func (_c *MockInfoRequestService_RequestAdditionalInfo_Call) Run(run func(name string)) *MockInfoRequestService_RequestAdditionalInfo_Call {
+func (_c *MockContactService_VerifyContact_Call) Run(run func(ctx context.Context, kind string, userId int32, contactId int32, code string)) *MockContactService_VerifyContact_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int32
+		if args[2] != nil {
+			arg2 = args[2].(int32)
+		}
+		var arg3 int32
+		if args[3] != nil {
+			arg3 = args[3].(int32)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockInfoRequestService_RequestAdditionalInfo_Call) Return(age int, gender string, nationality string, err error) *MockInfoRequestService_RequestAdditionalInfo_Call {
-	_c.Call.Return(age, gender, nationality, err)
+func (_c *MockContactService_VerifyContact_Call) Return(contact entities.Contact, err error) *MockContactService_VerifyContact_Call {
+	_c.Call.Return(contact, err)
 	return _c
 }
 
-func (_c *MockInfoRequestService_RequestAdditionalInfo_Call) RunAndReturn(run func(name string) (int, string, string, error)) *MockInfoRequestService_RequestAdditionalInfo_Call {
+func (_c *MockContactService_VerifyContact_Call) RunAndReturn(run func(ctx context.Context, kind string, userId int32, contactId int32, code string) (entities.Contact, error)) *MockContactService_VerifyContact_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Util787/user-manager-api/internal/config"
+)
+
+// case rules of NamePolicy
+const (
+	// every part of a name starts with a capital letter and the rest are lowercase: "Anna-Maria", "O'Neil"
+	NameCaseTitle = "title"
+	// name starts with a capital letter, the rest can be in any case: "McDonald", "DeLuca". Default
+	NameCaseCapital = "capital"
+	// case is not checked
+	NameCaseAny = "any"
+)
+
+var ErrInvalidNamePolicy = errors.New("invalid name policy")
+
+// NamePolicy describes valid names, surnames and patronymics. It is the same for create, update, bulk update and import
+type NamePolicy struct {
+	// letters must belong to one of these unicode scripts, like Latin or Cyrillic
+	scripts []*unicode.RangeTable
+	// all letters of one name must be from the same script, so "Ivаn" with cyrillic "а" is rejected
+	singleScript bool
+	// characters allowed between letters, like hyphen, apostrophe and space
+	separators string
+	// length in characters, separators included
+	minLength, maxLength int
+	caseRule             string
+}
+
+// NewNamePolicy checks config, script names are the same as in unicode.Scripts
+func NewNamePolicy(cfg config.NamePolicyConfig) (NamePolicy, error) {
+	policy := NamePolicy{
+		singleScript: cfg.SingleScript,
+		separators:   cfg.Separators,
+		minLength:    cfg.MinLength,
+		maxLength:    cfg.MaxLength,
+		caseRule:     cfg.Case,
+	}
+
+	if len(cfg.Scripts) == 0 {
+		return NamePolicy{}, fmt.Errorf("%w: at least one script is required", ErrInvalidNamePolicy)
+	}
+	for _, name := range cfg.Scripts {
+		script, ok := unicode.Scripts[strings.TrimSpace(name)]
+		if !ok {
+			return NamePolicy{}, fmt.Errorf("%w: unknown script %q", ErrInvalidNamePolicy, name)
+		}
+		policy.scripts = append(policy.scripts, script)
+	}
+	for _, r := range cfg.Separators {
+		if unicode.IsLetter(r) || unicode.Is(unicode.Mn, r) {
+			return NamePolicy{}, fmt.Errorf("%w: separator %q is a letter", ErrInvalidNamePolicy, r)
+		}
+	}
+	if cfg.MinLength < 1 || cfg.MaxLength < cfg.MinLength {
+		return NamePolicy{}, fmt.Errorf("%w: length limits must be 1 <= min <= max", ErrInvalidNamePolicy)
+	}
+	if cfg.Case != NameCaseTitle && cfg.Case != NameCaseCapital && cfg.Case != NameCaseAny {
+		return NamePolicy{}, fmt.Errorf("%w: case must be title, capital or any", ErrInvalidNamePolicy)
+	}
+
+	return policy, nil
+}
+
+// check returns field error code and its args if name breaks the policy, code is empty for valid names.
+// Combining marks are allowed after letters, so decomposed diacritics are valid too
+func (p NamePolicy) check(name string) (string, []any) {
+	length := utf8.RuneCountInString(name)
+	if length < p.minLength {
+		return FieldErrNameTooShort, []any{p.minLength}
+	}
+	if length > p.maxLength {
+		return FieldErrNameTooLong, []any{p.maxLength}
+	}
+
+	var nameScript *unicode.RangeTable
+	// previous rune is a letter or a combining mark of a letter
+	afterLetter := false
+	// letter is the first one of the name or goes after a separator
+	firstInName, firstInPart := true, true
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r):
+			script := p.scriptOf(r)
+			if script == nil {
+				return FieldErrNameInvalidChars, nil
+			}
+			if nameScript == nil {
+				nameScript = script
+			} else if p.singleScript && script != nameScript {
+				return FieldErrNameMixedScripts, nil
+			}
+			if code := p.checkCase(r, firstInName, firstInPart); code != "" {
+				return code, nil
+			}
+			afterLetter, firstInName, firstInPart = true, false, false
+		case unicode.Is(unicode.Mn, r):
+			if !afterLetter {
+				return FieldErrNameInvalidChars, nil
+			}
+		case strings.ContainsRune(p.separators, r):
+			// separators only between letters and never in a row
+			if !afterLetter {
+				return FieldErrNameInvalidSeparators, nil
+			}
+			afterLetter, firstInPart = false, true
+		default:
+			return FieldErrNameInvalidChars, nil
+		}
+	}
+	if !afterLetter {
+		return FieldErrNameInvalidSeparators, nil
+	}
+	return "", nil
+}
+
+// checkCase returns field error code if letter breaks case rule.
+// Letters of scripts without case are neither upper nor lower, so they always pass
+func (p NamePolicy) checkCase(r rune, firstInName, firstInPart bool) string {
+	upper := unicode.IsUpper(r) || unicode.IsTitle(r)
+	switch p.caseRule {
+	case NameCaseTitle:
+		if firstInPart && unicode.IsLower(r) || !firstInPart && upper {
+			return FieldErrNameNotTitleCase
+		}
+	case NameCaseCapital:
+		if firstInName && unicode.IsLower(r) {
+			return FieldErrNameNotCapitalized
+		}
+	}
+	return ""
+}
+
+func (p NamePolicy) scriptOf(r rune) *unicode.RangeTable {
+	for _, script := range p.scripts {
+		if unicode.Is(script, r) {
+			return script
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Util787/user-manager-api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the same as env defaults of config.NamePolicyConfig
+var defaultNamePolicyConfig = config.NamePolicyConfig{
+	Scripts:      []string{"Latin", "Cyrillic"},
+	SingleScript: true,
+	Separators:   "-'’ ",
+	MinLength:    2,
+	MaxLength:    100,
+	Case:         NameCaseCapital,
+}
+
+func TestNamePolicy_check(t *testing.T) {
+	withConfig := func(change func(cfg *config.NamePolicyConfig)) config.NamePolicyConfig {
+		cfg := defaultNamePolicyConfig
+		change(&cfg)
+		return cfg
+	}
+
+	tests := []struct {
+		testname     string
+		cfg          config.NamePolicyConfig
+		name         string
+		expectedCode string
+		expectedArgs []any
+	}{
+		{testname: "Latin", cfg: defaultNamePolicyConfig, name: "Ivan"},
+		{testname: "Cyrillic with ё", cfg: defaultNamePolicyConfig, name: "Пётр"},
+		{testname: "Ukrainian letters", cfg: defaultNamePolicyConfig, name: "Ївга Їжакевич-Єрмоленко"},
+		{testname: "Ukrainian і and ґ", cfg: defaultNamePolicyConfig, name: "Ґалина Іваненко-Біла"},
+		{testname: "Belarusian ў", cfg: defaultNamePolicyConfig, name: "Ўладзімір"},
+		{testname: "Hyphenated surname", cfg: defaultNamePolicyConfig, name: "Римский-Корсаков"},
+		{testname: "Apostrophe", cfg: defaultNamePolicyConfig, name: "O'Neil"},
+		{testname: "Typographic apostrophe", cfg: defaultNamePolicyConfig, name: "D’Angelo"},
+		{testname: "Precomposed diacritics", cfg: defaultNamePolicyConfig, name: "Zoë Ångström"},
+		{testname: "Decomposed diacritics", cfg: defaultNamePolicyConfig, name: "Zoe\u0308"},
+		{testname: "Multi-part name", cfg: defaultNamePolicyConfig, name: "Anna Maria"},
+		{testname: "Inner capitals with capital case", cfg: defaultNamePolicyConfig, name: "McDonald"},
+		{testname: "Minimum length", cfg: defaultNamePolicyConfig, name: "Li"},
+		{
+			testname:     "Too short",
+			cfg:          defaultNamePolicyConfig,
+			name:         "A",
+			expectedCode: FieldErrNameTooShort,
+			expectedArgs: []any{2},
+		},
+		{
+			testname:     "Too long",
+			cfg:          withConfig(func(cfg *config.NamePolicyConfig) { cfg.MaxLength = 5 }),
+			name:         "Ivanov",
+			expectedCode: FieldErrNameTooLong,
+			expectedArgs: []any{5},
+		},
+		{
+			testname: "Length is counted in characters, not bytes",
+			cfg:      withConfig(func(cfg *config.NamePolicyConfig) { cfg.MaxLength = 4 }),
+			name:     "Иван",
+		},
+		{testname: "Digits", cfg: defaultNamePolicyConfig, name: "Ivan3", expectedCode: FieldErrNameInvalidChars},
+		{testname: "Punctuation", cfg: defaultNamePolicyConfig, name: "Ivan!", expectedCode: FieldErrNameInvalidChars},
+		{testname: "Not allowed script", cfg: defaultNamePolicyConfig, name: "Γιάννης", expectedCode: FieldErrNameInvalidChars},
+		{testname: "Combining mark without letter", cfg: defaultNamePolicyConfig, name: "\u0308Ivan", expectedCode: FieldErrNameInvalidChars},
+		{
+			testname:     "Separator that is not allowed",
+			cfg:          withConfig(func(cfg *config.NamePolicyConfig) { cfg.Separators = "-" }),
+			name:         "Anna Maria",
+			expectedCode: FieldErrNameInvalidChars,
+		},
+		{
+			testname: "Configured script",
+			cfg:      withConfig(func(cfg *config.NamePolicyConfig) { cfg.Scripts = []string{"Greek"} }),
+			name:     "Γιάννης",
+		},
+		{testname: "Mixed scripts", cfg: defaultNamePolicyConfig, name: "Iv\u0430n", expectedCode: FieldErrNameMixedScripts},
+		{
+			testname: "Mixed scripts are allowed",
+			cfg:      withConfig(func(cfg *config.NamePolicyConfig) { cfg.SingleScript = false }),
+			name:     "Iv\u0430n",
+		},
+		{testname: "Leading separator", cfg: defaultNamePolicyConfig, name: "-Ivan", expectedCode: FieldErrNameInvalidSeparators},
+		{testname: "Trailing separator", cfg: defaultNamePolicyConfig, name: "Ivan'", expectedCode: FieldErrNameInvalidSeparators},
+		{testname: "Separators in a row", cfg: defaultNamePolicyConfig, name: "Anna--Maria", expectedCode: FieldErrNameInvalidSeparators},
+		{testname: "Double space", cfg: defaultNamePolicyConfig, name: "Anna  Maria", expectedCode: FieldErrNameInvalidSeparators},
+		{testname: "Lowercase first letter", cfg: defaultNamePolicyConfig, name: "ivan", expectedCode: FieldErrNameNotCapitalized},
+		{testname: "Lowercase second part with capital case", cfg: defaultNamePolicyConfig, name: "Van der Berg"},
+		{
+			testname: "Title case",
+			cfg:      withConfig(func(cfg *config.NamePolicyConfig) { cfg.Case = NameCaseTitle }),
+			name:     "Anna-Maria O'Neil",
+		},
+		{
+			testname:     "Inner capitals with title case",
+			cfg:          withConfig(func(cfg *config.NamePolicyConfig) { cfg.Case = NameCaseTitle }),
+			name:         "McDonald",
+			expectedCode: FieldErrNameNotTitleCase,
+		},
+		{
+			testname:     "Lowercase part with title case",
+			cfg:          withConfig(func(cfg *config.NamePolicyConfig) { cfg.Case = NameCaseTitle }),
+			name:         "Anna-maria",
+			expectedCode: FieldErrNameNotTitleCase,
+		},
+		{
+			testname: "Any case",
+			cfg:      withConfig(func(cfg *config.NamePolicyConfig) { cfg.Case = NameCaseAny }),
+			name:     "van der Berg",
+		},
+		{
+			testname: "Script without case",
+			cfg: withConfig(func(cfg *config.NamePolicyConfig) {
+				cfg.Scripts = []string{"Han"}
+				cfg.Case = NameCaseTitle
+			}),
+			name: "王小明",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			policy, err := NewNamePolicy(test.cfg)
+			require.NoError(t, err)
+
+			code, args := policy.check(test.name)
+
+			assert.Equal(t, test.expectedCode, code)
+			assert.Equal(t, test.expectedArgs, args)
+		})
+	}
+}
+
+func TestNewNamePolicy(t *testing.T) {
+	tests := []struct {
+		testname    string
+		change      func(cfg *config.NamePolicyConfig)
+		expectedErr bool
+	}{
+		{testname: "Defaults", change: func(cfg *config.NamePolicyConfig) {}},
+		{testname: "Unknown script", change: func(cfg *config.NamePolicyConfig) { cfg.Scripts = []string{"Latin", "Klingon"} }, expectedErr: true},
+		{testname: "No scripts", change: func(cfg *config.NamePolicyConfig) { cfg.Scripts = nil }, expectedErr: true},
+		{testname: "Letter as separator", change: func(cfg *config.NamePolicyConfig) { cfg.Separators = "-x" }, expectedErr: true},
+		{testname: "Zero min length", change: func(cfg *config.NamePolicyConfig) { cfg.MinLength = 0 }, expectedErr: true},
+		{testname: "Max length less than min", change: func(cfg *config.NamePolicyConfig) { cfg.MaxLength = 1 }, expectedErr: true},
+		{testname: "Unknown case", change: func(cfg *config.NamePolicyConfig) { cfg.Case = "upper" }, expectedErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			cfg := defaultNamePolicyConfig
+			test.change(&cfg)
+
+			_, err := NewNamePolicy(cfg)
+
+			if test.expectedErr {
+				assert.ErrorIs(t, err, ErrInvalidNamePolicy)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,420 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Util787/user-manager-api/entities"
+)
+
+// formats of PatchUser
+const (
+	// RFC 7386, application/merge-patch+json. null removes a field
+	PatchFormatMerge = "merge-patch"
+	// RFC 6902, application/json-patch+json. Operations are applied in order, all or nothing
+	PatchFormatJSON = "json-patch"
+)
+
+var (
+	ErrUnsupportedPatchFormat = errors.New("unsupported patch format, must be merge-patch or json-patch")
+	// ErrInvalidPatch is wrapped by errors about malformed patch documents
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrPatchFailed is wrapped by errors of well formed patches that cant be applied to the user, like failed test operations
+	ErrPatchFailed = errors.New("patch failed")
+)
+
+// fields of patched user document that cant be changed, they can be used in test operations
+var readOnlyUserFields = []string{"id", "created_at", "updated_at", "deleted_at"}
+
+var editableUserFields = []string{"name", "surname", "patronymic", "age", "gender", "nationality", "attributes"}
+
+// patchFunc applies parsed patch to json document decoded to any
+type patchFunc func(doc any) (any, error)
+
+// parsePatch checks syntax of patch before it is applied, so malformed patches dont lock the user
+func parsePatch(format string, data []byte) (patchFunc, error) {
+	switch format {
+	case PatchFormatMerge:
+		var patch any
+		if err := json.Unmarshal(data, &patch); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+		}
+		return func(doc any) (any, error) { return mergePatch(doc, patch), nil }, nil
+	case PatchFormatJSON:
+		ops, err := parseJSONPatch(data)
+		if err != nil {
+			return nil, err
+		}
+		return func(doc any) (any, error) { return applyJSONPatch(doc, ops) }, nil
+	default:
+		return nil, ErrUnsupportedPatchFormat
+	}
+}
+
+// mergePatch is MergePatch function of RFC 7386
+func mergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = map[string]any{}
+	}
+	for name, value := range patchObj {
+		if value == nil {
+			delete(targetObj, name)
+			continue
+		}
+		targetObj[name] = mergePatch(targetObj[name], value)
+	}
+	return targetObj
+}
+
+type patchOperation struct {
+	op    string
+	path  []string
+	from  []string
+	value any
+}
+
+func parseJSONPatch(data []byte) ([]patchOperation, error) {
+	var raw []struct {
+		Op    string          `json:"op"`
+		Path  *string         `json:"path"`
+		From  *string         `json:"from"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+
+	ops := make([]patchOperation, len(raw))
+	for i, r := range raw {
+		if r.Path == nil {
+			return nil, fmt.Errorf("%w: operation %d has no path", ErrInvalidPatch, i)
+		}
+		path, err := parsePointer(*r.Path)
+		if err != nil {
+			return nil, err
+		}
+		op := patchOperation{op: r.Op, path: path}
+
+		switch r.Op {
+		case "add", "replace", "test":
+			// missing value is not the same as null
+			if r.Value == nil {
+				return nil, fmt.Errorf("%w: %s operation %d has no value", ErrInvalidPatch, r.Op, i)
+			}
+			if err := json.Unmarshal(r.Value, &op.value); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+			}
+		case "move", "copy":
+			if r.From == nil {
+				return nil, fmt.Errorf("%w: %s operation %d has no from", ErrInvalidPatch, r.Op, i)
+			}
+			op.from, err = parsePointer(*r.From)
+			if err != nil {
+				return nil, err
+			}
+			if r.Op == "move" && len(op.from) < len(op.path) && slices.Equal(op.from, op.path[:len(op.from)]) {
+				return nil, fmt.Errorf("%w: operation %d moves a value into itself", ErrInvalidPatch, i)
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, r.Op)
+		}
+		ops[i] = op
+	}
+	return ops, nil
+}
+
+// parsePointer splits RFC 6901 json pointer to unescaped reference tokens, empty pointer is the whole document
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: pointer %q must start with /", ErrInvalidPatch, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func formatPointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// applyJSONPatch applies operations in order and stops at the first failed one
+func applyJSONPatch(doc any, ops []patchOperation) (any, error) {
+	var err error
+	for _, op := range ops {
+		switch op.op {
+		case "add":
+			doc, err = addValue(doc, op.path, op.value)
+		case "remove":
+			doc, _, err = removeValue(doc, op.path)
+		case "replace":
+			if doc, _, err = removeValue(doc, op.path); err == nil {
+				doc, err = addValue(doc, op.path, op.value)
+			}
+		case "move":
+			var value any
+			if doc, value, err = removeValue(doc, op.from); err == nil {
+				doc, err = addValue(doc, op.path, value)
+			}
+		case "copy":
+			var value any
+			if value, err = getValue(doc, op.from); err == nil {
+				doc, err = addValue(doc, op.path, copyValue(value))
+			}
+		case "test":
+			var value any
+			if value, err = getValue(doc, op.path); err == nil && !reflect.DeepEqual(value, op.value) {
+				err = fmt.Errorf("%w: test of %s failed", ErrPatchFailed, formatPointer(op.path))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+func getValue(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, missingPathError(token)
+			}
+			doc = value
+		case []any:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[index]
+		default:
+			return nil, missingPathError(token)
+		}
+	}
+	return doc, nil
+}
+
+// addValue returns changed doc, arrays are copied when they grow
+func addValue(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	token, last := path[0], len(path) == 1
+
+	switch node := doc.(type) {
+	case map[string]any:
+		if last {
+			node[token] = value
+			return node, nil
+		}
+		child, ok := node[token]
+		if !ok {
+			return nil, missingPathError(token)
+		}
+		child, err := addValue(child, path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		node[token] = child
+		return node, nil
+	case []any:
+		if last {
+			index := len(node)
+			if token != "-" {
+				var err error
+				if index, err = arrayIndex(token, len(node)); err != nil {
+					return nil, err
+				}
+			}
+			return slices.Insert(node, index, value), nil
+		}
+		index, err := arrayIndex(token, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		node[index], err = addValue(node[index], path[1:], value)
+		return node, err
+	default:
+		return nil, missingPathError(token)
+	}
+}
+
+// removeValue returns changed doc and removed value, removed value must exist
+func removeValue(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	token, last := path[0], len(path) == 1
+
+	switch node := doc.(type) {
+	case map[string]any:
+		child, ok := node[token]
+		if !ok {
+			return nil, nil, missingPathError(token)
+		}
+		if last {
+			delete(node, token)
+			return node, child, nil
+		}
+		child, removed, err := removeValue(child, path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		node[token] = child
+		return node, removed, nil
+	case []any:
+		index, err := arrayIndex(token, len(node)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		if last {
+			removed := node[index]
+			return slices.Delete(node, index, index+1), removed, nil
+		}
+		child, removed, err := removeValue(node[index], path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		node[index] = child
+		return node, removed, nil
+	default:
+		return nil, nil, missingPathError(token)
+	}
+}
+
+// arrayIndex parses array index token without leading zeros, maxIndex is the greatest allowed index
+func arrayIndex(token string, maxIndex int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (len(token) > 1 && token[0] == '0') || strings.HasPrefix(token, "+") {
+		return 0, fmt.Errorf("%w: %q is not an array index", ErrPatchFailed, token)
+	}
+	if index > maxIndex {
+		return 0, missingPathError(token)
+	}
+	return index, nil
+}
+
+func missingPathError(token string) error {
+	return fmt.Errorf("%w: %q doesnt exist", ErrPatchFailed, token)
+}
+
+func copyValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(v))
+		for key, item := range v {
+			copied[key] = copyValue(item)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(v))
+		for i, item := range v {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+// userDocument is user as it is returned by api, patches are applied to it
+func userDocument(user entities.User) (map[string]any, error) {
+	data, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	err = json.Unmarshal(data, &doc)
+	return doc, err
+}
+
+// replaceParamsFromDocument reads editable fields of patched document, read only fields must be the same as in original
+func replaceParamsFromDocument(original map[string]any, patched any) (entities.ReplaceUserParams, error) {
+	doc, ok := patched.(map[string]any)
+	if !ok {
+		return entities.ReplaceUserParams{}, fmt.Errorf("%w: patched user must be an object", ErrPatchFailed)
+	}
+
+	var v validator
+	for _, field := range readOnlyUserFields {
+		before, hadBefore := original[field]
+		after, hasAfter := doc[field]
+		v.check(hadBefore == hasAfter && reflect.DeepEqual(before, after), field, FieldErrReadOnly)
+	}
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		if !slices.Contains(readOnlyUserFields, key) && !slices.Contains(editableUserFields, key) {
+			keys = append(keys, key)
+		}
+	}
+	// the same order of errors for the same patch
+	slices.Sort(keys)
+	for _, key := range keys {
+		v.check(false, key, FieldErrUnknownField)
+	}
+
+	params := entities.ReplaceUserParams{
+		Name:        documentString(&v, doc, "name"),
+		Surname:     documentString(&v, doc, "surname"),
+		Patronymic:  documentString(&v, doc, "patronymic"),
+		Gender:      documentString(&v, doc, "gender"),
+		Nationality: documentString(&v, doc, "nationality"),
+	}
+	switch age := doc["age"].(type) {
+	case nil:
+	case float64:
+		if age == math.Trunc(age) && math.Abs(age) <= math.MaxInt32 {
+			ageInt := int(age)
+			params.Age = &ageInt
+		} else {
+			v.check(false, "age", FieldErrInvalidType)
+		}
+	default:
+		v.check(false, "age", FieldErrInvalidType)
+	}
+	switch attrs := doc["attributes"].(type) {
+	case nil:
+	case map[string]any:
+		params.Attributes = attrs
+	default:
+		v.check(false, "attributes", FieldErrInvalidType)
+	}
+
+	return params, v.err()
+}
+
+// documentString returns string field of document, empty if it is missing or null
+func documentString(v *validator, doc map[string]any, field string) string {
+	switch value := doc[field].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		v.check(false, field, FieldErrInvalidType)
+		return ""
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePatch(t *testing.T) {
+	const doc = `{"name":"Ivan","age":30,"tags":["a","b"],"address":{"city":"Minsk"}}`
+
+	tests := []struct {
+		testname    string
+		format      string
+		patch       string
+		expected    string
+		expectedErr error
+	}{
+		{testname: "Merge patch sets and removes fields", format: PatchFormatMerge, patch: `{"age":31,"address":{"city":null,"zip":"220000"}}`,
+			expected: `{"name":"Ivan","age":31,"tags":["a","b"],"address":{"zip":"220000"}}`},
+		{testname: "Merge patch replaces arrays", format: PatchFormatMerge, patch: `{"tags":["c"]}`,
+			expected: `{"name":"Ivan","age":30,"tags":["c"],"address":{"city":"Minsk"}}`},
+		{testname: "Malformed merge patch", format: PatchFormatMerge, patch: `{"age":`, expectedErr: ErrInvalidPatch},
+		{testname: "Add and replace", format: PatchFormatJSON, patch: `[{"op":"add","path":"/surname","value":"Ivanov"},{"op":"replace","path":"/age","value":31}]`,
+			expected: `{"name":"Ivan","surname":"Ivanov","age":31,"tags":["a","b"],"address":{"city":"Minsk"}}`},
+		{testname: "Add to array", format: PatchFormatJSON, patch: `[{"op":"add","path":"/tags/1","value":"x"},{"op":"add","path":"/tags/-","value":"y"}]`,
+			expected: `{"name":"Ivan","age":30,"tags":["a","x","b","y"],"address":{"city":"Minsk"}}`},
+		{testname: "Remove", format: PatchFormatJSON, patch: `[{"op":"remove","path":"/tags/0"},{"op":"remove","path":"/address/city"}]`,
+			expected: `{"name":"Ivan","age":30,"tags":["b"],"address":{}}`},
+		{testname: "Move and copy", format: PatchFormatJSON, patch: `[{"op":"move","from":"/address/city","path":"/city"},{"op":"copy","from":"/tags","path":"/labels"}]`,
+			expected: `{"name":"Ivan","age":30,"tags":["a","b"],"labels":["a","b"],"address":{},"city":"Minsk"}`},
+		{testname: "Passed test", format: PatchFormatJSON, patch: `[{"op":"test","path":"/address","value":{"city":"Minsk"}},{"op":"replace","path":"/age","value":31}]`,
+			expected: `{"name":"Ivan","age":31,"tags":["a","b"],"address":{"city":"Minsk"}}`},
+		{testname: "Escaped pointer", format: PatchFormatJSON, patch: `[{"op":"add","path":"/a~1b~0c","value":1}]`,
+			expected: `{"name":"Ivan","age":30,"tags":["a","b"],"address":{"city":"Minsk"},"a/b~c":1}`},
+		{testname: "Failed test", format: PatchFormatJSON, patch: `[{"op":"replace","path":"/age","value":31},{"op":"test","path":"/name","value":"Petr"}]`,
+			expectedErr: ErrPatchFailed},
+		{testname: "Replace of missing field", format: PatchFormatJSON, patch: `[{"op":"replace","path":"/surname","value":"Ivanov"}]`, expectedErr: ErrPatchFailed},
+		{testname: "Array index out of range", format: PatchFormatJSON, patch: `[{"op":"add","path":"/tags/3","value":"x"}]`, expectedErr: ErrPatchFailed},
+		{testname: "Leading zero index", format: PatchFormatJSON, patch: `[{"op":"remove","path":"/tags/01"}]`, expectedErr: ErrPatchFailed},
+		{testname: "Unknown operation", format: PatchFormatJSON, patch: `[{"op":"increment","path":"/age"}]`, expectedErr: ErrInvalidPatch},
+		{testname: "Missing value", format: PatchFormatJSON, patch: `[{"op":"add","path":"/age"}]`, expectedErr: ErrInvalidPatch},
+		{testname: "Pointer without slash", format: PatchFormatJSON, patch: `[{"op":"remove","path":"age"}]`, expectedErr: ErrInvalidPatch},
+		{testname: "Move into itself", format: PatchFormatJSON, patch: `[{"op":"move","from":"/address","path":"/address/old"}]`, expectedErr: ErrInvalidPatch},
+		{testname: "Not an array", format: PatchFormatJSON, patch: `{"op":"remove","path":"/age"}`, expectedErr: ErrInvalidPatch},
+		{testname: "Unknown format", format: "xml-patch", patch: `{}`, expectedErr: ErrUnsupportedPatchFormat},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			var target any
+			require.NoError(t, json.Unmarshal([]byte(doc), &target))
+
+			apply, err := parsePatch(test.format, []byte(test.patch))
+			if err == nil {
+				target, err = apply(target)
+			}
+
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			patched, err := json.Marshal(target)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(patched))
+		})
+	}
+}
+
+func TestReplaceParamsFromDocument(t *testing.T) {
+	age := 30
+	original := map[string]any{
+		"id": float64(1), "created_at": "2025-01-01T00:00:00Z", "updated_at": "2025-01-02T00:00:00Z",
+		"name": "Ivan", "surname": "Ivanov", "patronymic": "Ivanovich", "age": float64(30), "gender": "male", "nationality": "BY",
+	}
+	withChanges := func(changes map[string]any) map[string]any {
+		doc := copyValue(original).(map[string]any)
+		for key, value := range changes {
+			if value == nil {
+				delete(doc, key)
+				continue
+			}
+			doc[key] = value
+		}
+		return doc
+	}
+
+	tests := []struct {
+		testname       string
+		patched        any
+		expected       entities.ReplaceUserParams
+		expectedFields []string
+	}{
+		{
+			testname: "Removed patronymic",
+			patched:  withChanges(map[string]any{"patronymic": nil}),
+			expected: entities.ReplaceUserParams{Name: "Ivan", Surname: "Ivanov", Age: &age, Gender: "male", Nationality: "BY"},
+		},
+		{
+			testname: "Added attributes",
+			patched:  withChanges(map[string]any{"attributes": map[string]any{"remote": true}}),
+			expected: entities.ReplaceUserParams{Name: "Ivan", Surname: "Ivanov", Patronymic: "Ivanovich", Age: &age, Gender: "male", Nationality: "BY",
+				Attributes: entities.Attributes{"remote": true}},
+		},
+		{testname: "Attributes not an object", patched: withChanges(map[string]any{"attributes": "remote"}), expectedFields: []string{"attributes"}},
+		{testname: "Changed id", patched: withChanges(map[string]any{"id": float64(2)}), expectedFields: []string{"id"}},
+		{testname: "Removed updated_at", patched: withChanges(map[string]any{"updated_at": nil}), expectedFields: []string{"updated_at"}},
+		{testname: "Added deleted_at", patched: withChanges(map[string]any{"deleted_at": "2025-01-03T00:00:00Z"}), expectedFields: []string{"deleted_at"}},
+		{testname: "Unknown fields", patched: withChanges(map[string]any{"salary": float64(1), "email": "a@b.c"}), expectedFields: []string{"email", "salary"}},
+		{testname: "Invalid types", patched: withChanges(map[string]any{"name": float64(1), "age": 30.5}), expectedFields: []string{"name", "age"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			params, err := replaceParamsFromDocument(original, test.patched)
+
+			if len(test.expectedFields) == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, params)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			fields := make([]string, len(validationErr.Fields))
+			for i, f := range validationErr.Fields {
+				fields[i] = f.Field
+			}
+			assert.Equal(t, test.expectedFields, fields)
+		})
+	}
+
+	_, err := replaceParamsFromDocument(original, []any{})
+	assert.ErrorIs(t, err, ErrPatchFailed)
+}
@@ -26,3 +26,11 @@ func (r *redisService) Get(ctx context.Context, key string, dest any) error {
 func (r *redisService) Delete(ctx context.Context, key string) error {
 	return r.redisRepo.Delete(ctx, key)
 }
+
+func (r *redisService) MGet(ctx context.Context, keys []string) ([][]byte, error) {
+	return r.redisRepo.MGet(ctx, keys)
+}
+
+func (r *redisService) SetMany(ctx context.Context, values map[string]any) error {
+	return r.redisRepo.SetMany(ctx, values)
+}
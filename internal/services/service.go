@@ -2,19 +2,47 @@ package service
 
 import (
 	"context"
+	"io"
+	"log/slog"
 
 	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/notifier"
 	"github.com/Util787/user-manager-api/internal/repository"
 )
 
 type UserService interface {
-	GetAllUsers(pageSize, page int, name, surname, patronymic, gender string) (users []entities.User, totalCount int,err error)
-	CreateUser(params entities.User) (entities.User, error)
-	ExistByFullName(params entities.FullName) (bool, error)
-	ExistById(id int32) (bool, error)
-	GetUserById(id int32) (entities.User, error)
+	// fields are json names of user fields to select, all fields if empty
+	GetAllUsers(pageSize, page int, filter entities.UserFilter, sort []entities.SortField, fields []string) (users []entities.User, totalCount int,err error)
+	// cursor is empty for the first page, total count is calculated only if withTotal is true
+	GetUsersByCursor(filter entities.UserFilter, sort []entities.SortField, cursor string, limit int, withTotal bool, fields []string) (entities.UsersCursorPage, error)
+	// age, gender and nationality are requested from external apis.
+	// ErrConflict is returned if live user with the same full name exists, see repository.SetFullNameUniqueness
+	CreateUser(fullName entities.FullName) (entities.User, error)
+	GetUserById(id int32, fields []string) (entities.User, error)
+	// users are returned in any order, missing and deleted ids are skipped
+	GetUsersByIds(ids []int32) ([]entities.User, error)
+	// ErrNotFound is returned if user doesnt exist or is deleted
 	UpdateUser(id int32, params entities.UpdateUserParams) error
+	// all editable fields are replaced, missing patronymic and nationality are cleared
+	ReplaceUser(id int32, params entities.ReplaceUserParams) error
+	// format is PatchFormatMerge or PatchFormatJSON, patch is applied to user as it is returned by api and saved atomically.
+	// ErrInvalidPatch is returned for malformed patches, ErrPatchFailed for failed tests and missing paths
+	PatchUser(id int32, format string, patch []byte) (entities.User, error)
 	DeleteUser(id int32) error
+	BulkUpdateUsers(filter entities.BulkFilter, params entities.UpdateUserParams, opts entities.BulkOptions) (entities.BulkResult, error)
+	BulkDeleteUsers(filter entities.BulkFilter, hard bool, opts entities.BulkOptions) (entities.BulkResult, error)
+	// threshold is min similarity from 0 to 1, results are ordered by similarity
+	SearchUsers(query string, threshold float64, limit int) ([]entities.UserSearchResult, error)
+	// userId is 0 to find all duplicates, otherwise only duplicates of this user
+	FindDuplicates(threshold float64, limit int, userId int32) ([]entities.DuplicateCandidate, error)
+	// source user is soft deleted, returns target user after merge. Tags, group memberships and contacts of source are moved to target
+	MergeUsers(params entities.MergeUsersParams) (entities.User, error)
+	// tags are lowercased, missing tags are created. Result has all tags of the user after the change
+	GetUserTags(id int32) (entities.UserTags, error)
+	AddUserTags(id int32, tags []string) (entities.UserTags, error)
+	RemoveUserTags(id int32, tags []string) (entities.UserTags, error)
+	// adds and removes tags of users matched by filter, affected is the number of matched users
+	BulkTagUsers(filter entities.BulkFilter, params entities.BulkTagsParams, opts entities.BulkOptions) (entities.BulkResult, error)
 }
 
 type RedisService interface {
@@ -25,22 +53,105 @@ type RedisService interface {
 	// Example: Get(context.Background(),"key",&user)
 	Get(ctx context.Context, key string, dest any) error
 	Delete(ctx context.Context, key string) error
+	// json values in the same order as keys, nil for missing keys
+	MGet(ctx context.Context, keys []string) ([][]byte, error)
+	SetMany(ctx context.Context, values map[string]any) error
 }
 
 type InfoRequestService interface {
 	RequestAdditionalInfo(name string) (age int, gender string, nationality string, err error)
 }
 
+type ImportService interface {
+	// format is csv or ndjson, enrich fills missing age, gender and nationality from external apis
+	ImportUsers(r io.Reader, format string, enrich bool) (entities.ImportReport, error)
+}
+
+type ExportService interface {
+	// format is csv, ndjson or json
+	ExportUsers(ctx context.Context, w io.Writer, format string, filter entities.UserFilter) error
+}
+
+type StatsService interface {
+	// filter is the same as for users listing, results are cached until the next mutation of users
+	GetUserStats(ctx context.Context, filter entities.UserFilter, opts entities.StatsOptions) (entities.UserStats, error)
+	// InvalidateStats makes all cached stats outdated
+	InvalidateStats(ctx context.Context) error
+}
+
+// AttributeService manages definitions of custom user attributes, ErrNotFound and ErrConflict are returned like for users
+type AttributeService interface {
+	CreateDefinition(def entities.AttributeDefinition) (entities.AttributeDefinition, error)
+	// definitions are ordered by name
+	GetDefinitions() ([]entities.AttributeDefinition, error)
+	GetDefinition(name string) (entities.AttributeDefinition, error)
+	// existing values of users are not checked against changed definition
+	UpdateDefinition(def entities.AttributeDefinition) (entities.AttributeDefinition, error)
+	// the attribute is removed from all users
+	DeleteDefinition(name string) error
+}
+
+// GroupService manages the tree of groups and memberships of users, ErrNotFound and ErrConflict are returned like for users.
+// Invalid parent of a group is a validation error of parent_id
+type GroupService interface {
+	CreateGroup(params entities.CreateGroupParams) (entities.Group, error)
+	// groups are ordered by id
+	GetGroups(pageSize, page int, filter entities.GroupFilter) (groups []entities.Group, totalCount int, err error)
+	GetGroupById(id int32) (entities.Group, error)
+	// only provided fields are changed, parent_id 0 moves the group to the top level
+	UpdateGroup(id int32, params entities.UpdateGroupParams) (entities.Group, error)
+	// ErrConflict is returned if the group has subgroups
+	DeleteGroup(id int32) error
+	// members of live users with user data, recursive includes members of all subgroups
+	GetGroupMembers(groupId int32, recursive bool) ([]entities.GroupMember, error)
+	// adds user to the group or changes the role, role is member if empty
+	SetGroupMember(groupId, userId int32, params entities.GroupMemberParams) (entities.GroupMember, error)
+	RemoveGroupMember(groupId, userId int32) error
+	// recursive includes ancestors of groups the user is a direct member of
+	GetUserGroups(userId int32, recursive bool) ([]entities.UserGroup, error)
+}
+
+// ContactService manages emails and phones of users, kind is entities.ContactKindEmail or entities.ContactKindPhone.
+// ErrConflict is returned if another user has the same email or phone
+type ContactService interface {
+	// primary first, ErrNotFound is returned for missing and deleted users
+	GetContacts(kind string, userId int32) ([]entities.Contact, error)
+	// emails are checked by RFC 5322, phones are saved in E.164. The first contact of the kind is primary
+	CreateContact(kind string, userId int32, params entities.CreateContactParams) (entities.Contact, error)
+	UpdateContact(kind string, userId, contactId int32, params entities.UpdateContactParams) (entities.Contact, error)
+	// the oldest remaining contact becomes primary if the primary one is deleted
+	DeleteContact(kind string, userId, contactId int32) error
+	// sends one-time code through notifier, ErrVerificationCooldown is returned if code was sent recently
+	SendVerificationCode(ctx context.Context, kind string, userId, contactId int32) (entities.VerificationSent, error)
+	// wrong and expired codes are validation errors of code
+	VerifyContact(ctx context.Context, kind string, userId, contactId int32, code string) (entities.Contact, error)
+}
+
 type Service struct {
 	UserService        UserService
 	RedisService       RedisService
 	InfoRequestService InfoRequestService
+	ImportService      ImportService
+	ExportService      ExportService
+	StatsService       StatsService
+	AttributeService   AttributeService
+	GroupService       GroupService
+	ContactService     ContactService
 }
 
-func NewService(repos *repository.Repository) *Service {
+// verificationSecret is the key of verification code hashes, see contactService.hashVerificationCode
+func NewService(repos *repository.Repository, names NamePolicy, codeNotifier notifier.Notifier, verificationSecret string, log *slog.Logger) *Service {
+	infoRequestService := NewInfoRequestService()
+	statsService := NewStatsService(repos.UserRepository, repos.RedisRepository, repos.AttributeRepository, log)
 	return &Service{
-		UserService:        NewUserService(repos.UserRepository),
+		UserService:        NewUserService(repos.UserRepository, infoRequestService, statsService, names, repos.AttributeRepository),
 		RedisService:       NewRedisService(repos.RedisRepository),
-		InfoRequestService: NewInfoRequestService(),
+		InfoRequestService: infoRequestService,
+		ImportService:      NewImportService(repos.UserRepository, infoRequestService, statsService, names, repos.AttributeRepository),
+		ExportService:      NewExportService(repos.UserRepository, repos.AttributeRepository),
+		StatsService:       statsService,
+		AttributeService:   NewAttributeService(repos.AttributeRepository),
+		GroupService:       NewGroupService(repos.GroupRepository, repos.UserRepository),
+		ContactService:     NewContactService(repos.ContactRepository, repos.RedisRepository, codeNotifier, verificationSecret),
 	}
 }
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/repository"
+)
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+// ParseSort parses comma separated fields, "-" prefix means descending order
+//
+// Example: "surname,-created_at"
+func ParseSort(sort string) ([]entities.SortField, error) {
+	if strings.TrimSpace(sort) == "" {
+		return nil, nil
+	}
+
+	var fields []entities.SortField
+	seen := map[string]bool{}
+	for _, part := range strings.Split(sort, ",") {
+		part = strings.TrimSpace(part)
+		field := entities.SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+
+		if !repository.IsSortableUserColumn(field.Field) {
+			return nil, fmt.Errorf("%w: %q is not sortable", ErrInvalidSort, field.Field)
+		}
+		if seen[field.Field] {
+			return nil, fmt.Errorf("%w: %q is used twice", ErrInvalidSort, field.Field)
+		}
+		seen[field.Field] = true
+
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// FormatSort is the reverse of ParseSort
+func FormatSort(sort []entities.SortField) string {
+	parts := make([]string, 0, len(sort))
+	for _, field := range sort {
+		if field.Desc {
+			parts = append(parts, "-"+field.Field)
+		} else {
+			parts = append(parts, field.Field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/logger/sl"
+	"github.com/Util787/user-manager-api/internal/repository"
+)
+
+// ErrInvalidStatsOptions is returned by GetUserStats for bad age buckets or interval
+var ErrInvalidStatsOptions = repository.ErrInvalidStatsOptions
+
+// DefaultAgeBuckets are lower bounds of age buckets used when none are requested
+var DefaultAgeBuckets = []int{18, 25, 35, 45, 55, 65}
+
+// every mutation increments generation, so cached stats of older generations are never read again and expire by ttl
+const statsGenerationKey = "stats:generation"
+
+type statsService struct {
+	userRepo  repository.UserRepository
+	redisRepo repository.RedisRepository
+	// definitions of custom attributes for attr filters
+	attrRepo repository.AttributeRepository
+	log      *slog.Logger
+}
+
+func NewStatsService(userRepo repository.UserRepository, redisRepo repository.RedisRepository, attrRepo repository.AttributeRepository, log *slog.Logger) StatsService {
+	return &statsService{userRepo: userRepo, redisRepo: redisRepo, attrRepo: attrRepo, log: log}
+}
+
+// GetUserStats returns cached stats if there are any for the current generation, redis errors only disable the cache
+func (s *statsService) GetUserStats(ctx context.Context, filter entities.UserFilter, opts entities.StatsOptions) (entities.UserStats, error) {
+	filter, err := typeFilter(s.attrRepo, filter)
+	if err != nil {
+		return entities.UserStats{}, err
+	}
+
+	key, cacheable := s.cacheKey(ctx, filter, opts)
+	if cacheable {
+		var stats entities.UserStats
+		if err := s.redisRepo.Get(ctx, key, &stats); err == nil {
+			return stats, nil
+		}
+	}
+
+	stats, err := s.userRepo.GetUserStats(ctx, filter, opts)
+	if err != nil {
+		return entities.UserStats{}, err
+	}
+
+	if cacheable {
+		s.redisRepo.Set(ctx, key, stats)
+	}
+	return stats, nil
+}
+
+// InvalidateStats logs failure too, because callers after mutations dont return it
+func (s *statsService) InvalidateStats(ctx context.Context) error {
+	_, err := s.redisRepo.Incr(ctx, statsGenerationKey)
+	if err != nil {
+		s.log.Warn("Failed to invalidate cached stats", sl.Err(err))
+	}
+	return err
+}
+
+func (s *statsService) cacheKey(ctx context.Context, filter entities.UserFilter, opts entities.StatsOptions) (string, bool) {
+	var generation int64
+	err := s.redisRepo.Get(ctx, statsGenerationKey, &generation)
+	if err != nil && !errors.Is(err, repository.ErrCacheMiss) {
+		return "", false
+	}
+
+	params, err := json.Marshal(struct {
+		Filter  entities.UserFilter
+		Options entities.StatsOptions
+	}{filter, opts})
+	if err != nil {
+		return "", false
+	}
+	hash := sha256.Sum256(params)
+
+	return "stats:" + strconv.FormatInt(generation, 10) + ":" + hex.EncodeToString(hash[:]), true
+}
+
+// invalidateStats is called after successful mutations, failure is only logged by InvalidateStats
+// because the change is already saved and outdated stats live only for cache ttl anyway
+func invalidateStats(stats StatsService) {
+	if stats != nil {
+		_ = stats.InvalidateStats(context.Background())
+	}
+}
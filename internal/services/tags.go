@@ -0,0 +1,118 @@
+package service
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Util787/user-manager-api/entities"
+	"golang.org/x/text/unicode/norm"
+)
+
+// tags are lowercase, letters without case (like CJK) and digits are allowed too
+var tagRe = regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}][\p{Ll}\p{Lo}\p{N}_-]{0,49}$`)
+
+// ParseTags parses comma separated tags of ?tag= filter, tags are normalized and repeated ones are skipped
+func ParseTags(tagsStr string) ([]string, error) {
+	tags := normalizeTags(strings.Split(tagsStr, ","))
+	for _, tag := range tags {
+		if !tagRe.MatchString(tag) {
+			return nil, NewParamError("tag", FieldErrInvalidTag)
+		}
+	}
+	if len(tags) == 0 {
+		return nil, NewParamError("tag", FieldErrNoTags)
+	}
+	return tags, nil
+}
+
+// normalizeTags trims and lowercases tags, empty and repeated tags are skipped
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(norm.NFC.String(tag)))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// checkTags adds errors of invalid tags to field.<index>, tags must be normalized
+func (v *validator) checkTags(field string, tags []string) {
+	for i, tag := range tags {
+		v.check(tagRe.MatchString(tag), field+"."+strconv.Itoa(i), FieldErrInvalidTag)
+	}
+}
+
+func (u *userService) GetUserTags(id int32) (entities.UserTags, error) {
+	tags, err := u.userRepo.GetUserTags(id)
+	if err != nil {
+		return entities.UserTags{}, domainError(err)
+	}
+	return entities.UserTags{UserId: id, Tags: tags}, nil
+}
+
+func (u *userService) AddUserTags(id int32, tags []string) (entities.UserTags, error) {
+	tags = normalizeTags(tags)
+	var v validator
+	v.check(len(tags) > 0, "tags", FieldErrRequired)
+	v.checkTags("tags", tags)
+	if err := v.err(); err != nil {
+		return entities.UserTags{}, err
+	}
+
+	result, err := u.userRepo.AddUserTags(id, tags)
+	if err != nil {
+		return entities.UserTags{}, domainError(err)
+	}
+	invalidateStats(u.stats)
+	return entities.UserTags{UserId: id, Tags: result}, nil
+}
+
+func (u *userService) RemoveUserTags(id int32, tags []string) (entities.UserTags, error) {
+	tags = normalizeTags(tags)
+	var v validator
+	v.check(len(tags) > 0, "tags", FieldErrRequired)
+	v.checkTags("tags", tags)
+	if err := v.err(); err != nil {
+		return entities.UserTags{}, err
+	}
+
+	result, err := u.userRepo.RemoveUserTags(id, tags)
+	if err != nil {
+		return entities.UserTags{}, domainError(err)
+	}
+	invalidateStats(u.stats)
+	return entities.UserTags{UserId: id, Tags: result}, nil
+}
+
+func (u *userService) BulkTagUsers(filter entities.BulkFilter, params entities.BulkTagsParams, opts entities.BulkOptions) (entities.BulkResult, error) {
+	add, remove := normalizeTags(params.Add), normalizeTags(params.Remove)
+	if len(add) == 0 && len(remove) == 0 {
+		return entities.BulkResult{}, &ValidationError{Fields: []FieldError{newFieldError("", FieldErrNoFields)}}
+	}
+	var v validator
+	v.checkTags("add", add)
+	v.checkTags("remove", remove)
+	for i, tag := range remove {
+		v.check(!slices.Contains(add, tag), "remove."+strconv.Itoa(i), FieldErrTagAddedAndRemoved)
+	}
+	if err := v.err(); err != nil {
+		return entities.BulkResult{}, err
+	}
+	var err error
+	if filter.UserFilter, err = typeFilter(u.attrRepo, filter.UserFilter); err != nil {
+		return entities.BulkResult{}, err
+	}
+
+	result, err := u.userRepo.BulkTagUsers(filter, add, remove, opts)
+	if err != nil {
+		return entities.BulkResult{}, domainError(err)
+	}
+	if !result.DryRun && result.Affected > 0 {
+		invalidateStats(u.stats)
+	}
+	return result, nil
+}
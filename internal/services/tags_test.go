@@ -0,0 +1,70 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		testname  string
+		tagsStr   string
+		expected  []string
+		expectErr bool
+	}{
+		{testname: "Normalized and unique", tagsStr: " VIP ,newsletter,vip,,", expected: []string{"vip", "newsletter"}},
+		{testname: "Cyrillic and digits", tagsStr: "Акция-2025,q3_campaign", expected: []string{"акция-2025", "q3_campaign"}},
+		{testname: "Space inside tag", tagsStr: "vip list", expectErr: true},
+		{testname: "Leading separator", tagsStr: "-vip", expectErr: true},
+		{testname: "Only commas", tagsStr: ",,", expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			tags, err := ParseTags(test.tagsStr)
+
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, tags)
+		})
+	}
+}
+
+// invalid input is rejected before the repository is called
+func TestBulkTagUsersValidation(t *testing.T) {
+	u := &userService{}
+
+	tests := []struct {
+		testname      string
+		params        entities.BulkTagsParams
+		expectedCodes []string
+	}{
+		{testname: "No tags", params: entities.BulkTagsParams{Add: []string{" "}}, expectedCodes: []string{FieldErrNoFields}},
+		{testname: "Invalid tag", params: entities.BulkTagsParams{Add: []string{"vip list"}}, expectedCodes: []string{FieldErrInvalidTag}},
+		{
+			testname:      "Tag added and removed",
+			params:        entities.BulkTagsParams{Add: []string{"vip"}, Remove: []string{"trial", "VIP"}},
+			expectedCodes: []string{FieldErrTagAddedAndRemoved},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			_, err := u.BulkTagUsers(entities.BulkFilter{Ids: []int32{1}}, test.params, entities.BulkOptions{})
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			codes := make([]string, len(validationErr.Fields))
+			for i, f := range validationErr.Fields {
+				codes[i] = f.Code
+			}
+			assert.Equal(t, test.expectedCodes, codes)
+		})
+	}
+}
@@ -1,47 +1,326 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/namenorm"
 	"github.com/Util787/user-manager-api/internal/repository"
 )
 
-// TODO: implement validation logic from handlers here
+// userService checks input and business rules, so they are the same for http handlers and any other caller.
+// Errors are ValidationError, ErrNotFound, ErrConflict or ErrEnrichmentFailed, see errors.go
 type userService struct {
-	userRepo repository.UserRepository
+	userRepo    repository.UserRepository
+	infoRequest InfoRequestService
+	// cached stats are invalidated after every mutation
+	stats StatsService
+	names NamePolicy
+	// definitions of custom attributes
+	attrRepo repository.AttributeRepository
 }
 
-func NewUserService(repo repository.UserRepository) UserService {
-	return &userService{userRepo: repo}
+func NewUserService(repo repository.UserRepository, infoRequest InfoRequestService, stats StatsService, names NamePolicy, attrRepo repository.AttributeRepository) UserService {
+	return &userService{userRepo: repo, infoRequest: infoRequest, stats: stats, names: names, attrRepo: attrRepo}
 }
 
-func (u *userService) CreateUser(params entities.User) (entities.User, error) {
-	return u.userRepo.CreateUser(params)
+// CreateUser normalizes and validates full name and attributes, fills age, gender and nationality from external apis and saves the user
+func (u *userService) CreateUser(fullName entities.FullName) (entities.User, error) {
+	fullName = normalizeFullName(fullName)
+	attrs, attrsErr := u.checkAttributes(fullName.Attributes)
+	if err := joinValidationErrors(validateFullName(u.names, fullName), attrsErr); err != nil {
+		return entities.User{}, err
+	}
+
+	age, gender, nationality, err := u.infoRequest.RequestAdditionalInfo(fullName.Name)
+	if err != nil {
+		return entities.User{}, fmt.Errorf("%w: %w", ErrEnrichmentFailed, err)
+	}
+
+	user, err := u.userRepo.CreateUser(entities.User{
+		Name:        fullName.Name,
+		Surname:     fullName.Surname,
+		Patronymic:  fullName.Patronymic,
+		Age:         age,
+		Gender:      gender,
+		Nationality: nationality,
+		Attributes:  attrs,
+	})
+	if err != nil {
+		return entities.User{}, domainError(err)
+	}
+	invalidateStats(u.stats)
+	return user, nil
 }
 
-func (u *userService) GetAllUsers(pageSize, page int, name, surname, patronymic, gender string) ([]entities.User, int, error) {
-	return u.userRepo.GetAllUsers(pageSize, page, name, surname, patronymic, gender)
+func (u *userService) GetAllUsers(pageSize, page int, filter entities.UserFilter, sort []entities.SortField, fields []string) ([]entities.User, int, error) {
+	filter, err := typeFilter(u.attrRepo, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return u.userRepo.GetAllUsers(pageSize, page, filter, sort, fields)
 }
 
-func (u *userService) ExistByFullName(params entities.FullName) (bool, error) {
-	// its wrong to check existance if patronymic is null because people might have same names and surnames
-	if params.Patronymic == "" {
-		return false, nil
+func (u *userService) GetUsersByCursor(filter entities.UserFilter, sort []entities.SortField, cursorStr string, limit int, withTotal bool, fields []string) (entities.UsersCursorPage, error) {
+	filter, err := typeFilter(u.attrRepo, filter)
+	if err != nil {
+		return entities.UsersCursorPage{}, err
+	}
+	sortStr := FormatSort(sort)
+
+	var cursor *entities.Cursor
+	if cursorStr != "" {
+		decoded, err := decodeCursor(cursorStr)
+		if err != nil {
+			return entities.UsersCursorPage{}, err
+		}
+		// values of another sort would point to a random place
+		if decoded.Sort != sortStr {
+			return entities.UsersCursorPage{}, ErrInvalidCursor
+		}
+		cursor = &decoded
+	}
+
+	// one extra row shows if there is something after this page
+	users, err := u.userRepo.GetUsersByCursor(filter, sort, cursor, limit+1, fields)
+	if errors.Is(err, repository.ErrInvalidCursorValues) {
+		return entities.UsersCursorPage{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if err != nil {
+		return entities.UsersCursorPage{}, err
+	}
+
+	hasMore := len(users) > limit
+	if hasMore {
+		users = users[:limit]
+	}
+
+	backward := cursor != nil && cursor.Backward
+	if backward {
+		slices.Reverse(users)
+	}
+
+	page := entities.UsersCursorPage{Data: users}
+	if len(users) > 0 {
+		first, last := users[0], users[len(users)-1]
+		// going backward we came from the next page so it always exists, going forward previous page exists if cursor was provided
+		if hasMore || backward {
+			values, err := repository.UserSortValues(last, sort)
+			if err != nil {
+				return entities.UsersCursorPage{}, err
+			}
+			page.NextCursor = encodeCursor(entities.Cursor{Values: values, Sort: sortStr})
+		}
+		if (backward && hasMore) || (!backward && cursor != nil) {
+			values, err := repository.UserSortValues(first, sort)
+			if err != nil {
+				return entities.UsersCursorPage{}, err
+			}
+			page.PrevCursor = encodeCursor(entities.Cursor{Values: values, Sort: sortStr, Backward: true})
+		}
 	}
-	return u.userRepo.ExistByFullName(params)
+
+	if withTotal {
+		totalCount, err := u.userRepo.CountUsers(filter)
+		if err != nil {
+			return entities.UsersCursorPage{}, err
+		}
+		page.TotalCount = &totalCount
+	}
+
+	return page, nil
 }
 
-func (u *userService) ExistById(id int32) (bool, error) {
-	return u.userRepo.ExistById(id)
+func (u *userService) GetUserById(id int32, fields []string) (entities.User, error) {
+	user, err := u.userRepo.GetUserById(id, fields)
+	return user, domainError(err)
 }
 
-func (u *userService) GetUserById(id int32) (entities.User, error) {
-	return u.userRepo.GetUserById(id)
+func (u *userService) GetUsersByIds(ids []int32) ([]entities.User, error) {
+	return u.userRepo.GetUsersByIds(ids)
 }
 
 func (u *userService) UpdateUser(id int32, params entities.UpdateUserParams) error {
-	return u.userRepo.UpdateUser(id, params)
+	params, err := u.checkUpdateUserParams(params)
+	if err != nil {
+		return err
+	}
+
+	err = u.userRepo.UpdateUser(id, params)
+	if err != nil {
+		return domainError(err)
+	}
+	invalidateStats(u.stats)
+	return nil
+}
+
+func (u *userService) ReplaceUser(id int32, params entities.ReplaceUserParams) error {
+	defs, err := u.attrRepo.GetDefinitions()
+	if err != nil {
+		return err
+	}
+	update, err := u.replaceParams(params, defs)
+	if err != nil {
+		return err
+	}
+
+	err = u.userRepo.UpdateUser(id, update)
+	if err != nil {
+		return domainError(err)
+	}
+	invalidateStats(u.stats)
+	return nil
+}
+
+// PatchUser parses patch before the user is locked, then applies it to the locked user and validates the result as full replacement
+func (u *userService) PatchUser(id int32, format string, patch []byte) (entities.User, error) {
+	apply, err := parsePatch(format, patch)
+	if err != nil {
+		return entities.User{}, err
+	}
+	// definitions are loaded before the user is locked
+	defs, err := u.attrRepo.GetDefinitions()
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	user, err := u.userRepo.PatchUser(id, func(user entities.User) (entities.UpdateUserParams, error) {
+		doc, err := userDocument(user)
+		if err != nil {
+			return entities.UpdateUserParams{}, err
+		}
+		// patches change doc in place
+		original, err := userDocument(user)
+		if err != nil {
+			return entities.UpdateUserParams{}, err
+		}
+
+		patched, err := apply(doc)
+		if err != nil {
+			return entities.UpdateUserParams{}, err
+		}
+		params, err := replaceParamsFromDocument(original, patched)
+		if err != nil {
+			return entities.UpdateUserParams{}, err
+		}
+		return u.replaceParams(params, defs)
+	})
+	if err != nil {
+		return entities.User{}, domainError(err)
+	}
+	invalidateStats(u.stats)
+	return user, nil
+}
+
+// replaceParams normalizes and validates full replacement, all fields of result are set
+func (u *userService) replaceParams(params entities.ReplaceUserParams, defs []entities.AttributeDefinition) (entities.UpdateUserParams, error) {
+	params.Name = namenorm.Display(params.Name)
+	params.Surname = namenorm.Display(params.Surname)
+	params.Patronymic = namenorm.Display(params.Patronymic)
+	attrs, attrsErr := validateAttributes(defs, params.Attributes)
+	if err := joinValidationErrors(validateReplaceUserParams(u.names, params), attrsErr); err != nil {
+		return entities.UpdateUserParams{}, err
+	}
+
+	return entities.UpdateUserParams{
+		Name:        &params.Name,
+		Surname:     &params.Surname,
+		Patronymic:  &params.Patronymic,
+		Age:         params.Age,
+		Gender:      &params.Gender,
+		Nationality: &params.Nationality,
+		Attributes:  &attrs,
+	}, nil
 }
 
 func (u *userService) DeleteUser(id int32) error {
-	return u.userRepo.DeleteUser(id)
+	err := u.userRepo.DeleteUser(id)
+	if err != nil {
+		return domainError(err)
+	}
+	invalidateStats(u.stats)
+	return nil
+}
+
+func (u *userService) BulkUpdateUsers(filter entities.BulkFilter, params entities.UpdateUserParams, opts entities.BulkOptions) (entities.BulkResult, error) {
+	if params == (entities.UpdateUserParams{}) {
+		return entities.BulkResult{}, &ValidationError{Fields: []FieldError{newFieldError("", FieldErrNoFields)}}
+	}
+	// attributes are saved as a whole map, in bulk that would wipe other attributes of every matched user
+	if params.Attributes != nil {
+		return entities.BulkResult{}, &ValidationError{Fields: []FieldError{newFieldError("attributes", FieldErrNotAllowedInBulk)}}
+	}
+	params, err := u.checkUpdateUserParams(params)
+	if err != nil {
+		return entities.BulkResult{}, err
+	}
+	if filter.UserFilter, err = typeFilter(u.attrRepo, filter.UserFilter); err != nil {
+		return entities.BulkResult{}, err
+	}
+
+	result, err := u.userRepo.BulkUpdateUsers(filter, params, opts)
+	if err != nil {
+		return entities.BulkResult{}, domainError(err)
+	}
+	if !result.DryRun && result.Affected > 0 {
+		invalidateStats(u.stats)
+	}
+	return result, nil
+}
+
+func (u *userService) BulkDeleteUsers(filter entities.BulkFilter, hard bool, opts entities.BulkOptions) (entities.BulkResult, error) {
+	var err error
+	if filter.UserFilter, err = typeFilter(u.attrRepo, filter.UserFilter); err != nil {
+		return entities.BulkResult{}, err
+	}
+
+	result, err := u.userRepo.BulkDeleteUsers(filter, hard, opts)
+	if err != nil {
+		return entities.BulkResult{}, domainError(err)
+	}
+	if !result.DryRun && result.Affected > 0 {
+		invalidateStats(u.stats)
+	}
+	return result, nil
+}
+
+func (u *userService) SearchUsers(query string, threshold float64, limit int) ([]entities.UserSearchResult, error) {
+	return u.userRepo.SearchUsers(strings.TrimSpace(query), threshold, limit)
+}
+
+// checkUpdateUserParams normalizes names and validates params, attributes are checked only if they are set
+func (u *userService) checkUpdateUserParams(params entities.UpdateUserParams) (entities.UpdateUserParams, error) {
+	params = normalizeNames(params)
+	var attrsErr error
+	if params.Attributes != nil {
+		var attrs entities.Attributes
+		attrs, attrsErr = u.checkAttributes(*params.Attributes)
+		params.Attributes = &attrs
+	}
+	return params, joinValidationErrors(validateUpdateUserParams(u.names, params), attrsErr)
+}
+
+// checkAttributes validates attributes against current definitions, null values are removed
+func (u *userService) checkAttributes(attrs entities.Attributes) (entities.Attributes, error) {
+	defs, err := u.attrRepo.GetDefinitions()
+	if err != nil {
+		return nil, err
+	}
+	return validateAttributes(defs, attrs)
+}
+
+// typeFilter converts values of attribute filters to types of their definitions
+func typeFilter(attrRepo repository.AttributeRepository, filter entities.UserFilter) (entities.UserFilter, error) {
+	if len(filter.Attributes) == 0 {
+		return filter, nil
+	}
+	defs, err := attrRepo.GetDefinitions()
+	if err != nil {
+		return entities.UserFilter{}, err
+	}
+	filter.Attributes, err = typeAttributeFilters(defs, filter.Attributes)
+	return filter, err
 }
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/Util787/user-manager-api/internal/namenorm"
+)
+
+func IsValidGender(gender string) bool {
+	return gender == "male" || gender == "female"
+}
+
+// validator collects errors of all invalid fields
+type validator struct {
+	fields []FieldError
+}
+
+// check adds error with code to field if ok is false
+func (v *validator) check(ok bool, field, code string) {
+	if !ok {
+		v.fields = append(v.fields, newFieldError(field, code))
+	}
+}
+
+// checkName adds error to field if name breaks the policy
+func (v *validator) checkName(policy NamePolicy, field, name string) {
+	if code, args := policy.check(name); code != "" {
+		v.fields = append(v.fields, newFieldError(field, code, args...))
+	}
+}
+
+// err returns *ValidationError if any check failed
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
+
+// patronymic is optional
+func validateFullName(policy NamePolicy, fullName entities.FullName) error {
+	var v validator
+	v.checkName(policy, "name", fullName.Name)
+	v.checkName(policy, "surname", fullName.Surname)
+	if fullName.Patronymic != "" {
+		v.checkName(policy, "patronymic", fullName.Patronymic)
+	}
+	return v.err()
+}
+
+// only provided fields are checked, empty patronymic removes it
+func validateUpdateUserParams(policy NamePolicy, params entities.UpdateUserParams) error {
+	var v validator
+	if params.Name != nil {
+		v.checkName(policy, "name", *params.Name)
+	}
+	if params.Surname != nil {
+		v.checkName(policy, "surname", *params.Surname)
+	}
+	if params.Patronymic != nil && *params.Patronymic != "" {
+		v.checkName(policy, "patronymic", *params.Patronymic)
+	}
+	v.check(params.Age == nil || *params.Age >= 0, "age", FieldErrNegativeAge)
+	v.check(params.Gender == nil || IsValidGender(*params.Gender), "gender", FieldErrInvalidGender)
+	return v.err()
+}
+
+// all fields are required except patronymic and nationality
+func validateReplaceUserParams(policy NamePolicy, params entities.ReplaceUserParams) error {
+	var v validator
+	for _, part := range []struct{ field, name string }{{"name", params.Name}, {"surname", params.Surname}} {
+		if part.name == "" {
+			v.check(false, part.field, FieldErrRequired)
+			continue
+		}
+		v.checkName(policy, part.field, part.name)
+	}
+	if params.Patronymic != "" {
+		v.checkName(policy, "patronymic", params.Patronymic)
+	}
+	v.check(params.Age != nil, "age", FieldErrRequired)
+	v.check(params.Age == nil || *params.Age >= 0, "age", FieldErrNegativeAge)
+	v.check(IsValidGender(params.Gender), "gender", FieldErrRequiredGender)
+	return v.err()
+}
+
+// normalizeFullName turns name parts to display form before validation, so " ivan " is saved as "Ivan"
+func normalizeFullName(fullName entities.FullName) entities.FullName {
+	fullName.Name = namenorm.Display(fullName.Name)
+	fullName.Surname = namenorm.Display(fullName.Surname)
+	fullName.Patronymic = namenorm.Display(fullName.Patronymic)
+	return fullName
+}
+
+// normalizeNames turns provided name parts to display form, see normalizeFullName
+func normalizeNames(params entities.UpdateUserParams) entities.UpdateUserParams {
+	for _, part := range []**string{&params.Name, &params.Surname, &params.Patronymic} {
+		if *part != nil {
+			normalized := namenorm.Display(**part)
+			*part = &normalized
+		}
+	}
+	return params
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Util787/user-manager-api/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFullName(t *testing.T) {
+	policy, err := NewNamePolicy(defaultNamePolicyConfig)
+	require.NoError(t, err)
+
+	tests := []struct {
+		testname       string
+		fullName       entities.FullName
+		expectedFields []FieldError
+	}{
+		{testname: "Valid without patronymic", fullName: entities.FullName{Name: "Ivan", Surname: "Ivanov"}},
+		{testname: "Valid with patronymic", fullName: entities.FullName{Name: "Пётр", Surname: "Римский-Корсаков", Patronymic: "Ильич"}},
+		{
+			testname:       "Empty name",
+			fullName:       entities.FullName{Name: "", Surname: "Ivanov"},
+			expectedFields: []FieldError{newFieldError("name", FieldErrNameTooShort, 2)},
+		},
+		{
+			testname: "All parts invalid",
+			fullName: entities.FullName{Name: "Ivan1", Surname: "ivanov", Patronymic: "Ivanоvich"},
+			expectedFields: []FieldError{
+				newFieldError("name", FieldErrNameInvalidChars),
+				newFieldError("surname", FieldErrNameNotCapitalized),
+				newFieldError("patronymic", FieldErrNameMixedScripts),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			err := validateFullName(policy, test.fullName)
+
+			if test.expectedFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, test.expectedFields, validationErr.Fields)
+		})
+	}
+}
+
+func TestValidateUpdateUserParams(t *testing.T) {
+	policy, err := NewNamePolicy(defaultNamePolicyConfig)
+	require.NoError(t, err)
+
+	str := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+
+	tests := []struct {
+		testname      string
+		params        entities.UpdateUserParams
+		expectedCodes []string
+	}{
+		{testname: "Nothing provided", params: entities.UpdateUserParams{}},
+		{testname: "Valid fields", params: entities.UpdateUserParams{Name: str("Anna"), Age: num(0), Gender: str("female")}},
+		{testname: "Empty patronymic removes it", params: entities.UpdateUserParams{Patronymic: str("")}},
+		{testname: "Invalid patronymic", params: entities.UpdateUserParams{Patronymic: str("I")}, expectedCodes: []string{FieldErrNameTooShort}},
+		{testname: "Empty surname", params: entities.UpdateUserParams{Surname: str("")}, expectedCodes: []string{FieldErrNameTooShort}},
+		{
+			testname:      "All invalid fields are listed",
+			params:        entities.UpdateUserParams{Name: str("ivan"), Age: num(-1), Gender: str("unknown")},
+			expectedCodes: []string{FieldErrNameNotCapitalized, FieldErrNegativeAge, FieldErrInvalidGender},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			err := validateUpdateUserParams(policy, test.params)
+
+			if test.expectedCodes == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			codes := make([]string, len(validationErr.Fields))
+			for i, f := range validationErr.Fields {
+				codes[i] = f.Code
+			}
+			assert.Equal(t, test.expectedCodes, codes)
+		})
+	}
+}
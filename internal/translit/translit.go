@@ -0,0 +1,144 @@
+// Package translit converts cyrillic names to latin and builds search keys,
+// so that "Ivan", "Iwan" and "Иван" can be found by each other
+package translit
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+type Scheme int
+
+const (
+	// ISO9 is GOST 7.79-2000 system A, one latin letter (with diacritics) for every cyrillic letter, reversible
+	ISO9 Scheme = iota
+	// ICAO is ICAO Doc 9303 used in russian passports since 2013
+	ICAO
+	// Simple is the common informal scheme (close to BGN/PCGN): й - y, ю - yu, я - ya
+	Simple
+)
+
+var iso9Table = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "ë", 'ж': "ž", 'з': "z", 'и': "i", 'й': "j",
+	'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+	'х': "h", 'ц': "c", 'ч': "č", 'ш': "š", 'щ': "ŝ", 'ъ': "ʺ", 'ы': "y", 'ь': "ʹ", 'э': "è", 'ю': "û", 'я': "â",
+	'і': "ì", 'ї': "ï", 'є': "ê", 'ґ': "g̀", 'ў': "ǔ",
+}
+
+var icaoTable = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i", 'й': "i",
+	'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+	'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "ie", 'ы': "y", 'ь': "", 'э': "e", 'ю': "iu", 'я': "ia",
+	'і': "i", 'ї': "i", 'є': "ie", 'ґ': "g", 'ў': "u",
+}
+
+var simpleTable = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh", 'з': "z", 'и': "i", 'й': "y",
+	'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+	'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+}
+
+var tables = map[Scheme]map[rune]string{
+	ISO9:   iso9Table,
+	ICAO:   icaoTable,
+	Simple: simpleTable,
+}
+
+// ToLatin transliterates cyrillic letters of s by scheme, other characters are kept as is.
+// Case is kept: "Жанна" becomes "Zhanna", "ЖАННА" becomes "ZHANNA"
+func ToLatin(s string, scheme Scheme) string {
+	table := tables[scheme]
+	runes := []rune(s)
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for i, r := range runes {
+		latin, ok := table[unicode.ToLower(r)]
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+		if !unicode.IsUpper(r) || latin == "" {
+			b.WriteString(latin)
+			continue
+		}
+
+		// digraph of upper case letter is upper case too if the next letter is upper case
+		if i+1 < len(runes) && unicode.IsUpper(runes[i+1]) {
+			b.WriteString(strings.ToUpper(latin))
+			continue
+		}
+		first := []rune(latin)
+		b.WriteRune(unicode.ToUpper(first[0]))
+		b.WriteString(string(first[1:]))
+	}
+
+	return b.String()
+}
+
+// latin letters with diacritics from ISO 9 and other schemes, so that keys of ISO 9 and informal spelling are the same
+var diacriticsFolding = strings.NewReplacer(
+	"ž", "zh", "č", "ch", "š", "sh", "ŝ", "shch", "û", "yu", "â", "ya", "ë", "yo", "è", "e", "ì", "i", "ï", "yi", "ê", "ye",
+	"g̀", "g", "ǔ", "u", "ʺ", "", "ʹ", "", "'", "", "’", "", "`", "",
+)
+
+// common differences of informal spellings, applied in order
+var spellingFolding = []struct{ from, to string }{
+	{"x", "ks"},
+	{"w", "v"},
+	{"q", "k"},
+	{"shch", "sch"},
+	{"kh", "h"},
+	{"tz", "ts"},
+	{"j", "y"},
+	// Andrey, Andrei, Andrej
+	{"y", "i"},
+	// Fyodor, Fedor, Фёдор
+	{"io", "e"},
+}
+
+// SearchKey returns normalized lower case latin key of a name, keys of cyrillic name and of its latin spellings are the same:
+// "Юрий", "Yuriy", "Yuri", "Jurij" and "Iurii" all become "iuri". Key is lossy and used only for search
+func SearchKey(s string) string {
+	s = strings.ToLower(norm.NFC.String(strings.TrimSpace(s)))
+	s = ToLatin(s, Simple)
+	s = diacriticsFolding.Replace(s)
+
+	words := strings.Fields(s)
+	for i, word := range words {
+		words[i] = foldWord(word)
+	}
+	return strings.Join(words, " ")
+}
+
+func foldWord(word string) string {
+	// ц is already ts here, so c is latin c of Victor or Nicolai. ch is ч
+	word = strings.ReplaceAll(word, "ch", "\x00")
+	word = strings.ReplaceAll(word, "ck", "k")
+	word = strings.ReplaceAll(word, "c", "k")
+	word = strings.ReplaceAll(word, "\x00", "ch")
+
+	for _, f := range spellingFolding {
+		word = strings.ReplaceAll(word, f.from, f.to)
+	}
+
+	// Yevgeny, Evgeny, Евгений
+	if strings.HasPrefix(word, "ie") {
+		word = word[1:]
+	}
+
+	// Iurii, Yuri
+	for strings.Contains(word, "ii") {
+		word = strings.ReplaceAll(word, "ii", "i")
+	}
+
+	// Alexander, Aleksandr, Александр and Peter, Petr
+	if n := len(word); n > 3 && strings.HasSuffix(word, "er") && !strings.ContainsRune("aeiou", rune(word[n-3])) {
+		word = word[:n-2] + "r"
+	}
+
+	return word
+}
@@ -0,0 +1,62 @@
+package translit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToLatin(t *testing.T) {
+	tests := []struct {
+		testname string
+		name     string
+		scheme   Scheme
+		expected string
+	}{
+		{testname: "ISO 9", name: "Щукин", scheme: ISO9, expected: "Ŝukin"},
+		{testname: "ICAO", name: "Юрий", scheme: ICAO, expected: "Iurii"},
+		{testname: "Simple", name: "Юрий", scheme: Simple, expected: "Yuriy"},
+		{testname: "Digraph of upper case letter", name: "Жанна", scheme: Simple, expected: "Zhanna"},
+		{testname: "Upper case word", name: "ЖАННА", scheme: Simple, expected: "ZHANNA"},
+		{testname: "Soft sign is dropped", name: "Игорь", scheme: Simple, expected: "Igor"},
+		{testname: "Latin is kept", name: "Anna-Мария", scheme: Simple, expected: "Anna-Mariya"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			assert.Equal(t, test.expected, ToLatin(test.name, test.scheme))
+		})
+	}
+}
+
+func TestSearchKey(t *testing.T) {
+	tests := []struct {
+		testname string
+		a, b     string
+		equal    bool
+	}{
+		{testname: "Latin c", a: "Victor", b: "Виктор", equal: true},
+		{testname: "Latin c and k", a: "Nicolai", b: "Nikolay", equal: true},
+		{testname: "ck", a: "Frederick", b: "Фредерик", equal: true},
+		{testname: "ц", a: "Tsvetkov", b: "Цветков", equal: true},
+		{testname: "tz", a: "Tzoi", b: "Цой", equal: true},
+		{testname: "ch", a: "Chekhov", b: "Чехов", equal: true},
+		{testname: "x", a: "Alexander", b: "Aleksandr", equal: true},
+		{testname: "er ending", a: "Alexander", b: "Александр", equal: true},
+		{testname: "shch", a: "Shchukin", b: "Щукин", equal: true},
+		{testname: "sch", a: "Schukin", b: "Щукин", equal: true},
+		{testname: "y and i", a: "Yuri", b: "Iurii", equal: true},
+		{testname: "j", a: "Jurij", b: "Юрий", equal: true},
+		{testname: "ё", a: "Fyodor", b: "Фёдор", equal: true},
+		{testname: "Initial ye", a: "Yevgeny", b: "Евгений", equal: true},
+		{testname: "ISO 9 diacritics", a: "Ŝukin", b: "Щукин", equal: true},
+		{testname: "Case and spaces", a: " ANNA  MARIA ", b: "Анна Мария", equal: true},
+		{testname: "Different names", a: "Victor", b: "Viktoria", equal: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testname, func(t *testing.T) {
+			assert.Equal(t, test.equal, SearchKey(test.a) == SearchKey(test.b))
+		})
+	}
+}
@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMPTZ;
+-- most queries read only live rows
+CREATE INDEX idx_users_deleted_at ON users (deleted_at) WHERE deleted_at IS NULL;
@@ -0,0 +1,6 @@
+DROP INDEX idx_users_patronymic_key_trgm;
+DROP INDEX idx_users_surname_key_trgm;
+DROP INDEX idx_users_name_key_trgm;
+ALTER TABLE users DROP COLUMN patronymic_key;
+ALTER TABLE users DROP COLUMN surname_key;
+ALTER TABLE users DROP COLUMN name_key;
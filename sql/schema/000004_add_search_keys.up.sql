@@ -0,0 +1,9 @@
+-- normalized latin keys of name parts, see internal/translit.SearchKey
+-- existing rows are filled by: go run ./cmd reindex
+ALTER TABLE users ADD COLUMN name_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN surname_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN patronymic_key TEXT NOT NULL DEFAULT '';
+-- used by list filters (ILIKE) and fuzzy search (similarity)
+CREATE INDEX idx_users_name_key_trgm ON users USING gin (name_key gin_trgm_ops);
+CREATE INDEX idx_users_surname_key_trgm ON users USING gin (surname_key gin_trgm_ops);
+CREATE INDEX idx_users_patronymic_key_trgm ON users USING gin (patronymic_key gin_trgm_ops);
@@ -0,0 +1,12 @@
+-- history of merged duplicates, both users are saved as they were before the merge.
+-- source user is soft deleted, so ids are not foreign keys to keep history after hard deletes
+CREATE TABLE user_merges(
+    id SERIAL PRIMARY KEY,
+    target_id INT NOT NULL,
+    source_id INT NOT NULL,
+    target_before JSONB NOT NULL,
+    source_before JSONB NOT NULL,
+    merged_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_user_merges_target_id ON user_merges (target_id);
+CREATE INDEX idx_user_merges_source_id ON user_merges (source_id);
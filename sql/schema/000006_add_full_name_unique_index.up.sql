@@ -0,0 +1,5 @@
+-- full names are compared case insensitive, only live users with patronymic are checked by default.
+-- scope can be changed without downtime by: go run ./cmd uniqueness -scope all|patronymic|off
+-- fails if live users already have equal full names, merge them first (GET /api/users/duplicates)
+CREATE UNIQUE INDEX idx_users_full_name_unique ON users (lower(name), lower(surname), lower(COALESCE(patronymic, '')))
+    WHERE deleted_at IS NULL AND COALESCE(patronymic, '') <> '';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_users_full_name_unique;
+CREATE UNIQUE INDEX idx_users_full_name_unique ON users (lower(name), lower(surname), lower(COALESCE(patronymic, '')))
+    WHERE deleted_at IS NULL AND COALESCE(patronymic, '') <> '';
+ALTER TABLE users DROP COLUMN patronymic_norm;
+ALTER TABLE users DROP COLUMN surname_norm;
+ALTER TABLE users DROP COLUMN name_norm;
@@ -0,0 +1,14 @@
+-- normalized keys of name parts for equality checks, see internal/namenorm.Key
+-- the same as keys of the app for names saved through the api, go run ./cmd reindex recalculates them anyway
+ALTER TABLE users ADD COLUMN name_norm TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN surname_norm TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN patronymic_norm TEXT NOT NULL DEFAULT '';
+UPDATE users SET
+    name_norm = replace(replace(lower(regexp_replace(btrim(normalize(name, NFC)), '\s+', ' ', 'g')), 'ё', 'е'), '’', ''''),
+    surname_norm = replace(replace(lower(regexp_replace(btrim(normalize(surname, NFC)), '\s+', ' ', 'g')), 'ё', 'е'), '’', ''''),
+    patronymic_norm = replace(replace(lower(regexp_replace(btrim(normalize(COALESCE(patronymic, ''), NFC)), '\s+', ' ', 'g')), 'ё', 'е'), '’', '''');
+-- full names are compared by normalized keys now, so "Пётр" and "ПЕТР" are equal.
+-- fails if live users already have equal keys, merge them first (GET /api/users/duplicates)
+DROP INDEX IF EXISTS idx_users_full_name_unique;
+CREATE UNIQUE INDEX idx_users_full_name_unique ON users (name_norm, surname_norm, patronymic_norm)
+    WHERE deleted_at IS NULL AND patronymic_norm <> '';
@@ -0,0 +1,3 @@
+DROP TABLE attribute_definitions;
+DROP INDEX idx_users_attributes;
+ALTER TABLE users DROP COLUMN attributes;
@@ -0,0 +1,17 @@
+-- custom attributes of users, keys and values are described by attribute_definitions
+ALTER TABLE users ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+-- used by ?attr.<name>= filters, they are jsonpath equality checks (@@)
+CREATE INDEX idx_users_attributes ON users USING gin (attributes jsonb_path_ops);
+
+CREATE TABLE attribute_definitions(
+    name TEXT PRIMARY KEY,
+    type TEXT NOT NULL CHECK (type IN ('string', 'number', 'boolean')),
+    required BOOLEAN NOT NULL DEFAULT false,
+    -- allowed values, json array of values of the type, empty means any value
+    enum JSONB NOT NULL DEFAULT '[]',
+    -- RE2 regular expression for string values, empty means any value
+    pattern TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
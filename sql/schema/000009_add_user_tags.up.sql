@@ -0,0 +1,16 @@
+-- tags are created on first use, names are lowercase
+CREATE TABLE tags(
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+-- hard deleted users lose their tags, soft deleted keep them
+CREATE TABLE user_tags(
+    user_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    tag_id INT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, tag_id)
+);
+-- used by ?tag= filters and tag counts, user_id lookups use the primary key
+CREATE INDEX idx_user_tags_tag_id ON user_tags (tag_id);
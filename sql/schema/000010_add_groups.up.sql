@@ -0,0 +1,24 @@
+-- groups form a tree, a group with subgroups cant be deleted
+CREATE TABLE groups(
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    parent_id INT REFERENCES groups (id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+-- names are unique among siblings, top level groups are siblings too
+CREATE UNIQUE INDEX idx_groups_parent_name_unique ON groups (COALESCE(parent_id, 0), lower(name));
+CREATE INDEX idx_groups_parent_id ON groups (parent_id);
+
+-- hard deleted users and deleted groups lose their memberships, soft deleted users keep them
+CREATE TABLE group_members(
+    group_id INT NOT NULL REFERENCES groups (id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (group_id, user_id)
+);
+-- used by groups of a user, group_id lookups use the primary key
+CREATE INDEX idx_group_members_user_id ON group_members (user_id);
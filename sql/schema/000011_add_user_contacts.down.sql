@@ -0,0 +1,2 @@
+DROP TABLE user_phones;
+DROP TABLE user_emails;
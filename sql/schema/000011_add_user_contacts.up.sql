@@ -0,0 +1,31 @@
+-- emails and phones of users, values are unique across all users (soft deleted too).
+-- Every user with contacts of a kind has exactly one primary contact of that kind
+CREATE TABLE user_emails(
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('personal', 'work', 'other')),
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    verified_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+-- emails are compared case insensitive
+CREATE UNIQUE INDEX idx_user_emails_email_unique ON user_emails (lower(email));
+CREATE UNIQUE INDEX idx_user_emails_primary ON user_emails (user_id) WHERE is_primary;
+CREATE INDEX idx_user_emails_user_id ON user_emails (user_id);
+
+-- phones are stored in E.164
+CREATE TABLE user_phones(
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    phone TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('mobile', 'home', 'work', 'other')),
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    verified_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE UNIQUE INDEX idx_user_phones_phone_unique ON user_phones (phone);
+CREATE UNIQUE INDEX idx_user_phones_primary ON user_phones (user_id) WHERE is_primary;
+CREATE INDEX idx_user_phones_user_id ON user_phones (user_id);